@@ -1812,7 +1812,8 @@ func (cs *State) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
 		// But if it's a conflicting sig, add it to the cs.evpool.
 		// If it's otherwise invalid, punish peer.
 		// nolint: gocritic
-		if voteErr, ok := err.(*types.ErrVoteConflictingVotes); ok {
+		var voteErr *types.ErrVoteConflictingVotes
+		if errors.As(err, &voteErr) {
 			if cs.privValidatorPubKey == nil {
 				return false, errPubKeyIsNotSet
 			}
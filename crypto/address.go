@@ -0,0 +1,28 @@
+package crypto
+
+// AddressHasher derives the address of a public key.
+//
+// Chains using another address scheme than the default one of each key type
+// (see PubKey.Address) pass their AddressHasher explicitly where addresses
+// are derived, e.g. to types.NewValidatorWithAddressHasher.
+type AddressHasher interface {
+	Address(pubKey PubKey) Address
+}
+
+// AddressHasherFunc is an adapter to allow the use of an ordinary function as
+// an AddressHasher.
+type AddressHasherFunc func(pubKey PubKey) Address
+
+// Address calls f(pubKey).
+func (f AddressHasherFunc) Address(pubKey PubKey) Address {
+	return f(pubKey)
+}
+
+// PubKeyAddressHasher is the AddressHasher using the default scheme of each
+// key type, i.e. PubKey.Address.
+type PubKeyAddressHasher struct{}
+
+// Address returns pubKey.Address().
+func (PubKeyAddressHasher) Address(pubKey PubKey) Address {
+	return pubKey.Address()
+}
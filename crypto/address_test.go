@@ -0,0 +1,27 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
+)
+
+func TestAddressHasher(t *testing.T) {
+	identity := crypto.AddressHasherFunc(func(pubKey crypto.PubKey) crypto.Address {
+		return crypto.Address(pubKey.Bytes())
+	})
+
+	for _, pubKey := range []crypto.PubKey{
+		ed25519.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(),
+		sr25519.GenPrivKey().PubKey(),
+	} {
+		assert.Equal(t, pubKey.Address(), crypto.PubKeyAddressHasher{}.Address(pubKey), pubKey.Type())
+		assert.Equal(t, crypto.Address(pubKey.Bytes()), identity.Address(pubKey), pubKey.Type())
+	}
+}
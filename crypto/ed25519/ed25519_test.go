@@ -28,3 +28,13 @@ func TestSignAndValidateEd25519(t *testing.T) {
 
 	assert.False(t, pubKey.VerifySignature(msg, sig))
 }
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	privKey := ed25519.GenPrivKeyFromSecret([]byte("mySecret"))
+
+	// the same secret always yields the same key, on every platform
+	assert.Equal(t, privKey, ed25519.GenPrivKeyFromSecret([]byte("mySecret")))
+	assert.Equal(t, "B50F63C6DD36003860E2FCC720B8335A76015686", privKey.PubKey().Address().String())
+
+	assert.NotEqual(t, privKey, ed25519.GenPrivKeyFromSecret([]byte("myOtherSecret")))
+}
@@ -0,0 +1,90 @@
+package ed25519
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+const (
+	// PrivKeyPEMType is the PEM block type of PKCS #8 encoded private keys.
+	PrivKeyPEMType = "PRIVATE KEY"
+	// PubKeyPEMType is the PEM block type of PKIX encoded public keys.
+	PubKeyPEMType = "PUBLIC KEY"
+)
+
+// MarshalPEM encodes the private key as a PEM block of type PrivKeyPEMType
+// holding its PKCS #8 (RFC 8410) encoding, for use with standard tooling.
+func (privKey PrivKey) MarshalPEM() ([]byte, error) {
+	if len(privKey) != PrivateKeySize {
+		return nil, fmt.Errorf("invalid private key size: %d", len(privKey))
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(ed25519.PrivateKey(privKey))
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PrivKeyPEMType, Bytes: der}), nil
+}
+
+// ParsePrivKeyPEM decodes a private key encoded by PrivKey.MarshalPEM. Any
+// data after the first PEM block is ignored.
+func ParsePrivKeyPEM(bz []byte) (PrivKey, error) {
+	der, err := decodePEM(bz, PrivKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	privKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an ed25519 private key: %T", key)
+	}
+	return PrivKey(privKey), nil
+}
+
+// MarshalPEM encodes the public key as a PEM block of type PubKeyPEMType
+// holding its PKIX (RFC 8410) encoding, for use with standard tooling.
+func (pubKey PubKey) MarshalPEM() ([]byte, error) {
+	if len(pubKey) != PubKeySize {
+		return nil, fmt.Errorf("invalid public key size: %d", len(pubKey))
+	}
+	der, err := x509.MarshalPKIXPublicKey(ed25519.PublicKey(pubKey))
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PubKeyPEMType, Bytes: der}), nil
+}
+
+// ParsePubKeyPEM decodes a public key encoded by PubKey.MarshalPEM. Any data
+// after the first PEM block is ignored.
+func ParsePubKeyPEM(bz []byte) (PubKey, error) {
+	der, err := decodePEM(bz, PubKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("not an ed25519 public key: %T", key)
+	}
+	return PubKey(pubKey), nil
+}
+
+func decodePEM(bz []byte, blockType string) ([]byte, error) {
+	block, _ := pem.Decode(bz)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if block.Type != blockType {
+		return nil, fmt.Errorf("unexpected PEM block type %q, want %q", block.Type, blockType)
+	}
+	return block.Bytes, nil
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
 	pc "github.com/tendermint/tendermint/proto/tendermint/crypto"
 )
 
@@ -73,3 +75,16 @@ func PrivKeyFromProto(k pc.PrivateKey) (crypto.PrivKey, error) {
 		return nil, errors.New("fromproto: key type not supported")
 	}
 }
+
+// PubKeyTypeName returns the name of the key type of k (e.g. "ed25519" or
+// "secp256k1"), or "unknown" if the type is not supported. Unlike addresses,
+// which have the same length for all key types, the name identifies the
+// scheme.
+func PubKeyTypeName(k crypto.PubKey) string {
+	switch k.(type) {
+	case ed25519.PubKey, secp256k1.PubKey, sr25519.PubKey:
+		return k.Type()
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,34 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
+)
+
+type unknownPubKey struct {
+	crypto.PubKey
+}
+
+func TestPubKeyTypeName(t *testing.T) {
+	testCases := []struct {
+		pubKey crypto.PubKey
+		name   string
+	}{
+		{ed25519.GenPrivKey().PubKey(), "ed25519"},
+		{secp256k1.GenPrivKey().PubKey(), "secp256k1"},
+		{sr25519.GenPrivKey().PubKey(), "sr25519"},
+		{unknownPubKey{}, "unknown"},
+		{nil, "unknown"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.name, PubKeyTypeName(tc.pubKey))
+	}
+	// addresses don't tell the key types apart
+	assert.Equal(t, len(testCases[0].pubKey.Address()), len(testCases[1].pubKey.Address()))
+}
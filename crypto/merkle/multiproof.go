@@ -0,0 +1,132 @@
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// MultiProof is a Merkle proof of the inclusion of several items at once.
+// Unlike a set of Proofs, the hashes of the subtrees shared by the proven
+// items are not repeated: Aunts only holds the roots of the maximal subtrees
+// which don't contain any proven item.
+type MultiProof struct {
+	Total   int64    `json:"total"`   // Total number of items.
+	Indices []int64  `json:"indices"` // Indices of the proven items, sorted.
+	Aunts   [][]byte `json:"aunts"`   // Hashes of the other subtrees, from left to right.
+}
+
+// MultiProofFromByteSlices computes the proof of inclusion of the items at
+// the given indices in the Merkle tree of items (see HashFromByteSlices). An
+// error is returned if there are no indices, they are out of range or an
+// index is repeated.
+func MultiProofFromByteSlices(items [][]byte, indices []int) (*MultiProof, error) {
+	if len(indices) == 0 {
+		return nil, errors.New("no indices")
+	}
+	sorted := make([]int64, len(indices))
+	for i, idx := range indices {
+		if idx < 0 || idx >= len(items) {
+			return nil, fmt.Errorf("index %d out of range [0, %d)", idx, len(items))
+		}
+		sorted[i] = int64(idx)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return nil, fmt.Errorf("duplicate index %d", sorted[i])
+		}
+	}
+
+	mp := &MultiProof{Total: int64(len(items)), Indices: sorted}
+	mp.collectAunts(items, 0, sorted)
+	return mp, nil
+}
+
+// collectAunts appends the aunts of the subtree of items, starting at offset,
+// in which the given indices are proven.
+func (mp *MultiProof) collectAunts(items [][]byte, offset int64, indices []int64) {
+	if len(indices) == 0 {
+		mp.Aunts = append(mp.Aunts, HashFromByteSlices(items))
+		return
+	}
+	if len(items) == 1 {
+		return
+	}
+	k := getSplitPoint(int64(len(items)))
+	split := sort.Search(len(indices), func(i int) bool { return indices[i] >= offset+k })
+	mp.collectAunts(items[:k], offset, indices[:split])
+	mp.collectAunts(items[k:], offset+k, indices[split:])
+}
+
+// VerifyMultiProof returns an error unless the proof proves that leaves, a
+// map of index to item, are included in the Merkle tree with the given root.
+// leaves must contain exactly the proven indices.
+func VerifyMultiProof(root []byte, leaves map[int][]byte, proof *MultiProof) error {
+	if proof == nil {
+		return errors.New("nil proof")
+	}
+	if proof.Total <= 0 {
+		return errors.New("proof total must be positive")
+	}
+	if len(proof.Indices) == 0 {
+		return errors.New("proof has no indices")
+	}
+	if len(leaves) != len(proof.Indices) {
+		return fmt.Errorf("expected %d leaves, got %d", len(proof.Indices), len(leaves))
+	}
+	hashes := make([][]byte, len(proof.Indices))
+	for i, idx := range proof.Indices {
+		if idx < 0 || idx >= proof.Total || (i > 0 && idx <= proof.Indices[i-1]) {
+			return errors.New("proof indices must be sorted, unique and less than total")
+		}
+		leaf, ok := leaves[int(idx)]
+		if !ok {
+			return fmt.Errorf("missing leaf %d", idx)
+		}
+		hashes[i] = leafHash(leaf)
+	}
+
+	aunts := proof.Aunts
+	computed, err := computeHashFromMultiProof(0, proof.Total, proof.Indices, hashes, &aunts)
+	if err != nil {
+		return err
+	}
+	if len(aunts) != 0 {
+		return fmt.Errorf("%d unused aunts", len(aunts))
+	}
+	if !bytes.Equal(computed, root) {
+		return fmt.Errorf("invalid root hash: wanted %X got %X", root, computed)
+	}
+	return nil
+}
+
+// computeHashFromMultiProof returns the root of the subtree of the given
+// total, starting at offset, given the hashes of the proven leaves at the
+// given indices and consuming the aunts it needs.
+func computeHashFromMultiProof(offset, total int64, indices []int64, hashes [][]byte,
+	aunts *[][]byte) ([]byte, error) {
+	if len(indices) == 0 {
+		if len(*aunts) == 0 {
+			return nil, errors.New("not enough aunts")
+		}
+		aunt := (*aunts)[0]
+		*aunts = (*aunts)[1:]
+		return aunt, nil
+	}
+	if total == 1 {
+		return hashes[0], nil
+	}
+	k := getSplitPoint(total)
+	split := sort.Search(len(indices), func(i int) bool { return indices[i] >= offset+k })
+	left, err := computeHashFromMultiProof(offset, k, indices[:split], hashes[:split], aunts)
+	if err != nil {
+		return nil, err
+	}
+	right, err := computeHashFromMultiProof(offset+k, total-k, indices[split:], hashes[split:], aunts)
+	if err != nil {
+		return nil, err
+	}
+	return innerHash(left, right), nil
+}
@@ -0,0 +1,82 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestMultiProof(t *testing.T) {
+	items := make([][]byte, 8)
+	for i := range items {
+		items[i] = tmrand.Bytes(32)
+	}
+	root := HashFromByteSlices(items)
+
+	proof, err := MultiProofFromByteSlices(items, []int{2, 0, 1})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 1, 2}, proof.Indices)
+	// the sibling of 2 and the right half, vs 3 aunts for each single proof
+	assert.Len(t, proof.Aunts, 2)
+
+	leaves := map[int][]byte{0: items[0], 1: items[1], 2: items[2]}
+	require.NoError(t, VerifyMultiProof(root, leaves, proof))
+
+	// tampered leaf
+	tampered := map[int][]byte{0: items[0], 1: items[3], 2: items[2]}
+	assert.Error(t, VerifyMultiProof(root, tampered, proof))
+
+	// missing or unexpected index
+	assert.Error(t, VerifyMultiProof(root, map[int][]byte{0: items[0], 1: items[1]}, proof))
+	assert.Error(t, VerifyMultiProof(root, map[int][]byte{0: items[0], 1: items[1], 3: items[3]}, proof))
+
+	// wrong root
+	assert.Error(t, VerifyMultiProof(HashFromByteSlices(items[1:]), leaves, proof))
+
+	// tampered proof
+	bad := *proof
+	bad.Aunts = proof.Aunts[:1]
+	assert.Error(t, VerifyMultiProof(root, leaves, &bad))
+	bad.Aunts = append(append([][]byte(nil), proof.Aunts...), proof.Aunts[0])
+	assert.Error(t, VerifyMultiProof(root, leaves, &bad))
+	bad.Aunts = proof.Aunts
+	bad.Total = 9
+	assert.Error(t, VerifyMultiProof(root, leaves, &bad))
+	bad.Total = 8
+	bad.Indices = []int64{0, 2, 1}
+	assert.Error(t, VerifyMultiProof(root, leaves, &bad))
+	assert.Error(t, VerifyMultiProof(root, leaves, nil))
+
+	// invalid indices
+	_, err = MultiProofFromByteSlices(items, nil)
+	assert.Error(t, err)
+	_, err = MultiProofFromByteSlices(items, []int{1, 8})
+	assert.Error(t, err)
+	_, err = MultiProofFromByteSlices(items, []int{1, 1})
+	assert.Error(t, err)
+}
+
+func TestMultiProofRandom(t *testing.T) {
+	for _, total := range []int{1, 2, 3, 7, 100} {
+		items := make([][]byte, total)
+		for i := range items {
+			items[i] = tmrand.Bytes(tmrand.Intn(64))
+		}
+		root := HashFromByteSlices(items)
+
+		for n := 1; n <= total && n <= 10; n++ {
+			indices := tmrand.Perm(total)[:n]
+			proof, err := MultiProofFromByteSlices(items, indices)
+			require.NoError(t, err)
+
+			leaves := make(map[int][]byte, n)
+			for _, idx := range indices {
+				leaves[idx] = items[idx]
+			}
+			assert.NoError(t, VerifyMultiProof(root, leaves, proof), "total %d, indices %v", total, indices)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package merkle
 
 import (
 	"math/bits"
+	"sync"
 )
 
 // HashFromByteSlices computes a Merkle tree where the leaves are the byte slice,
@@ -91,6 +92,55 @@ func HashFromByteSlicesIterative(input [][]byte) []byte {
 	}
 }
 
+// HashFromByteSlicesParallel returns the same root as HashFromByteSlices, but
+// computes the leaf hashes, which dominate the cost for large trees (e.g. the
+// parts of a big block), concurrently using the given number of workers.
+// Panics if workers is not positive.
+func HashFromByteSlicesParallel(items [][]byte, workers int) []byte {
+	if workers <= 0 {
+		panic("workers must be positive")
+	}
+	if len(items) == 0 {
+		return emptyHash()
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	// each worker hashes a contiguous chunk of the leaves
+	var (
+		leaves    = make([][]byte, len(items))
+		chunkSize = (len(items) + workers - 1) / workers
+		wg        sync.WaitGroup
+	)
+	for start := 0; start < len(items); start += chunkSize {
+		end := start + chunkSize
+		if end > len(items) {
+			end = len(items)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				leaves[i] = leafHash(items[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return hashFromLeafHashes(leaves)
+}
+
+// hashFromLeafHashes is like HashFromByteSlices, but for already hashed
+// leaves. There must be at least one leaf.
+func hashFromLeafHashes(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := getSplitPoint(int64(len(leaves)))
+	return innerHash(hashFromLeafHashes(leaves[:k]), hashFromLeafHashes(leaves[k:]))
+}
+
 // getSplitPoint returns the largest power of 2 less than length
 func getSplitPoint(length int64) int64 {
 	if length < 1 {
@@ -2,6 +2,7 @@ package merkle
 
 import (
 	"encoding/hex"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -137,6 +138,44 @@ func BenchmarkHashAlternatives(b *testing.B) {
 	})
 }
 
+func TestHashFromByteSlicesParallel(t *testing.T) {
+	for _, total := range []int{0, 1, 2, 3, 1000, 4096} {
+		items := make([][]byte, total)
+		for i := 0; i < total; i++ {
+			items[i] = tmrand.Bytes(tmrand.Intn(100))
+		}
+		expected := HashFromByteSlices(items)
+		for _, workers := range []int{1, 3, 8, 5000} {
+			assert.Equal(t, expected, HashFromByteSlicesParallel(items, workers),
+				"%d items, %d workers", total, workers)
+		}
+	}
+
+	assert.Panics(t, func() { HashFromByteSlicesParallel(nil, 0) })
+}
+
+func BenchmarkHashFromByteSlicesParallel(b *testing.B) {
+	const total = 4096
+
+	items := make([][]byte, total)
+	for i := 0; i < total; i++ {
+		items[i] = tmrand.Bytes(4096)
+	}
+
+	b.ResetTimer()
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = HashFromByteSlices(items)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = HashFromByteSlicesParallel(items, runtime.NumCPU())
+		}
+	})
+}
+
 func Test_getSplitPoint(t *testing.T) {
 	tests := []struct {
 		length int64
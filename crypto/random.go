@@ -4,19 +4,43 @@ import (
 	crand "crypto/rand"
 	"encoding/hex"
 	"io"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+var (
+	randReaderMtx tmsync.RWMutex
+	randReader    = crand.Reader
 )
 
-// This only uses the OS's randomness
+// SetRandReader replaces the source of randomness used by CRandBytes, CReader
+// and thus the GenPrivKey functions of the key packages. It is meant for tests
+// that need reproducible keys. The reader must be safe for concurrent use if
+// randomness is generated concurrently.
+// NEVER use it outside of tests.
+func SetRandReader(r io.Reader) {
+	randReaderMtx.Lock()
+	defer randReaderMtx.Unlock()
+	randReader = r
+}
+
+// ResetRandReader restores the OS's randomness (crypto/rand) as the source of
+// randomness.
+func ResetRandReader() {
+	SetRandReader(crand.Reader)
+}
+
+// This only uses the OS's randomness, unless overridden by SetRandReader
 func randBytes(numBytes int) []byte {
 	b := make([]byte, numBytes)
-	_, err := crand.Read(b)
+	_, err := io.ReadFull(CReader(), b)
 	if err != nil {
 		panic(err)
 	}
 	return b
 }
 
-// This only uses the OS's randomness
+// This only uses the OS's randomness, unless overridden by SetRandReader
 func CRandBytes(numBytes int) []byte {
 	return randBytes(numBytes)
 }
@@ -29,7 +53,9 @@ func CRandHex(numDigits int) string {
 	return hex.EncodeToString(CRandBytes(numDigits / 2))
 }
 
-// Returns a crand.Reader.
+// Returns a crand.Reader, unless overridden by SetRandReader.
 func CReader() io.Reader {
-	return crand.Reader
+	randReaderMtx.RLock()
+	defer randReaderMtx.RUnlock()
+	return randReader
 }
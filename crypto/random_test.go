@@ -1,11 +1,13 @@
 package crypto_test
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 )
 
 // the purpose of this test is primarily to ensure that the randomness
@@ -21,3 +23,20 @@ func TestRandomConsistency(t *testing.T) {
 	require.NotEqual(t, x4, x5)
 	require.NotEqual(t, x1, x5)
 }
+
+func TestSetRandReader(t *testing.T) {
+	defer crypto.ResetRandReader()
+
+	crypto.SetRandReader(rand.New(rand.NewSource(42)))
+	key1, key2 := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	bz := crypto.CRandBytes(32)
+	require.NotEqual(t, key1, key2)
+
+	crypto.ResetRandReader()
+	require.NotEqual(t, key1, ed25519.GenPrivKey())
+
+	crypto.SetRandReader(rand.New(rand.NewSource(42)))
+	require.Equal(t, key1, ed25519.GenPrivKey())
+	require.Equal(t, key2, ed25519.GenPrivKey())
+	require.Equal(t, bz, crypto.CRandBytes(32))
+}
@@ -8,6 +8,7 @@ import (
 )
 
 // Sign creates an ECDSA signature on curve Secp256k1, using SHA256 on the msg.
+// The returned signature will be of the form R || S (in lower-S form).
 func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
 	rsv, err := secp256k1.Sign(crypto.Sha256(msg), privKey[:])
 	if err != nil {
@@ -18,6 +19,8 @@ func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
 	return rs, nil
 }
 
+// VerifySignature verifies a signature of the form R || S.
+// libsecp256k1 rejects signatures which are not in lower-S form.
 func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
 	return secp256k1.VerifySignature(pubKey[:], crypto.Sha256(msg), sig)
 }
@@ -28,7 +28,7 @@ func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
 	return sigBytes, nil
 }
 
-// VerifyBytes verifies a signature of the form R || S.
+// VerifySignature verifies a signature of the form R || S.
 // It rejects signatures which are not in lower-S form.
 func (pubKey PubKey) VerifySignature(msg []byte, sigStr []byte) bool {
 	if len(sigStr) != 64 {
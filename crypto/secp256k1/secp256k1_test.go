@@ -63,6 +63,32 @@ func TestSignAndValidateSecp256k1(t *testing.T) {
 	assert.False(t, pubKey.VerifySignature(msg, sig))
 }
 
+// Signatures must be canonical, so that a validator can't produce two valid
+// signatures for the same message.
+func TestSignIsCanonicalSecp256k1(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	pubKey := privKey.PubKey()
+	msg := crypto.CRandBytes(128)
+
+	sig1, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	sig2, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	assert.Equal(t, sig1, sig2, "signing must be deterministic")
+
+	n := underlyingSecp256k1.S256().N
+	s := new(big.Int).SetBytes(sig1[32:])
+	require.True(t, s.Cmp(new(big.Int).Rsh(n, 1)) <= 0, "signature must be in lower-S form")
+	require.True(t, pubKey.VerifySignature(msg, sig1))
+
+	// the high-S counterpart is an otherwise valid signature
+	highS := make([]byte, 64)
+	copy(highS, sig1[:32])
+	sBytes := new(big.Int).Sub(n, s).Bytes()
+	copy(highS[64-len(sBytes):], sBytes)
+	assert.False(t, pubKey.VerifySignature(msg, highS), "high-S signature must be rejected")
+}
+
 // This test is intended to justify the removal of calls to the underlying library
 // in creating the privkey.
 func TestSecp256k1LoadPrivkeyAndSerializeIsIdentity(t *testing.T) {
@@ -29,3 +29,34 @@ func TestSignAndValidateSr25519(t *testing.T) {
 
 	assert.False(t, pubKey.VerifySignature(msg, sig))
 }
+
+func TestVRFProveAndVerify(t *testing.T) {
+	privKey := sr25519.GenPrivKey()
+	pubKey := privKey.PubKey().(sr25519.PubKey)
+	msg := []byte("seed")
+
+	hash, proof, err := privKey.VRFProve(msg)
+	require.NoError(t, err)
+	assert.Len(t, hash, sr25519.VRFHashSize)
+	assert.Len(t, proof, sr25519.VRFProofSize)
+
+	verified, err := pubKey.VRFVerify(msg, proof)
+	require.NoError(t, err)
+	assert.Equal(t, hash, verified)
+
+	// the hash is deterministic
+	hash2, _, err := privKey.VRFProve(msg)
+	require.NoError(t, err)
+	assert.Equal(t, hash, hash2)
+
+	_, err = pubKey.VRFVerify([]byte("other seed"), proof)
+	assert.Error(t, err)
+	other := sr25519.GenPrivKey().PubKey().(sr25519.PubKey)
+	_, err = other.VRFVerify(msg, proof)
+	assert.Error(t, err)
+	proof[40] ^= 0x01
+	_, err = pubKey.VRFVerify(msg, proof)
+	assert.Error(t, err)
+	_, err = pubKey.VRFVerify(msg, proof[:10])
+	assert.Error(t, err)
+}
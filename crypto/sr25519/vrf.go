@@ -0,0 +1,90 @@
+package sr25519
+
+import (
+	"errors"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+	"github.com/gtank/merlin"
+)
+
+const (
+	// VRFProofSize is the number of bytes in a VRF proof: the VRF output
+	// point followed by the DLEQ proof.
+	VRFProofSize = 32 + 64
+	// VRFHashSize is the number of bytes in the pseudorandom hash output by
+	// the VRF.
+	VRFHashSize = tmhash.Size
+)
+
+var vrfContext = []byte("tendermint-vrf")
+
+func vrfTranscript(msg []byte) *merlin.Transcript {
+	return schnorrkel.NewSigningContext(vrfContext, msg)
+}
+
+// VRFProve evaluates the schnorrkel VRF on msg. It returns the pseudorandom
+// hash, which only the holder of the private key can compute, and a proof
+// allowing anyone to compute it and check it's correct, given the public key
+// (see PubKey.VRFVerify).
+func (privKey PrivKey) VRFProve(msg []byte) (hash []byte, proof []byte, err error) {
+	var p [PrivKeySize]byte
+	copy(p[:], privKey)
+	miniSecretKey, err := schnorrkel.NewMiniSecretKeyFromRaw(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	secretKey := miniSecretKey.ExpandEd25519()
+
+	inout, dleq, err := secretKey.VrfSign(vrfTranscript(msg))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	output := inout.Output().Encode()
+	dleqBz := dleq.Encode()
+	proof = make([]byte, 0, VRFProofSize)
+	proof = append(proof, output[:]...)
+	proof = append(proof, dleqBz[:]...)
+	return tmhash.Sum(inout.Encode()), proof, nil
+}
+
+// VRFVerify checks the VRF proof for msg, returning the pseudorandom hash
+// computed by VRFProve.
+func (pubKey PubKey) VRFVerify(msg []byte, proof []byte) ([]byte, error) {
+	if len(proof) != VRFProofSize {
+		return nil, errors.New("invalid VRF proof size")
+	}
+
+	publicKey := &(schnorrkel.PublicKey{})
+	var p [PubKeySize]byte
+	copy(p[:], pubKey)
+	if err := publicKey.Decode(p); err != nil {
+		return nil, err
+	}
+
+	var outputBz [32]byte
+	copy(outputBz[:], proof[:32])
+	output := &(schnorrkel.VrfOutput{})
+	if err := output.Decode(outputBz); err != nil {
+		return nil, err
+	}
+
+	var dleqBz [64]byte
+	copy(dleqBz[:], proof[32:])
+	dleq := &(schnorrkel.VrfProof{})
+	if err := dleq.Decode(dleqBz); err != nil {
+		return nil, err
+	}
+
+	inout := output.AttachInput(publicKey, vrfTranscript(msg))
+	ok, err := publicKey.VrfVerify(vrfTranscript(msg), inout, dleq)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("invalid VRF proof")
+	}
+	return tmhash.Sum(inout.Encode()), nil
+}
@@ -21,15 +21,21 @@ var (
 //
 // Should only be called in init() functions, as it panics on error.
 func RegisterType(_type interface{}, name string) {
-	if _type == nil {
-		panic("cannot register nil type")
-	}
-	err := typeRegistry.register(name, reflect.ValueOf(_type).Type())
-	if err != nil {
+	if err := TryRegisterType(_type, name); err != nil {
 		panic(err)
 	}
 }
 
+// TryRegisterType is like RegisterType, but returns an error instead of
+// panicking, e.g. if the name or the type is already registered. It can be
+// used to register types at runtime.
+func TryRegisterType(_type interface{}, name string) error {
+	if _type == nil {
+		return errors.New("cannot register nil type")
+	}
+	return typeRegistry.register(name, reflect.ValueOf(_type).Type())
+}
+
 // typeInfo contains type information.
 type typeInfo struct {
 	name      string
@@ -0,0 +1,270 @@
+// Package reedsolomon implements systematic Reed-Solomon erasure coding over
+// GF(2^8).
+//
+// Data is split into dataShards equally sized shards, from which parityShards
+// parity shards are computed. The original data shards can be recovered from
+// any dataShards of the dataShards+parityShards shards.
+package reedsolomon
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxShards is the maximum number of data and parity shards combined.
+const MaxShards = 256
+
+var (
+	// ErrTooFewShards is returned by Reconstruct if less than dataShards
+	// shards are present.
+	ErrTooFewShards = errors.New("too few shards to reconstruct")
+	// ErrShardSize is returned if the present shards are not of equal size.
+	ErrShardSize = errors.New("shards must have equal size")
+)
+
+// Encoder computes parity shards and reconstructs missing shards.
+type Encoder struct {
+	dataShards   int
+	parityShards int
+	// matrix is the (dataShards+parityShards) x dataShards encoding matrix.
+	// Its top dataShards rows are the identity matrix, so data shards are
+	// kept as is.
+	matrix [][]byte
+}
+
+// New returns an Encoder for the given number of data and parity shards.
+func New(dataShards, parityShards int) (*Encoder, error) {
+	if dataShards <= 0 {
+		return nil, fmt.Errorf("dataShards must be positive, got %d", dataShards)
+	}
+	if parityShards < 0 {
+		return nil, fmt.Errorf("parityShards must not be negative, got %d", parityShards)
+	}
+	if dataShards+parityShards > MaxShards {
+		return nil, fmt.Errorf("too many shards: %d, max: %d", dataShards+parityShards, MaxShards)
+	}
+
+	total := dataShards + parityShards
+	// Any dataShards rows of a Vandermonde matrix with distinct points are
+	// linearly independent. Multiplying by the inverse of its top square
+	// makes the top part the identity, while preserving that property.
+	vm := make([][]byte, total)
+	for r := range vm {
+		vm[r] = make([]byte, dataShards)
+		for c := range vm[r] {
+			vm[r][c] = gfExp(byte(r), c)
+		}
+	}
+	top, err := invert(vm[:dataShards])
+	if err != nil {
+		panic(err) // can't happen: Vandermonde matrices are invertible
+	}
+
+	return &Encoder{
+		dataShards:   dataShards,
+		parityShards: parityShards,
+		matrix:       mul(vm, top),
+	}, nil
+}
+
+// DataShards returns the number of data shards.
+func (e *Encoder) DataShards() int { return e.dataShards }
+
+// ParityShards returns the number of parity shards.
+func (e *Encoder) ParityShards() int { return e.parityShards }
+
+// Encode computes the parity shards from the data shards. shards must contain
+// dataShards data shards of equal size, followed by parityShards parity
+// shards, which are (re)allocated if they don't have the right size.
+func (e *Encoder) Encode(shards [][]byte) error {
+	if len(shards) != e.dataShards+e.parityShards {
+		return fmt.Errorf("expected %d shards, got %d", e.dataShards+e.parityShards, len(shards))
+	}
+	size := len(shards[0])
+	for _, shard := range shards[:e.dataShards] {
+		if len(shard) != size {
+			return ErrShardSize
+		}
+	}
+
+	for i := e.dataShards; i < len(shards); i++ {
+		if len(shards[i]) != size {
+			shards[i] = make([]byte, size)
+		}
+		e.codeShard(e.matrix[i], shards[:e.dataShards], shards[i])
+	}
+	return nil
+}
+
+// Reconstruct recovers the missing shards, which must be nil or empty, from
+// the present ones. It returns ErrTooFewShards if less than dataShards shards
+// are present.
+//
+// NOTE: Reconstruct can't detect corrupted shards. Verify the result against
+// a hash (e.g. a PartSetHeader) if the shards are untrusted.
+func (e *Encoder) Reconstruct(shards [][]byte) error {
+	if len(shards) != e.dataShards+e.parityShards {
+		return fmt.Errorf("expected %d shards, got %d", e.dataShards+e.parityShards, len(shards))
+	}
+
+	var (
+		size    = -1
+		rows    = make([][]byte, 0, e.dataShards) // encoding matrix rows of present shards
+		present = make([][]byte, 0, e.dataShards)
+	)
+	for i, shard := range shards {
+		if len(shard) == 0 {
+			continue
+		}
+		if size == -1 {
+			size = len(shard)
+		} else if len(shard) != size {
+			return ErrShardSize
+		}
+		if len(present) < e.dataShards {
+			rows = append(rows, e.matrix[i])
+			present = append(present, shard)
+		}
+	}
+	if len(present) < e.dataShards {
+		return ErrTooFewShards
+	}
+
+	decode, err := invert(rows)
+	if err != nil {
+		panic(err) // can't happen: any dataShards rows are independent
+	}
+	for i := 0; i < e.dataShards; i++ {
+		if len(shards[i]) == 0 {
+			shards[i] = make([]byte, size)
+			e.codeShard(decode[i], present, shards[i])
+		}
+	}
+	for i := e.dataShards; i < len(shards); i++ {
+		if len(shards[i]) == 0 {
+			shards[i] = make([]byte, size)
+			e.codeShard(e.matrix[i], shards[:e.dataShards], shards[i])
+		}
+	}
+	return nil
+}
+
+// codeShard sets out to the linear combination of the inputs with the given
+// coefficients.
+func (e *Encoder) codeShard(coeffs []byte, inputs [][]byte, out []byte) {
+	for i := range out {
+		out[i] = 0
+	}
+	for j, input := range inputs {
+		c := coeffs[j]
+		if c == 0 {
+			continue
+		}
+		for i, b := range input {
+			out[i] ^= gfMul(c, b)
+		}
+	}
+}
+
+//-------------------------------------
+// GF(2^8) arithmetic, using the polynomial x^8 + x^4 + x^3 + x^2 + 1 (0x11d).
+
+var (
+	expTable [510]byte
+	logTable [256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		expTable[i] = byte(x)
+		expTable[i+255] = byte(x)
+		logTable[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+func gfInv(a byte) byte {
+	return expTable[255-int(logTable[a])]
+}
+
+// gfExp returns a^n, with 0^0 = 1.
+func gfExp(a byte, n int) byte {
+	if n == 0 {
+		return 1
+	}
+	if a == 0 {
+		return 0
+	}
+	return expTable[(int(logTable[a])*n)%255]
+}
+
+// mul returns the matrix product a * b.
+func mul(a, b [][]byte) [][]byte {
+	out := make([][]byte, len(a))
+	for r := range a {
+		out[r] = make([]byte, len(b[0]))
+		for c := range out[r] {
+			var v byte
+			for k := range b {
+				v ^= gfMul(a[r][k], b[k][c])
+			}
+			out[r][c] = v
+		}
+	}
+	return out
+}
+
+// invert returns the inverse of the square matrix m, using Gauss-Jordan
+// elimination. m is left unchanged.
+func invert(m [][]byte) ([][]byte, error) {
+	n := len(m)
+	// work is [m | I]
+	work := make([][]byte, n)
+	for r := range m {
+		work[r] = make([]byte, 2*n)
+		copy(work[r], m[r])
+		work[r][n+r] = 1
+	}
+
+	for c := 0; c < n; c++ {
+		pivot := c
+		for pivot < n && work[pivot][c] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, errors.New("matrix is singular")
+		}
+		work[c], work[pivot] = work[pivot], work[c]
+
+		inv := gfInv(work[c][c])
+		for k := range work[c] {
+			work[c][k] = gfMul(work[c][k], inv)
+		}
+		for r := 0; r < n; r++ {
+			if r == c || work[r][c] == 0 {
+				continue
+			}
+			f := work[r][c]
+			for k := range work[r] {
+				work[r][k] ^= gfMul(f, work[c][k])
+			}
+		}
+	}
+
+	out := make([][]byte, n)
+	for r := range work {
+		out[r] = work[r][n:]
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package reedsolomon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestNewInvalidParams(t *testing.T) {
+	_, err := New(0, 2)
+	assert.Error(t, err)
+	_, err = New(4, -1)
+	assert.Error(t, err)
+	_, err = New(200, 57)
+	assert.Error(t, err)
+	_, err = New(200, 56)
+	assert.NoError(t, err)
+}
+
+func TestEncodeReconstruct(t *testing.T) {
+	const dataShards, parityShards = 4, 3
+
+	enc, err := New(dataShards, parityShards)
+	require.NoError(t, err)
+
+	shards := make([][]byte, dataShards+parityShards)
+	for i := 0; i < dataShards; i++ {
+		shards[i] = tmrand.Bytes(100)
+	}
+	require.NoError(t, enc.Encode(shards))
+
+	// drop every combination of parityShards shards
+	for a := 0; a < len(shards); a++ {
+		for b := a + 1; b < len(shards); b++ {
+			for c := b + 1; c < len(shards); c++ {
+				damaged := append([][]byte(nil), shards...)
+				damaged[a], damaged[b], damaged[c] = nil, nil, nil
+				require.NoError(t, enc.Reconstruct(damaged))
+				assert.Equal(t, shards, damaged, "dropped %d, %d and %d", a, b, c)
+			}
+		}
+	}
+
+	damaged := append([][]byte(nil), shards...)
+	damaged[0], damaged[1], damaged[2], damaged[3] = nil, nil, nil, nil
+	assert.Equal(t, ErrTooFewShards, enc.Reconstruct(damaged))
+
+	damaged = append([][]byte(nil), shards...)
+	damaged[0], damaged[1] = nil, shards[1][:99]
+	assert.Equal(t, ErrShardSize, enc.Reconstruct(damaged))
+}
+
+func TestInvert(t *testing.T) {
+	m := [][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 10}}
+	inv, err := invert(m)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}, mul(m, inv))
+
+	_, err = invert([][]byte{{1, 2}, {1, 2}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// DetectFork compares the headers received from the primary and a witness at
+// the same height. If they conflict, it returns ConflictingHeadersEvidence
+// describing the conflict, to be reported to the full nodes. Otherwise, it
+// returns nil.
+//
+// The headers are expected to have been verified beforehand. An error is
+// returned if any of them is nil or if their heights differ.
+func DetectFork(primary, witness *types.SignedHeader) (types.Evidence, error) {
+	if primary == nil || primary.Header == nil {
+		return nil, errors.New("nil primary header")
+	}
+	if witness == nil || witness.Header == nil {
+		return nil, errors.New("nil witness header")
+	}
+	if primary.Height != witness.Height {
+		return nil, fmt.Errorf("headers must be at the same height: primary %d, witness %d",
+			primary.Height, witness.Height)
+	}
+
+	if bytes.Equal(primary.Hash(), witness.Hash()) {
+		return nil, nil
+	}
+	return types.NewConflictingHeadersEvidence(primary, witness), nil
+}
@@ -0,0 +1,49 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestDetectFork(t *testing.T) {
+	const chainID = "TestDetectFork"
+
+	var (
+		keys     = genPrivKeys(4)
+		vals     = keys.ToValidators(20, 10)
+		bTime, _ = time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+		primary  = keys.GenSignedHeader(chainID, 2, bTime, nil, vals, vals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+		same = keys.GenSignedHeader(chainID, 2, bTime, nil, vals, vals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+		conflicting = keys.GenSignedHeader(chainID, 2, bTime, nil, vals, vals,
+			hash("app_hash2"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+		otherHeight = keys.GenSignedHeader(chainID, 3, bTime, nil, vals, vals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+	)
+
+	// headers agree
+	ev, err := light.DetectFork(primary, same)
+	require.NoError(t, err)
+	assert.Nil(t, ev)
+
+	// headers conflict
+	ev, err = light.DetectFork(primary, conflicting)
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.EqualValues(t, 2, ev.Height())
+	assert.Equal(t, types.NewConflictingHeadersEvidence(primary, conflicting), ev)
+
+	// heights differ
+	_, err = light.DetectFork(primary, otherHeight)
+	assert.Error(t, err)
+
+	_, err = light.DetectFork(primary, nil)
+	assert.Error(t, err)
+}
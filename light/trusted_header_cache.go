@@ -0,0 +1,69 @@
+package light
+
+import (
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// TrustedHeaderCache is a concurrent-safe, in-memory cache of verified
+// headers and their validator sets, indexed by height, which saves verifying
+// the same header again during bisection.
+//
+// An entry is only returned while its header is within the trusting period
+// (see HeaderExpired). Expired entries are evicted when accessed.
+type TrustedHeaderCache struct {
+	trustingPeriod time.Duration
+
+	mtx     tmsync.Mutex
+	entries map[int64]trustedHeaderCacheEntry
+}
+
+type trustedHeaderCacheEntry struct {
+	sh   *types.SignedHeader
+	vals *types.ValidatorSet
+}
+
+// NewTrustedHeaderCache returns an empty cache, keeping headers for the given
+// trusting period.
+func NewTrustedHeaderCache(trustingPeriod time.Duration) *TrustedHeaderCache {
+	return &TrustedHeaderCache{
+		trustingPeriod: trustingPeriod,
+		entries:        make(map[int64]trustedHeaderCacheEntry),
+	}
+}
+
+// Set caches the verified header and its validator set, replacing any entry
+// at the same height.
+func (c *TrustedHeaderCache) Set(sh *types.SignedHeader, vals *types.ValidatorSet) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.entries[sh.Height] = trustedHeaderCacheEntry{sh: sh, vals: vals}
+}
+
+// Get returns the header and validator set cached at the given height. It
+// returns false if there's none or the header has expired, in which case the
+// entry is evicted.
+func (c *TrustedHeaderCache) Get(height int64) (*types.SignedHeader, *types.ValidatorSet, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	entry, ok := c.entries[height]
+	if !ok {
+		return nil, nil, false
+	}
+	if HeaderExpired(entry.sh, c.trustingPeriod, time.Now()) {
+		delete(c.entries, height)
+		return nil, nil, false
+	}
+	return entry.sh, entry.vals, true
+}
+
+// Len returns the number of cached entries, including expired ones not
+// evicted yet.
+func (c *TrustedHeaderCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return len(c.entries)
+}
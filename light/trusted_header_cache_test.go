@@ -0,0 +1,81 @@
+package light_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/light"
+)
+
+func TestTrustedHeaderCache(t *testing.T) {
+	const (
+		chainID        = "TestTrustedHeaderCache"
+		trustingPeriod = 500 * time.Millisecond
+	)
+
+	var (
+		keys   = genPrivKeys(4)
+		vals   = keys.ToValidators(20, 10)
+		header = keys.GenSignedHeader(chainID, 1, time.Now(), nil, vals, vals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+		expired = keys.GenSignedHeader(chainID, 2, time.Now().Add(-trustingPeriod), nil, vals, vals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+		c = light.NewTrustedHeaderCache(trustingPeriod)
+	)
+
+	_, _, ok := c.Get(1)
+	assert.False(t, ok)
+
+	c.Set(header, vals)
+	sh, v, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, header, sh)
+	assert.Equal(t, vals, v)
+
+	c.Set(expired, vals)
+	_, _, ok = c.Get(2)
+	assert.False(t, ok)
+	assert.Equal(t, 1, c.Len())
+
+	time.Sleep(trustingPeriod)
+	_, _, ok = c.Get(1)
+	assert.False(t, ok)
+	assert.Zero(t, c.Len())
+}
+
+func TestTrustedHeaderCacheConcurrency(t *testing.T) {
+	const chainID = "TestTrustedHeaderCacheConcurrency"
+
+	var (
+		keys = genPrivKeys(4)
+		vals = keys.ToValidators(20, 10)
+		c    = light.NewTrustedHeaderCache(time.Hour)
+		wg   sync.WaitGroup
+	)
+	for i := int64(1); i <= 10; i++ {
+		header := keys.GenSignedHeader(chainID, i, time.Now(), nil, vals, vals,
+			hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.Set(header, vals)
+		}()
+		go func(height int64) {
+			defer wg.Done()
+			if sh, _, ok := c.Get(height); ok {
+				assert.EqualValues(t, height, sh.Height)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, c.Len())
+	for i := int64(1); i <= 10; i++ {
+		sh, _, ok := c.Get(i)
+		assert.True(t, ok)
+		assert.EqualValues(t, i, sh.Height)
+	}
+}
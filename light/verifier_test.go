@@ -264,6 +264,16 @@ func TestVerifyNonAdjacentHeaders(t *testing.T) {
 			light.ErrNewValSetCantBeTrusted{types.ErrNotEnoughVotingPowerSigned{Got: 20, Needed: 46}},
 			"",
 		},
+		// trusted header expired -> error
+		6: {
+			keys.GenSignedHeader(chainID, 3, bTime.Add(1*time.Hour), nil, vals, vals,
+				hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys)),
+			vals,
+			1 * time.Hour,
+			bTime.Add(2 * time.Hour),
+			light.ErrOldHeaderExpired{bTime.Add(1 * time.Hour), bTime.Add(2 * time.Hour)},
+			"",
+		},
 	}
 
 	for i, tc := range testCases {
@@ -251,16 +251,28 @@ func (pv *FilePV) GetPubKey() (crypto.PubKey, error) {
 // chainID. Implements PrivValidator.
 func (pv *FilePV) SignVote(chainID string, vote *tmproto.Vote) error {
 	if err := pv.signVote(chainID, vote); err != nil {
-		return fmt.Errorf("error signing vote: %v", err)
+		return fmt.Errorf("error signing vote: %w", err)
 	}
 	return nil
 }
 
+// SignVoteExtension signs the extension of the vote, along with the chainID.
+// Unlike votes, extensions aren't protected against double signing.
+// Implements types.VoteExtensionSigner.
+func (pv *FilePV) SignVoteExtension(chainID string, vote *types.Vote) error {
+	sig, err := pv.Key.PrivKey.Sign(types.VoteExtensionSignBytes(chainID, vote))
+	if err != nil {
+		return fmt.Errorf("error signing vote extension: %w", err)
+	}
+	vote.ExtensionSignature = sig
+	return nil
+}
+
 // SignProposal signs a canonical representation of the proposal, along with
 // the chainID. Implements PrivValidator.
 func (pv *FilePV) SignProposal(chainID string, proposal *tmproto.Proposal) error {
 	if err := pv.signProposal(chainID, proposal); err != nil {
-		return fmt.Errorf("error signing proposal: %v", err)
+		return fmt.Errorf("error signing proposal: %w", err)
 	}
 	return nil
 }
@@ -323,7 +335,7 @@ func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 			vote.Timestamp = timestamp
 			vote.Signature = lss.Signature
 		} else {
-			err = fmt.Errorf("conflicting data")
+			err = fmt.Errorf("conflicting data: %w", types.ErrVoteDoubleSign)
 		}
 		return err
 	}
@@ -365,7 +377,7 @@ func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error
 			proposal.Timestamp = timestamp
 			proposal.Signature = lss.Signature
 		} else {
-			err = fmt.Errorf("conflicting data")
+			err = fmt.Errorf("conflicting data: %w", types.ErrVoteDoubleSign)
 		}
 		return err
 	}
@@ -2,6 +2,7 @@ package privval
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -200,6 +201,8 @@ func TestSignVote(t *testing.T) {
 		err = privVal.SignVote("mychainid", cpb)
 		assert.Error(err, "expected error on signing conflicting vote")
 	}
+	err = privVal.SignVote("mychainid", cases[len(cases)-1].ToProto())
+	assert.True(errors.Is(err, types.ErrVoteDoubleSign), err)
 
 	// try signing a vote with a different time stamp
 	sig := vote.Signature
@@ -250,6 +253,8 @@ func TestSignProposal(t *testing.T) {
 		err = privVal.SignProposal("mychainid", c.ToProto())
 		assert.Error(err, "expected error on signing conflicting proposal")
 	}
+	err = privVal.SignProposal("mychainid", cases[len(cases)-1].ToProto())
+	assert.True(errors.Is(err, types.ErrVoteDoubleSign), err)
 
 	// try signing a proposal with a different time stamp
 	sig := proposal.Signature
@@ -320,6 +325,36 @@ func TestDifferByTimestamp(t *testing.T) {
 	}
 }
 
+func TestSignVoteExtension(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	randbytes := tmrand.Bytes(tmhash.Size)
+	block1 := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+	chainID := "mychainid"
+
+	vote := newVote(privVal.Key.Address, 0, 10, 1, tmproto.PrecommitType, block1)
+	vote.Extension = []byte("extension")
+	v := vote.ToProto()
+	require.NoError(t, privVal.SignVote(chainID, v))
+	vote.Signature = v.Signature
+	require.NoError(t, privVal.SignVoteExtension(chainID, vote))
+	assert.NoError(t, vote.Verify(chainID, privVal.Key.PubKey))
+
+	// the vote signature doesn't depend on the extension
+	vote.Extension = []byte("other extension")
+	v = vote.ToProto()
+	v.Signature = nil
+	require.NoError(t, privVal.SignVote(chainID, v))
+	assert.Equal(t, vote.Signature, v.Signature)
+	assert.Error(t, vote.Verify(chainID, privVal.Key.PubKey))
+	require.NoError(t, privVal.SignVoteExtension(chainID, vote))
+	assert.NoError(t, vote.Verify(chainID, privVal.Key.PubKey))
+}
+
 func newVote(addr types.Address, idx int32, height int64, round int32,
 	typ tmproto.SignedMsgType, blockID types.BlockID) *types.Vote {
 	return &types.Vote{
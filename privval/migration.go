@@ -0,0 +1,79 @@
+package privval
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MigrationPV wraps the old and the new PrivValidator of a validator whose
+// key is being rotated. It signs with the new one, unless told to fall back to
+// the old one (see SetUseOld) during the transition window, i.e. until the
+// validator set update replacing the old key is committed.
+//
+// Both pubkeys remain available, so that messages signed before the switch
+// can still be verified.
+type MigrationPV struct {
+	oldPV types.PrivValidator
+	newPV types.PrivValidator
+
+	mtx    tmsync.RWMutex
+	useOld bool
+}
+
+// NewMigrationPV returns a MigrationPV signing with newPV.
+func NewMigrationPV(oldPV, newPV types.PrivValidator) *MigrationPV {
+	return &MigrationPV{oldPV: oldPV, newPV: newPV}
+}
+
+var _ types.PrivValidator = (*MigrationPV)(nil)
+
+// SetUseOld switches signing to the old (true) or the new (false) key.
+func (pv *MigrationPV) SetUseOld(useOld bool) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	pv.useOld = useOld
+}
+
+// UseOld returns true if signing with the old key.
+func (pv *MigrationPV) UseOld() bool {
+	pv.mtx.RLock()
+	defer pv.mtx.RUnlock()
+	return pv.useOld
+}
+
+// OldPubKey returns the pubkey of the old PrivValidator.
+func (pv *MigrationPV) OldPubKey() (crypto.PubKey, error) {
+	return pv.oldPV.GetPubKey()
+}
+
+// NewPubKey returns the pubkey of the new PrivValidator.
+func (pv *MigrationPV) NewPubKey() (crypto.PubKey, error) {
+	return pv.newPV.GetPubKey()
+}
+
+func (pv *MigrationPV) active() types.PrivValidator {
+	if pv.UseOld() {
+		return pv.oldPV
+	}
+	return pv.newPV
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey returns the pubkey of the active PrivValidator.
+func (pv *MigrationPV) GetPubKey() (crypto.PubKey, error) {
+	return pv.active().GetPubKey()
+}
+
+// SignVote signs the vote with the active PrivValidator.
+func (pv *MigrationPV) SignVote(chainID string, vote *tmproto.Vote) error {
+	return pv.active().SignVote(chainID, vote)
+}
+
+// SignProposal signs the proposal with the active PrivValidator.
+func (pv *MigrationPV) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	return pv.active().SignProposal(chainID, proposal)
+}
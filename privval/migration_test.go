@@ -0,0 +1,59 @@
+package privval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMigrationPV(t *testing.T) {
+	const chainID = "test-chain"
+	var (
+		oldPV   = types.NewMockPV()
+		newPV   = types.NewMockPV()
+		pv      = NewMigrationPV(oldPV, newPV)
+		blockID = types.BlockID{Hash: tmhash.Sum([]byte("block")),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}}
+	)
+
+	oldPubKey, err := pv.OldPubKey()
+	require.NoError(t, err)
+	newPubKey, err := pv.NewPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, oldPV.PrivKey.PubKey(), oldPubKey)
+	assert.Equal(t, newPV.PrivKey.PubKey(), newPubKey)
+
+	// signs with the new key by default
+	assert.False(t, pv.UseOld())
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, newPubKey, pubKey)
+
+	vote := newVote(newPubKey.Address(), 0, 1, 0, tmproto.PrevoteType, blockID).ToProto()
+	require.NoError(t, pv.SignVote(chainID, vote))
+	assert.True(t, newPubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+	assert.False(t, oldPubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+
+	proposal := newProposal(1, 0, blockID).ToProto()
+	require.NoError(t, pv.SignProposal(chainID, proposal))
+	assert.True(t, newPubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+
+	// falls back to the old key, whose messages can be verified afterwards
+	pv.SetUseOld(true)
+	pubKey, err = pv.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, oldPubKey, pubKey)
+
+	inFlight := newVote(oldPubKey.Address(), 0, 1, 0, tmproto.PrecommitType, blockID).ToProto()
+	require.NoError(t, pv.SignVote(chainID, inFlight))
+
+	pv.SetUseOld(false)
+	oldPubKey, err = pv.OldPubKey()
+	require.NoError(t, err)
+	assert.True(t, oldPubKey.VerifySignature(types.VoteSignBytes(chainID, inFlight), inFlight.Signature))
+}
@@ -1,6 +1,7 @@
 package privval
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,18 +10,60 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
+// retrySignerClientNext is the part of SignerClient wrapped by
+// RetrySignerClient.
+type retrySignerClientNext interface {
+	types.PrivValidator
+
+	Close() error
+	IsConnected() bool
+	WaitForConnection(maxWait time.Duration) error
+	Ping() error
+}
+
+// RetrySignerClientOption sets an optional parameter on the RetrySignerClient.
+type RetrySignerClientOption func(*RetrySignerClient)
+
+// RetrySignerClientMaxTimeout makes the client wait twice as long after each
+// failed attempt, starting from the timeout given to NewRetrySignerClient, but
+// never longer than maxTimeout. By default, it always waits the timeout.
+func RetrySignerClientMaxTimeout(maxTimeout time.Duration) RetrySignerClientOption {
+	return func(sc *RetrySignerClient) { sc.maxTimeout = maxTimeout }
+}
+
 // RetrySignerClient wraps SignerClient adding retry for each operation (except
-// Ping) w/ a timeout.
+// Ping) w/ a timeout, optionally growing exponentially (see
+// RetrySignerClientMaxTimeout).
+//
+// Errors which won't go away by retrying, i.e. RemoteSignerError and refusing
+// to double sign (types.ErrVoteDoubleSign), are returned right away. Retrying
+// can't produce a double sign, since the signer still decides whether to sign.
 type RetrySignerClient struct {
-	next    *SignerClient
-	retries int
-	timeout time.Duration
+	next       retrySignerClientNext
+	retries    int
+	timeout    time.Duration
+	maxTimeout time.Duration
 }
 
 // NewRetrySignerClient returns RetrySignerClient. If +retries+ is 0, the
 // client will be retrying each operation indefinitely.
-func NewRetrySignerClient(sc *SignerClient, retries int, timeout time.Duration) *RetrySignerClient {
-	return &RetrySignerClient{sc, retries, timeout}
+func NewRetrySignerClient(sc *SignerClient, retries int, timeout time.Duration,
+	options ...RetrySignerClientOption) *RetrySignerClient {
+	return newRetrySignerClient(sc, retries, timeout, options...)
+}
+
+func newRetrySignerClient(next retrySignerClientNext, retries int, timeout time.Duration,
+	options ...RetrySignerClientOption) *RetrySignerClient {
+	sc := &RetrySignerClient{
+		next:       next,
+		retries:    retries,
+		timeout:    timeout,
+		maxTimeout: timeout,
+	}
+	for _, option := range options {
+		option(sc)
+	}
+	return sc
 }
 
 var _ types.PrivValidator = (*RetrySignerClient)(nil)
@@ -37,6 +80,28 @@ func (sc *RetrySignerClient) WaitForConnection(maxWait time.Duration) error {
 	return sc.next.WaitForConnection(maxWait)
 }
 
+// retry calls fn until it succeeds, returns an error which won't go away by
+// retrying or all attempts to do +what+ are exhausted.
+func (sc *RetrySignerClient) retry(what string, fn func() error) error {
+	var err error
+	timeout := sc.timeout
+	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		// If remote signer errors, we don't retry.
+		if _, ok := err.(*RemoteSignerError); ok || errors.Is(err, types.ErrVoteDoubleSign) {
+			return err
+		}
+		time.Sleep(timeout)
+		if timeout *= 2; timeout > sc.maxTimeout {
+			timeout = sc.maxTimeout
+		}
+	}
+	return fmt.Errorf("exhausted all attempts to %s: %w", what, err)
+}
+
 //--------------------------------------------------------
 // Implement PrivValidator
 
@@ -45,52 +110,21 @@ func (sc *RetrySignerClient) Ping() error {
 }
 
 func (sc *RetrySignerClient) GetPubKey() (crypto.PubKey, error) {
-	var (
-		pk  crypto.PubKey
-		err error
-	)
-	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+	var pk crypto.PubKey
+	err := sc.retry("get pubkey", func() (err error) {
 		pk, err = sc.next.GetPubKey()
-		if err == nil {
-			return pk, nil
-		}
-		// If remote signer errors, we don't retry.
-		if _, ok := err.(*RemoteSignerError); ok {
-			return nil, err
-		}
-		time.Sleep(sc.timeout)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("exhausted all attempts to get pubkey: %w", err)
+	return pk, nil
 }
 
 func (sc *RetrySignerClient) SignVote(chainID string, vote *tmproto.Vote) error {
-	var err error
-	for i := 0; i < sc.retries || sc.retries == 0; i++ {
-		err = sc.next.SignVote(chainID, vote)
-		if err == nil {
-			return nil
-		}
-		// If remote signer errors, we don't retry.
-		if _, ok := err.(*RemoteSignerError); ok {
-			return err
-		}
-		time.Sleep(sc.timeout)
-	}
-	return fmt.Errorf("exhausted all attempts to sign vote: %w", err)
+	return sc.retry("sign vote", func() error { return sc.next.SignVote(chainID, vote) })
 }
 
 func (sc *RetrySignerClient) SignProposal(chainID string, proposal *tmproto.Proposal) error {
-	var err error
-	for i := 0; i < sc.retries || sc.retries == 0; i++ {
-		err = sc.next.SignProposal(chainID, proposal)
-		if err == nil {
-			return nil
-		}
-		// If remote signer errors, we don't retry.
-		if _, ok := err.(*RemoteSignerError); ok {
-			return err
-		}
-		time.Sleep(sc.timeout)
-	}
-	return fmt.Errorf("exhausted all attempts to sign proposal: %w", err)
+	return sc.retry("sign proposal", func() error { return sc.next.SignProposal(chainID, proposal) })
 }
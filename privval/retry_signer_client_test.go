@@ -0,0 +1,119 @@
+package privval
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+var errTransient = errors.New("transient error")
+
+// flakyPV is a signer client failing the first +failures+ calls of each
+// operation (all of them if negative).
+type flakyPV struct {
+	types.MockPV
+	failures int
+	calls    int
+}
+
+func (pv *flakyPV) fail() bool {
+	pv.calls++
+	return pv.failures < 0 || pv.calls <= pv.failures
+}
+
+func (pv *flakyPV) Close() error                          { return nil }
+func (pv *flakyPV) IsConnected() bool                     { return true }
+func (pv *flakyPV) WaitForConnection(time.Duration) error { return nil }
+func (pv *flakyPV) Ping() error                           { return nil }
+
+func (pv *flakyPV) GetPubKey() (crypto.PubKey, error) {
+	if pv.fail() {
+		return nil, errTransient
+	}
+	return pv.MockPV.GetPubKey()
+}
+
+func (pv *flakyPV) SignVote(chainID string, vote *tmproto.Vote) error {
+	if pv.fail() {
+		return errTransient
+	}
+	return pv.MockPV.SignVote(chainID, vote)
+}
+
+func (pv *flakyPV) SignProposal(chainID string, proposal *tmproto.Proposal) error {
+	if pv.fail() {
+		return errTransient
+	}
+	return pv.MockPV.SignProposal(chainID, proposal)
+}
+
+func TestRetrySignerClient(t *testing.T) {
+	const chainID = "test-chain"
+	blockID := types.BlockID{Hash: tmhash.Sum([]byte("block")),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}}
+
+	// fails twice, then succeeds
+	flaky := &flakyPV{MockPV: types.NewMockPV(), failures: 2}
+	pv := newRetrySignerClient(flaky, 3, time.Millisecond, RetrySignerClientMaxTimeout(2*time.Millisecond))
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, flaky.PrivKey.PubKey(), pubKey)
+	assert.Equal(t, 3, flaky.calls)
+
+	flaky.calls = 0
+	vote := newVote(pubKey.Address(), 0, 1, 0, tmproto.PrevoteType, blockID).ToProto()
+	require.NoError(t, pv.SignVote(chainID, vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, vote), vote.Signature))
+	assert.Equal(t, 3, flaky.calls)
+
+	flaky.calls = 0
+	proposal := newProposal(1, 0, blockID).ToProto()
+	require.NoError(t, pv.SignProposal(chainID, proposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+
+	// always fails
+	broken := &flakyPV{MockPV: types.NewMockPV(), failures: -1}
+	pv = newRetrySignerClient(broken, 4, time.Millisecond, RetrySignerClientMaxTimeout(2*time.Millisecond))
+	err = pv.SignVote(chainID, newVote(pubKey.Address(), 0, 1, 0, tmproto.PrevoteType, blockID).ToProto())
+	assert.True(t, errors.Is(err, errTransient), err)
+	assert.Equal(t, 4, broken.calls)
+	_, err = pv.GetPubKey()
+	assert.True(t, errors.Is(err, errTransient), err)
+
+	// the timeout doubles after each failure, up to the max
+	broken.calls = 0
+	pv = newRetrySignerClient(broken, 4, 10*time.Millisecond, RetrySignerClientMaxTimeout(20*time.Millisecond))
+	start := time.Now()
+	assert.Error(t, pv.SignProposal(chainID, newProposal(1, 0, blockID).ToProto()))
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64((10+20+20+20)*time.Millisecond))
+	assert.Equal(t, 4, broken.calls)
+}
+
+func TestRetrySignerClientDoubleSign(t *testing.T) {
+	const chainID = "test-chain"
+	blockID := types.BlockID{Hash: tmhash.Sum([]byte("block")),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}}
+	blockID2 := types.BlockID{Hash: tmhash.Sum([]byte("block2")),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}}
+
+	inner := &flakyPV{MockPV: types.NewMockPVWithDoubleSignProtection()}
+	pv := newRetrySignerClient(inner, 5, time.Millisecond)
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	require.NoError(t, pv.SignVote(chainID, newVote(pubKey.Address(), 0, 1, 0, tmproto.PrevoteType, blockID).ToProto()))
+
+	// the inner protection still applies and the error is not retried
+	inner.calls = 0
+	err = pv.SignVote(chainID, newVote(pubKey.Address(), 0, 1, 0, tmproto.PrevoteType, blockID2).ToProto())
+	assert.True(t, errors.Is(err, types.ErrVoteDoubleSign), err)
+	assert.Equal(t, 1, inner.calls)
+}
@@ -1,6 +1,7 @@
 package privval
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -146,10 +147,12 @@ func TestSignerProposal(t *testing.T) {
 			}
 		})
 
-		require.NoError(t, tc.mockPV.SignProposal(tc.chainID, want.ToProto()))
-		require.NoError(t, tc.signerClient.SignProposal(tc.chainID, have.ToProto()))
+		wantPb, havePb := want.ToProto(), have.ToProto()
+		require.NoError(t, tc.mockPV.SignProposal(tc.chainID, wantPb))
+		require.NoError(t, tc.signerClient.SignProposal(tc.chainID, havePb))
 
-		assert.Equal(t, want.Signature, have.Signature)
+		require.NotEmpty(t, havePb.Signature)
+		assert.Equal(t, wantPb.Signature, havePb.Signature)
 	}
 }
 
@@ -190,10 +193,83 @@ func TestSignerVote(t *testing.T) {
 			}
 		})
 
-		require.NoError(t, tc.mockPV.SignVote(tc.chainID, want.ToProto()))
-		require.NoError(t, tc.signerClient.SignVote(tc.chainID, have.ToProto()))
+		wantPb, havePb := want.ToProto(), have.ToProto()
+		require.NoError(t, tc.mockPV.SignVote(tc.chainID, wantPb))
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, havePb))
 
-		assert.Equal(t, want.Signature, have.Signature)
+		require.NotEmpty(t, havePb.Signature)
+		assert.Equal(t, wantPb.Signature, havePb.Signature)
+	}
+}
+
+func TestSignerVoteVerifies(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		serverPubKey, err := tc.mockPV.GetPubKey()
+		require.NoError(t, err)
+		pubKey, err := tc.signerClient.GetPubKey()
+		require.NoError(t, err)
+		require.Equal(t, serverPubKey, pubKey)
+
+		hash := tmrand.Bytes(tmhash.Size)
+		vote := &types.Vote{
+			Type:             tmproto.PrecommitType,
+			Height:           1,
+			Round:            2,
+			BlockID:          types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+			Timestamp:        time.Now(),
+			ValidatorAddress: serverPubKey.Address(),
+			ValidatorIndex:   1,
+		}
+		v := vote.ToProto()
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, v))
+		vote.Signature = v.Signature
+
+		assert.NoError(t, vote.Verify(tc.chainID, serverPubKey))
+		assert.Error(t, vote.Verify("other-chain", serverPubKey))
+	}
+}
+
+func slowHandler(privVal types.PrivValidator, request privvalproto.Message,
+	chainID string) (privvalproto.Message, error) {
+	time.Sleep(2 * testTimeoutReadWrite)
+	return DefaultValidationRequestHandler(privVal, request, chainID)
+}
+
+func TestSignerVoteTimeout(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		tc.signerServer.SetRequestHandler(slowHandler)
+
+		tc := tc
+		t.Cleanup(func() {
+			if err := tc.signerServer.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := tc.signerClient.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		// Depending on whether the slow server delays a ping enough for the
+		// listener to drop the connection first, the request either times out
+		// while waiting for the response or while waiting for a connection.
+		vote := &types.Vote{Timestamp: time.Now(), Type: tmproto.PrecommitType}
+		err := tc.signerClient.SignVote(tc.chainID, vote.ToProto())
+		assert.True(t, errors.Is(err, ErrReadTimeout) || errors.Is(err, ErrConnectionTimeout),
+			"expected a timeout, got: %v", err)
 	}
 }
 
@@ -236,18 +312,22 @@ func TestSignerVoteResetDeadline(t *testing.T) {
 
 		time.Sleep(testTimeoutReadWrite2o3)
 
-		require.NoError(t, tc.mockPV.SignVote(tc.chainID, want.ToProto()))
-		require.NoError(t, tc.signerClient.SignVote(tc.chainID, have.ToProto()))
-		assert.Equal(t, want.Signature, have.Signature)
+		wantPb, havePb := want.ToProto(), have.ToProto()
+		require.NoError(t, tc.mockPV.SignVote(tc.chainID, wantPb))
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, havePb))
+		require.NotEmpty(t, havePb.Signature)
+		assert.Equal(t, wantPb.Signature, havePb.Signature)
 
 		// TODO(jleni): Clarify what is actually being tested
 
 		// This would exceed the deadline if it was not extended by the previous message
 		time.Sleep(testTimeoutReadWrite2o3)
 
-		require.NoError(t, tc.mockPV.SignVote(tc.chainID, want.ToProto()))
-		require.NoError(t, tc.signerClient.SignVote(tc.chainID, have.ToProto()))
-		assert.Equal(t, want.Signature, have.Signature)
+		wantPb, havePb = want.ToProto(), have.ToProto()
+		require.NoError(t, tc.mockPV.SignVote(tc.chainID, wantPb))
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, havePb))
+		require.NotEmpty(t, havePb.Signature)
+		assert.Equal(t, wantPb.Signature, havePb.Signature)
 	}
 }
 
@@ -297,10 +377,12 @@ func TestSignerVoteKeepAlive(t *testing.T) {
 		time.Sleep(testTimeoutReadWrite * 3)
 		tc.signerServer.Logger.Debug("TEST: Forced Wait DONE---------------------------------------------")
 
-		require.NoError(t, tc.mockPV.SignVote(tc.chainID, want.ToProto()))
-		require.NoError(t, tc.signerClient.SignVote(tc.chainID, have.ToProto()))
+		wantPb, havePb := want.ToProto(), have.ToProto()
+		require.NoError(t, tc.mockPV.SignVote(tc.chainID, wantPb))
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, havePb))
 
-		assert.Equal(t, want.Signature, have.Signature)
+		require.NotEmpty(t, havePb.Signature)
+		assert.Equal(t, wantPb.Signature, havePb.Signature)
 	}
 }
 
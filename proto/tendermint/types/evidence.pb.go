@@ -263,10 +263,13 @@ func (m *ConflictingHeadersEvidence) GetH2() *SignedHeader {
 }
 
 type LunaticValidatorEvidence struct {
-	Header             *Header   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Vote               *Vote     `protobuf:"bytes,2,opt,name=vote,proto3" json:"vote,omitempty"`
-	InvalidHeaderField string    `protobuf:"bytes,3,opt,name=invalid_header_field,json=invalidHeaderField,proto3" json:"invalid_header_field,omitempty"`
-	Timestamp          time.Time `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Header *Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Vote   *Vote   `protobuf:"bytes,2,opt,name=vote,proto3" json:"vote,omitempty"`
+	// Only decoded, for evidence encoded before several fields could be
+	// reported: use invalid_header_fields.
+	InvalidHeaderField  string    `protobuf:"bytes,3,opt,name=invalid_header_field,json=invalidHeaderField,proto3" json:"invalid_header_field,omitempty"`
+	InvalidHeaderFields []string  `protobuf:"bytes,5,rep,name=invalid_header_fields,json=invalidHeaderFields,proto3" json:"invalid_header_fields,omitempty"`
+	Timestamp           time.Time `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
 }
 
 func (m *LunaticValidatorEvidence) Reset()         { *m = LunaticValidatorEvidence{} }
@@ -323,6 +326,13 @@ func (m *LunaticValidatorEvidence) GetInvalidHeaderField() string {
 	return ""
 }
 
+func (m *LunaticValidatorEvidence) GetInvalidHeaderFields() []string {
+	if m != nil {
+		return m.InvalidHeaderFields
+	}
+	return nil
+}
+
 func (m *LunaticValidatorEvidence) GetTimestamp() time.Time {
 	if m != nil {
 		return m.Timestamp
@@ -330,6 +340,138 @@ func (m *LunaticValidatorEvidence) GetTimestamp() time.Time {
 	return time.Time{}
 }
 
+// DuplicateProposalEvidence contains evidence a validator signed two
+// conflicting proposals.
+type DuplicateProposalEvidence struct {
+	ProposalA        *Proposal `protobuf:"bytes,1,opt,name=proposal_a,json=proposalA,proto3" json:"proposal_a,omitempty"`
+	ProposalB        *Proposal `protobuf:"bytes,2,opt,name=proposal_b,json=proposalB,proto3" json:"proposal_b,omitempty"`
+	ValidatorAddress []byte    `protobuf:"bytes,3,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
+	Timestamp        time.Time `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+}
+
+func (m *DuplicateProposalEvidence) Reset()         { *m = DuplicateProposalEvidence{} }
+func (m *DuplicateProposalEvidence) String() string { return proto.CompactTextString(m) }
+func (*DuplicateProposalEvidence) ProtoMessage()    {}
+func (*DuplicateProposalEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6825fabc78e0a168, []int{5}
+}
+func (m *DuplicateProposalEvidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DuplicateProposalEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DuplicateProposalEvidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DuplicateProposalEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateProposalEvidence.Merge(m, src)
+}
+func (m *DuplicateProposalEvidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *DuplicateProposalEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateProposalEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateProposalEvidence proto.InternalMessageInfo
+
+func (m *DuplicateProposalEvidence) GetProposalA() *Proposal {
+	if m != nil {
+		return m.ProposalA
+	}
+	return nil
+}
+
+func (m *DuplicateProposalEvidence) GetProposalB() *Proposal {
+	if m != nil {
+		return m.ProposalB
+	}
+	return nil
+}
+
+func (m *DuplicateProposalEvidence) GetValidatorAddress() []byte {
+	if m != nil {
+		return m.ValidatorAddress
+	}
+	return nil
+}
+
+func (m *DuplicateProposalEvidence) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+// FutureHeightEvidence contains evidence a validator signed a vote for a
+// height beyond the chain height at the time.
+type FutureHeightEvidence struct {
+	Vote          *Vote     `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
+	ClaimedHeight int64     `protobuf:"varint,2,opt,name=claimed_height,json=claimedHeight,proto3" json:"claimed_height,omitempty"`
+	Timestamp     time.Time `protobuf:"bytes,3,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+}
+
+func (m *FutureHeightEvidence) Reset()         { *m = FutureHeightEvidence{} }
+func (m *FutureHeightEvidence) String() string { return proto.CompactTextString(m) }
+func (*FutureHeightEvidence) ProtoMessage()    {}
+func (*FutureHeightEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6825fabc78e0a168, []int{6}
+}
+func (m *FutureHeightEvidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FutureHeightEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FutureHeightEvidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FutureHeightEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FutureHeightEvidence.Merge(m, src)
+}
+func (m *FutureHeightEvidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *FutureHeightEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_FutureHeightEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FutureHeightEvidence proto.InternalMessageInfo
+
+func (m *FutureHeightEvidence) GetVote() *Vote {
+	if m != nil {
+		return m.Vote
+	}
+	return nil
+}
+
+func (m *FutureHeightEvidence) GetClaimedHeight() int64 {
+	if m != nil {
+		return m.ClaimedHeight
+	}
+	return 0
+}
+
+func (m *FutureHeightEvidence) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
 type Evidence struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Evidence_DuplicateVoteEvidence
@@ -337,6 +479,8 @@ type Evidence struct {
 	//	*Evidence_LunaticValidatorEvidence
 	//	*Evidence_PotentialAmnesiaEvidence
 	//	*Evidence_AmnesiaEvidence
+	//	*Evidence_DuplicateProposalEvidence
+	//	*Evidence_FutureHeightEvidence
 	Sum isEvidence_Sum `protobuf_oneof:"sum"`
 }
 
@@ -344,7 +488,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6825fabc78e0a168, []int{5}
+	return fileDescriptor_6825fabc78e0a168, []int{7}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -394,12 +538,20 @@ type Evidence_PotentialAmnesiaEvidence struct {
 type Evidence_AmnesiaEvidence struct {
 	AmnesiaEvidence *AmnesiaEvidence `protobuf:"bytes,5,opt,name=amnesia_evidence,json=amnesiaEvidence,proto3,oneof" json:"amnesia_evidence,omitempty"`
 }
+type Evidence_DuplicateProposalEvidence struct {
+	DuplicateProposalEvidence *DuplicateProposalEvidence `protobuf:"bytes,6,opt,name=duplicate_proposal_evidence,json=duplicateProposalEvidence,proto3,oneof" json:"duplicate_proposal_evidence,omitempty"`
+}
+type Evidence_FutureHeightEvidence struct {
+	FutureHeightEvidence *FutureHeightEvidence `protobuf:"bytes,7,opt,name=future_height_evidence,json=futureHeightEvidence,proto3,oneof" json:"future_height_evidence,omitempty"`
+}
 
 func (*Evidence_DuplicateVoteEvidence) isEvidence_Sum()      {}
 func (*Evidence_ConflictingHeadersEvidence) isEvidence_Sum() {}
 func (*Evidence_LunaticValidatorEvidence) isEvidence_Sum()   {}
 func (*Evidence_PotentialAmnesiaEvidence) isEvidence_Sum()   {}
 func (*Evidence_AmnesiaEvidence) isEvidence_Sum()            {}
+func (*Evidence_DuplicateProposalEvidence) isEvidence_Sum()  {}
+func (*Evidence_FutureHeightEvidence) isEvidence_Sum()       {}
 
 func (m *Evidence) GetSum() isEvidence_Sum {
 	if m != nil {
@@ -443,6 +595,20 @@ func (m *Evidence) GetAmnesiaEvidence() *AmnesiaEvidence {
 	return nil
 }
 
+func (m *Evidence) GetDuplicateProposalEvidence() *DuplicateProposalEvidence {
+	if x, ok := m.GetSum().(*Evidence_DuplicateProposalEvidence); ok {
+		return x.DuplicateProposalEvidence
+	}
+	return nil
+}
+
+func (m *Evidence) GetFutureHeightEvidence() *FutureHeightEvidence {
+	if x, ok := m.GetSum().(*Evidence_FutureHeightEvidence); ok {
+		return x.FutureHeightEvidence
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Evidence) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -451,6 +617,8 @@ func (*Evidence) XXX_OneofWrappers() []interface{} {
 		(*Evidence_LunaticValidatorEvidence)(nil),
 		(*Evidence_PotentialAmnesiaEvidence)(nil),
 		(*Evidence_AmnesiaEvidence)(nil),
+		(*Evidence_DuplicateProposalEvidence)(nil),
+		(*Evidence_FutureHeightEvidence)(nil),
 	}
 }
 
@@ -464,7 +632,7 @@ func (m *EvidenceData) Reset()         { *m = EvidenceData{} }
 func (m *EvidenceData) String() string { return proto.CompactTextString(m) }
 func (*EvidenceData) ProtoMessage()    {}
 func (*EvidenceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6825fabc78e0a168, []int{6}
+	return fileDescriptor_6825fabc78e0a168, []int{8}
 }
 func (m *EvidenceData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -516,7 +684,7 @@ func (m *ProofOfLockChange) Reset()         { *m = ProofOfLockChange{} }
 func (m *ProofOfLockChange) String() string { return proto.CompactTextString(m) }
 func (*ProofOfLockChange) ProtoMessage()    {}
 func (*ProofOfLockChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6825fabc78e0a168, []int{7}
+	return fileDescriptor_6825fabc78e0a168, []int{9}
 }
 func (m *ProofOfLockChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -565,6 +733,8 @@ func init() {
 	proto.RegisterType((*AmnesiaEvidence)(nil), "tendermint.types.AmnesiaEvidence")
 	proto.RegisterType((*ConflictingHeadersEvidence)(nil), "tendermint.types.ConflictingHeadersEvidence")
 	proto.RegisterType((*LunaticValidatorEvidence)(nil), "tendermint.types.LunaticValidatorEvidence")
+	proto.RegisterType((*DuplicateProposalEvidence)(nil), "tendermint.types.DuplicateProposalEvidence")
+	proto.RegisterType((*FutureHeightEvidence)(nil), "tendermint.types.FutureHeightEvidence")
 	proto.RegisterType((*Evidence)(nil), "tendermint.types.Evidence")
 	proto.RegisterType((*EvidenceData)(nil), "tendermint.types.EvidenceData")
 	proto.RegisterType((*ProofOfLockChange)(nil), "tendermint.types.ProofOfLockChange")
@@ -573,52 +743,63 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/evidence.proto", fileDescriptor_6825fabc78e0a168) }
 
 var fileDescriptor_6825fabc78e0a168 = []byte{
-	// 710 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xc1, 0x4e, 0xdb, 0x4a,
-	0x14, 0xb5, 0x89, 0xc9, 0x83, 0x01, 0x09, 0x9e, 0x05, 0xef, 0x59, 0x16, 0x72, 0x4a, 0xba, 0x68,
-	0x85, 0xa8, 0x0d, 0x54, 0x15, 0x9b, 0x6e, 0x08, 0xb4, 0x8a, 0x04, 0x6a, 0xa9, 0xa9, 0x58, 0x74,
-	0xe3, 0x8e, 0xed, 0x89, 0x3d, 0xe0, 0x78, 0xac, 0x78, 0x1c, 0x35, 0x52, 0xbf, 0xa1, 0xe2, 0x63,
-	0xba, 0xe9, 0x1f, 0xb0, 0x64, 0xd9, 0x55, 0xa9, 0x60, 0xdf, 0x6f, 0xa8, 0x3c, 0x1e, 0xdb, 0x10,
-	0xc7, 0xa0, 0x56, 0x55, 0x37, 0x91, 0x33, 0xf7, 0xdc, 0x7b, 0xee, 0x99, 0x39, 0x77, 0x06, 0xb4,
-	0x28, 0x0a, 0x5d, 0x34, 0xe8, 0xe3, 0x90, 0x1a, 0x74, 0x14, 0xa1, 0xd8, 0x40, 0x43, 0xec, 0xa2,
-	0xd0, 0x41, 0x7a, 0x34, 0x20, 0x94, 0xc8, 0x8b, 0x25, 0x40, 0x67, 0x00, 0x75, 0xc9, 0x23, 0x1e,
-	0x61, 0x41, 0x23, 0xfd, 0xca, 0x70, 0x6a, 0xcb, 0x23, 0xc4, 0x0b, 0x90, 0xc1, 0xfe, 0xd9, 0x49,
-	0xcf, 0xa0, 0xb8, 0x8f, 0x62, 0x0a, 0xfb, 0x11, 0x07, 0xac, 0x54, 0x98, 0xd8, 0xef, 0x84, 0xa8,
-	0x33, 0x18, 0x45, 0x94, 0x18, 0xa7, 0x68, 0xc4, 0xa3, 0xed, 0x2f, 0x22, 0x58, 0xde, 0x4b, 0xa2,
-	0x00, 0x3b, 0x90, 0xa2, 0x63, 0x42, 0xd1, 0x0b, 0xde, 0xa4, 0xfc, 0x04, 0x34, 0x87, 0x84, 0x22,
-	0x0b, 0x2a, 0xe2, 0x03, 0xf1, 0xf1, 0xdc, 0xd6, 0x7f, 0xfa, 0x78, 0xbf, 0x7a, 0x8a, 0x37, 0xa7,
-	0x53, 0xd4, 0x4e, 0x01, 0xb7, 0x95, 0xa9, 0xfb, 0xe1, 0x1d, 0xb9, 0x03, 0x66, 0x0b, 0x19, 0x4a,
-	0x83, 0x65, 0xa8, 0x7a, 0x26, 0x54, 0xcf, 0x85, 0xea, 0x6f, 0x73, 0x44, 0x67, 0xe6, 0xfc, 0x5b,
-	0x4b, 0x38, 0xbb, 0x6c, 0x89, 0x66, 0x99, 0xd6, 0xbe, 0x14, 0x81, 0x72, 0x48, 0x28, 0x0a, 0x29,
-	0x86, 0xc1, 0x4e, 0x3f, 0x44, 0x31, 0x86, 0x7f, 0xa9, 0xfd, 0x55, 0x30, 0xef, 0x23, 0xec, 0xf9,
-	0xd4, 0x2a, 0x15, 0x34, 0xcc, 0xb9, 0x6c, 0xed, 0x28, 0x5d, 0xba, 0xad, 0x50, 0xfa, 0x3d, 0x85,
-	0x9f, 0x45, 0xb0, 0x30, 0x2e, 0xcc, 0x07, 0x6a, 0x94, 0x8b, 0xb6, 0x60, 0x16, 0xb4, 0x72, 0x6b,
-	0x71, 0xb1, 0x6b, 0xd5, 0xee, 0xeb, 0x36, 0xca, 0x54, 0xa2, 0xba, 0x2d, 0xdc, 0x06, 0x52, 0x44,
-	0x02, 0x87, 0xef, 0xc8, 0xc3, 0x09, 0x35, 0x07, 0x84, 0xf4, 0x5e, 0xf7, 0x0e, 0x88, 0x73, 0xba,
-	0xeb, 0xc3, 0xd0, 0x43, 0x26, 0x4b, 0x68, 0x7f, 0x04, 0xea, 0x2e, 0x09, 0x7b, 0x01, 0x76, 0x28,
-	0x0e, 0xbd, 0x2e, 0x82, 0x2e, 0x1a, 0xc4, 0x45, 0x59, 0x1d, 0x4c, 0xf9, 0x9b, 0xbc, 0x51, 0xad,
-	0x5a, 0xf4, 0x08, 0x7b, 0x21, 0x72, 0xb3, 0x24, 0x73, 0xca, 0xdf, 0x64, 0xf8, 0x2d, 0xde, 0xc4,
-	0xfd, 0xf8, 0xad, 0xf6, 0x0f, 0x11, 0x28, 0x07, 0x49, 0x08, 0x29, 0x76, 0x8e, 0x61, 0x80, 0x5d,
-	0x48, 0xc9, 0xa0, 0x20, 0xdf, 0x00, 0x4d, 0x9f, 0x41, 0x79, 0x03, 0x4a, 0xb5, 0x20, 0x2f, 0xc5,
-	0x71, 0xf2, 0x1a, 0x90, 0xd2, 0x33, 0xbf, 0xc7, 0x17, 0x0c, 0x23, 0x6f, 0x80, 0x25, 0x1c, 0x0e,
-	0x53, 0x52, 0x2b, 0xcb, 0xb6, 0x7a, 0x18, 0x05, 0x2e, 0xb3, 0xc7, 0xac, 0x29, 0xf3, 0x58, 0x46,
-	0xf0, 0x32, 0x8d, 0xfc, 0x11, 0x97, 0x7c, 0x92, 0xc0, 0x4c, 0x21, 0x10, 0x82, 0xff, 0xdd, 0x7c,
-	0x9e, 0x2d, 0x66, 0xe9, 0x31, 0x6f, 0x3c, 0xaa, 0x2a, 0x98, 0x78, 0x01, 0x74, 0x05, 0x73, 0xd9,
-	0x9d, 0x78, 0x33, 0x44, 0x60, 0xc5, 0x29, 0x8f, 0x97, 0x2b, 0x8d, 0x4b, 0x9e, 0x6c, 0xa7, 0xd6,
-	0xab, 0x3c, 0xf5, 0xa6, 0xe8, 0x0a, 0xa6, 0xea, 0xd4, 0x5b, 0xe6, 0x04, 0xa8, 0x41, 0x76, 0xa2,
-	0xd6, 0x30, 0x3f, 0xd2, 0x92, 0xaf, 0x51, 0xe7, 0xf9, 0x3a, 0x17, 0x74, 0x05, 0x53, 0x09, 0xea,
-	0x1c, 0x72, 0x72, 0xe7, 0x7c, 0x49, 0xbf, 0x3a, 0x5f, 0x29, 0x57, 0xed, 0x84, 0xbd, 0x02, 0x8b,
-	0x15, 0x86, 0x69, 0xc6, 0xb0, 0x5a, 0x65, 0xa8, 0x16, 0x5e, 0x80, 0xb7, 0x97, 0x3a, 0xd3, 0xa0,
-	0x11, 0x27, 0xfd, 0xf6, 0x7b, 0x30, 0x9f, 0x2f, 0xed, 0x41, 0x0a, 0xe5, 0xe7, 0x60, 0xe6, 0x86,
-	0x09, 0x1a, 0xcc, 0x63, 0x95, 0xf2, 0x45, 0x11, 0x29, 0xf5, 0x98, 0x59, 0x64, 0xc8, 0x32, 0x90,
-	0x7c, 0x18, 0xfb, 0xec, 0x58, 0xe7, 0x4d, 0xf6, 0xdd, 0xfe, 0x00, 0xfe, 0xad, 0x0c, 0xbf, 0xbc,
-	0x0e, 0xd8, 0xed, 0x18, 0x73, 0x8e, 0x3b, 0xaf, 0xd0, 0x58, 0x7e, 0x06, 0xfe, 0x89, 0x12, 0xdb,
-	0x3a, 0x45, 0x23, 0x6e, 0x98, 0x95, 0x9b, 0xf8, 0xec, 0xa5, 0xd2, 0x0f, 0x13, 0x3b, 0xc0, 0xce,
-	0x3e, 0x1a, 0x99, 0xcd, 0x28, 0xb1, 0xf7, 0xd1, 0xa8, 0xf3, 0xe6, 0xfc, 0x4a, 0x13, 0x2f, 0xae,
-	0x34, 0xf1, 0xfb, 0x95, 0x26, 0x9e, 0x5d, 0x6b, 0xc2, 0xc5, 0xb5, 0x26, 0x7c, 0xbd, 0xd6, 0x84,
-	0x77, 0xdb, 0x1e, 0xa6, 0x7e, 0x62, 0xeb, 0x0e, 0xe9, 0x1b, 0x37, 0x5f, 0xc4, 0xf2, 0x33, 0x7b,
-	0x5a, 0xc7, 0x5f, 0x4b, 0xbb, 0xc9, 0xd6, 0x9f, 0xfe, 0x0c, 0x00, 0x00, 0xff, 0xff, 0x84, 0x99,
-	0x61, 0x70, 0xb2, 0x07, 0x00, 0x00,
+	// 886 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x4f, 0x6f, 0xdc, 0x44,
+	0x14, 0xb7, 0xf7, 0x5f, 0x93, 0xd7, 0x40, 0xd3, 0x21, 0x29, 0xee, 0x12, 0x6d, 0xda, 0x45, 0x40,
+	0xd5, 0x16, 0x6f, 0x1b, 0x84, 0x2a, 0x24, 0x2e, 0xd9, 0x96, 0x6a, 0xa5, 0x56, 0x10, 0xa6, 0xa8,
+	0x07, 0x0e, 0x98, 0x59, 0x7b, 0xd6, 0x1e, 0xe2, 0xf5, 0x58, 0xf6, 0x78, 0xc5, 0x4a, 0x9c, 0xf8,
+	0x04, 0xfd, 0x0c, 0xdc, 0xb9, 0x71, 0xe1, 0x1b, 0xf4, 0xd8, 0x23, 0x17, 0x28, 0x4a, 0xbe, 0x08,
+	0xf2, 0x78, 0x6c, 0xa7, 0x6b, 0x3b, 0x09, 0x28, 0xe2, 0xb2, 0xf2, 0xbe, 0xf7, 0x7b, 0xef, 0xf7,
+	0xde, 0xbc, 0x3f, 0x33, 0xb0, 0x2b, 0x68, 0xe0, 0xd0, 0x68, 0xce, 0x02, 0x31, 0x12, 0xcb, 0x90,
+	0xc6, 0x23, 0xba, 0x60, 0x0e, 0x0d, 0x6c, 0x6a, 0x86, 0x11, 0x17, 0x1c, 0x6d, 0x96, 0x00, 0x53,
+	0x02, 0xfa, 0x5b, 0x2e, 0x77, 0xb9, 0x54, 0x8e, 0xd2, 0xaf, 0x0c, 0xd7, 0xdf, 0x75, 0x39, 0x77,
+	0x7d, 0x3a, 0x92, 0xff, 0xa6, 0xc9, 0x6c, 0x24, 0xd8, 0x9c, 0xc6, 0x82, 0xcc, 0x43, 0x05, 0xd8,
+	0xa9, 0x30, 0xc9, 0xdf, 0x1a, 0xad, 0x1d, 0x2d, 0x43, 0xc1, 0x47, 0x87, 0x74, 0xa9, 0xb4, 0xc3,
+	0xdf, 0x75, 0xd8, 0x7e, 0x94, 0x84, 0x3e, 0xb3, 0x89, 0xa0, 0xcf, 0xb9, 0xa0, 0x5f, 0xa8, 0x20,
+	0xd1, 0xc7, 0xd0, 0x5b, 0x70, 0x41, 0x2d, 0x62, 0xe8, 0x37, 0xf4, 0x5b, 0x97, 0xf7, 0xae, 0x99,
+	0xab, 0xf1, 0x9a, 0x29, 0x1e, 0x77, 0x53, 0xd4, 0x7e, 0x01, 0x9f, 0x1a, 0xad, 0xb3, 0xe1, 0x63,
+	0x34, 0x86, 0xf5, 0x22, 0x0d, 0xa3, 0x2d, 0x2d, 0xfa, 0x66, 0x96, 0xa8, 0x99, 0x27, 0x6a, 0x7e,
+	0x93, 0x23, 0xc6, 0x6b, 0x2f, 0xff, 0xda, 0xd5, 0x5e, 0xbc, 0xde, 0xd5, 0x71, 0x69, 0x36, 0x7c,
+	0xad, 0x83, 0x71, 0xc0, 0x05, 0x0d, 0x04, 0x23, 0xfe, 0xfe, 0x3c, 0xa0, 0x31, 0x23, 0xff, 0x53,
+	0xf8, 0x37, 0x61, 0xc3, 0xa3, 0xcc, 0xf5, 0x84, 0x55, 0x66, 0xd0, 0xc6, 0x97, 0x33, 0xd9, 0xb3,
+	0x54, 0xf4, 0x66, 0x86, 0x9d, 0xff, 0x96, 0xe1, 0x6f, 0x3a, 0x5c, 0x59, 0x4d, 0xcc, 0x83, 0x7e,
+	0x98, 0x27, 0x6d, 0x91, 0x4c, 0x69, 0xe5, 0xad, 0xa5, 0x92, 0xbd, 0x5d, 0x8d, 0xbe, 0xe9, 0xa0,
+	0xb0, 0x11, 0x36, 0x1d, 0xe1, 0x03, 0xe8, 0x84, 0xdc, 0xb7, 0xd5, 0x89, 0xbc, 0x5f, 0xe3, 0x33,
+	0xe2, 0x7c, 0xf6, 0xd5, 0xec, 0x29, 0xb7, 0x0f, 0x1f, 0x7a, 0x24, 0x70, 0x29, 0x96, 0x06, 0xc3,
+	0x9f, 0xa0, 0xff, 0x90, 0x07, 0x33, 0x9f, 0xd9, 0x82, 0x05, 0xee, 0x84, 0x12, 0x87, 0x46, 0x71,
+	0xe1, 0xd6, 0x84, 0x96, 0x77, 0x5f, 0x05, 0x3a, 0xa8, 0x3a, 0x7d, 0xc6, 0xdc, 0x80, 0x3a, 0x99,
+	0x11, 0x6e, 0x79, 0xf7, 0x25, 0x7e, 0xcf, 0x68, 0x9d, 0x13, 0xbf, 0x37, 0xfc, 0xa5, 0x05, 0xc6,
+	0xd3, 0x24, 0x20, 0x82, 0xd9, 0xcf, 0x89, 0xcf, 0x1c, 0x22, 0x78, 0x54, 0x90, 0xdf, 0x83, 0x9e,
+	0x27, 0xa1, 0x2a, 0x00, 0xa3, 0xea, 0x50, 0xb9, 0x52, 0x38, 0x74, 0x1b, 0x3a, 0x69, 0xcd, 0xcf,
+	0xe8, 0x0b, 0x89, 0x41, 0xf7, 0x60, 0x8b, 0x05, 0x8b, 0x94, 0xd4, 0xca, 0xac, 0xad, 0x19, 0xa3,
+	0xbe, 0x23, 0xdb, 0x63, 0x1d, 0x23, 0xa5, 0xcb, 0x08, 0x1e, 0xa7, 0x1a, 0xb4, 0x07, 0xdb, 0x75,
+	0x16, 0xb1, 0xd1, 0xbd, 0xd1, 0xbe, 0xb5, 0x8e, 0xdf, 0xa9, 0x9a, 0xc4, 0x17, 0xd2, 0x59, 0x3f,
+	0xb7, 0xe0, 0x7a, 0x31, 0xf7, 0x07, 0x11, 0x0f, 0x79, 0x4c, 0xfc, 0xe2, 0x94, 0x3e, 0x03, 0x08,
+	0x95, 0xac, 0x18, 0xa0, 0x7e, 0x6d, 0xfd, 0x25, 0x06, 0xaf, 0xe7, 0xe8, 0xfd, 0x37, 0x4c, 0xf3,
+	0x61, 0x3a, 0x97, 0xe9, 0x18, 0xdd, 0x81, 0xab, 0x8b, 0xbc, 0x60, 0x16, 0x71, 0x9c, 0x88, 0xc6,
+	0xb1, 0x3c, 0xba, 0x0d, 0xbc, 0x59, 0x28, 0xf6, 0x33, 0xf9, 0x85, 0x1c, 0xc2, 0xaf, 0x3a, 0x6c,
+	0x3d, 0x4e, 0x44, 0x12, 0xd1, 0x89, 0x1c, 0xdc, 0x22, 0xff, 0xbc, 0xe6, 0xfa, 0x39, 0x6a, 0xfe,
+	0x01, 0xbc, 0x6d, 0xfb, 0x84, 0xcd, 0x69, 0x5a, 0xc1, 0xd4, 0x8b, 0x4c, 0xba, 0x8d, 0xdf, 0x52,
+	0xd2, 0xcc, 0xf5, 0x85, 0x2c, 0xbc, 0x3f, 0xbb, 0xb0, 0x56, 0xc4, 0x48, 0xe0, 0x5d, 0x27, 0x2f,
+	0xa0, 0x25, 0x77, 0xd7, 0xca, 0x12, 0xf8, 0xa8, 0x1a, 0x76, 0xed, 0xa6, 0x9f, 0x68, 0x78, 0xdb,
+	0xa9, 0x53, 0xa0, 0x10, 0x76, 0xec, 0x72, 0x8e, 0x55, 0x83, 0xc6, 0x25, 0x4f, 0x56, 0xdd, 0xbb,
+	0x55, 0x9e, 0xe6, 0xe9, 0x9f, 0x68, 0xb8, 0x6f, 0x37, 0x6a, 0xd1, 0x0f, 0xd0, 0xf7, 0xb3, 0xd1,
+	0xb5, 0xca, 0x56, 0x28, 0xf8, 0xda, 0x4d, 0xcb, 0xad, 0x69, 0xdc, 0x27, 0x1a, 0x36, 0xfc, 0x06,
+	0x5d, 0xca, 0x75, 0xca, 0x22, 0xed, 0xfc, 0xdb, 0x45, 0x9a, 0x72, 0x35, 0xae, 0xd2, 0x2f, 0x61,
+	0xb3, 0xc2, 0xd0, 0x95, 0x0c, 0x37, 0xab, 0x0c, 0x55, 0xc7, 0x57, 0xc8, 0x8a, 0xbf, 0x39, 0xbc,
+	0x57, 0x16, 0xbf, 0x98, 0xb7, 0xc2, 0x75, 0x4f, 0xba, 0xbe, 0x73, 0x4a, 0x03, 0xac, 0x8e, 0xfc,
+	0x44, 0xc3, 0xd7, 0x9d, 0x26, 0x25, 0xfa, 0x0e, 0xae, 0xcd, 0xe4, 0x9c, 0xa8, 0x16, 0x2f, 0x99,
+	0x2e, 0x49, 0xa6, 0x0f, 0xab, 0x4c, 0x75, 0x73, 0x35, 0xd1, 0xf0, 0xd6, 0xac, 0x46, 0x3e, 0xee,
+	0x42, 0x3b, 0x4e, 0xe6, 0xc3, 0xef, 0x61, 0x23, 0x17, 0x3d, 0x22, 0x82, 0xa0, 0xcf, 0x61, 0xed,
+	0x44, 0x4f, 0xb7, 0xeb, 0x37, 0x49, 0xe1, 0xa4, 0x93, 0x8e, 0x0c, 0x2e, 0x2c, 0x10, 0x82, 0x8e,
+	0x47, 0x62, 0x4f, 0x76, 0xe9, 0x06, 0x96, 0xdf, 0xc3, 0x1f, 0xe1, 0x6a, 0xe5, 0xd2, 0x42, 0x77,
+	0x41, 0xde, 0xea, 0xb1, 0xe2, 0x38, 0xf5, 0xea, 0x8f, 0xd1, 0xa7, 0x70, 0x29, 0x4c, 0xa6, 0xd6,
+	0x21, 0x5d, 0xaa, 0xfe, 0xdf, 0x39, 0x89, 0xcf, 0x5e, 0x58, 0xe6, 0x41, 0x32, 0xf5, 0x99, 0xfd,
+	0x84, 0x2e, 0x71, 0x2f, 0x4c, 0xa6, 0x4f, 0xe8, 0x72, 0xfc, 0xf5, 0xcb, 0xa3, 0x81, 0xfe, 0xea,
+	0x68, 0xa0, 0xff, 0x7d, 0x34, 0xd0, 0x5f, 0x1c, 0x0f, 0xb4, 0x57, 0xc7, 0x03, 0xed, 0x8f, 0xe3,
+	0x81, 0xf6, 0xed, 0x03, 0x97, 0x09, 0x2f, 0x99, 0x9a, 0x36, 0x9f, 0x8f, 0x4e, 0xbe, 0xe4, 0xca,
+	0xcf, 0xec, 0x49, 0xb8, 0xfa, 0xca, 0x9b, 0xf6, 0xa4, 0xfc, 0x93, 0x7f, 0x06, 0x00, 0xb3, 0xd0,
+	0xc2, 0xeb, 0x6a, 0x0a, 0x00, 0x00,
 }
 
 func (m *DuplicateVoteEvidence) Marshal() (dAtA []byte, err error) {
@@ -850,6 +1031,15 @@ func (m *LunaticValidatorEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error
 	_ = i
 	var l int
 	_ = l
+	if len(m.InvalidHeaderFields) > 0 {
+		for iNdEx := len(m.InvalidHeaderFields) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.InvalidHeaderFields[iNdEx])
+			copy(dAtA[i:], m.InvalidHeaderFields[iNdEx])
+			i = encodeVarintEvidence(dAtA, i, uint64(len(m.InvalidHeaderFields[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
 	if err11 != nil {
 		return 0, err11
@@ -892,6 +1082,116 @@ func (m *LunaticValidatorEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error
 	return len(dAtA) - i, nil
 }
 
+func (m *DuplicateProposalEvidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DuplicateProposalEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DuplicateProposalEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n14, err14 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err14 != nil {
+		return 0, err14
+	}
+	i -= n14
+	i = encodeVarintEvidence(dAtA, i, uint64(n14))
+	i--
+	dAtA[i] = 0x22
+	if len(m.ValidatorAddress) > 0 {
+		i -= len(m.ValidatorAddress)
+		copy(dAtA[i:], m.ValidatorAddress)
+		i = encodeVarintEvidence(dAtA, i, uint64(len(m.ValidatorAddress)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ProposalB != nil {
+		{
+			size, err := m.ProposalB.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvidence(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ProposalA != nil {
+		{
+			size, err := m.ProposalA.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvidence(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FutureHeightEvidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FutureHeightEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FutureHeightEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err17 != nil {
+		return 0, err17
+	}
+	i -= n17
+	i = encodeVarintEvidence(dAtA, i, uint64(n17))
+	i--
+	dAtA[i] = 0x1a
+	if m.ClaimedHeight != 0 {
+		i = encodeVarintEvidence(dAtA, i, uint64(m.ClaimedHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Vote != nil {
+		{
+			size, err := m.Vote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvidence(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Evidence) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1029,6 +1329,48 @@ func (m *Evidence_AmnesiaEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Evidence_DuplicateProposalEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Evidence_DuplicateProposalEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.DuplicateProposalEvidence != nil {
+		{
+			size, err := m.DuplicateProposalEvidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvidence(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Evidence_FutureHeightEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Evidence_FutureHeightEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.FutureHeightEvidence != nil {
+		{
+			size, err := m.FutureHeightEvidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintEvidence(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *EvidenceData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1228,6 +1570,53 @@ func (m *LunaticValidatorEvidence) Size() (n int) {
 	}
 	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
 	n += 1 + l + sovEvidence(uint64(l))
+	if len(m.InvalidHeaderFields) > 0 {
+		for _, s := range m.InvalidHeaderFields {
+			l = len(s)
+			n += 1 + l + sovEvidence(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DuplicateProposalEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProposalA != nil {
+		l = m.ProposalA.Size()
+		n += 1 + l + sovEvidence(uint64(l))
+	}
+	if m.ProposalB != nil {
+		l = m.ProposalB.Size()
+		n += 1 + l + sovEvidence(uint64(l))
+	}
+	l = len(m.ValidatorAddress)
+	if l > 0 {
+		n += 1 + l + sovEvidence(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovEvidence(uint64(l))
+	return n
+}
+
+func (m *FutureHeightEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Vote != nil {
+		l = m.Vote.Size()
+		n += 1 + l + sovEvidence(uint64(l))
+	}
+	if m.ClaimedHeight != 0 {
+		n += 1 + sovEvidence(uint64(m.ClaimedHeight))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovEvidence(uint64(l))
 	return n
 }
 
@@ -1303,13 +1692,37 @@ func (m *Evidence_AmnesiaEvidence) Size() (n int) {
 	}
 	return n
 }
-func (m *EvidenceData) Size() (n int) {
+func (m *Evidence_DuplicateProposalEvidence) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Evidence) > 0 {
+	if m.DuplicateProposalEvidence != nil {
+		l = m.DuplicateProposalEvidence.Size()
+		n += 1 + l + sovEvidence(uint64(l))
+	}
+	return n
+}
+func (m *Evidence_FutureHeightEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FutureHeightEvidence != nil {
+		l = m.FutureHeightEvidence.Size()
+		n += 1 + l + sovEvidence(uint64(l))
+	}
+	return n
+}
+func (m *EvidenceData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Evidence) > 0 {
 		for _, e := range m.Evidence {
 			l = e.Size()
 			n += 1 + l + sovEvidence(uint64(l))
@@ -2098,6 +2511,371 @@ func (m *LunaticValidatorEvidence) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InvalidHeaderFields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InvalidHeaderFields = append(m.InvalidHeaderFields, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvidence(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DuplicateProposalEvidence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvidence
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DuplicateProposalEvidence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DuplicateProposalEvidence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalA", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProposalA == nil {
+				m.ProposalA = &Proposal{}
+			}
+			if err := m.ProposalA.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalB", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProposalB == nil {
+				m.ProposalB = &Proposal{}
+			}
+			if err := m.ProposalB.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorAddress = append(m.ValidatorAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValidatorAddress == nil {
+				m.ValidatorAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvidence(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FutureHeightEvidence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvidence
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FutureHeightEvidence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FutureHeightEvidence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vote == nil {
+				m.Vote = &Vote{}
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClaimedHeight", wireType)
+			}
+			m.ClaimedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClaimedHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvidence(dAtA[iNdEx:])
@@ -2326,6 +3104,76 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Evidence_AmnesiaEvidence{v}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DuplicateProposalEvidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &DuplicateProposalEvidence{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Evidence_DuplicateProposalEvidence{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FutureHeightEvidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvidence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvidence
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &FutureHeightEvidence{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Evidence_FutureHeightEvidence{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvidence(dAtA[iNdEx:])
@@ -484,6 +484,10 @@ type Vote struct {
 	ValidatorAddress []byte        `protobuf:"bytes,6,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
 	ValidatorIndex   int32         `protobuf:"varint,7,opt,name=validator_index,json=validatorIndex,proto3" json:"validator_index,omitempty"`
 	Signature        []byte        `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Application data attached to a precommit, and its signature (see
+	// types.VoteExtensionSignBytes). Neither is included in commits.
+	Extension          []byte `protobuf:"bytes,9,opt,name=extension,proto3" json:"extension,omitempty"`
+	ExtensionSignature []byte `protobuf:"bytes,10,opt,name=extension_signature,json=extensionSignature,proto3" json:"extension_signature,omitempty"`
 }
 
 func (m *Vote) Reset()         { *m = Vote{} }
@@ -575,6 +579,20 @@ func (m *Vote) GetSignature() []byte {
 	return nil
 }
 
+func (m *Vote) GetExtension() []byte {
+	if m != nil {
+		return m.Extension
+	}
+	return nil
+}
+
+func (m *Vote) GetExtensionSignature() []byte {
+	if m != nil {
+		return m.ExtensionSignature
+	}
+	return nil
+}
+
 // Commit contains the evidence that a block was committed by a set of validators.
 type Commit struct {
 	Height     int64          `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -1022,89 +1040,91 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1312 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcf, 0x6e, 0xdb, 0xc6,
-	0x13, 0x36, 0x25, 0xca, 0x92, 0x46, 0x96, 0x2d, 0x2f, 0x9c, 0x44, 0x51, 0x62, 0x99, 0xd0, 0x0f,
-	0xbf, 0xd6, 0x49, 0x03, 0x2a, 0x75, 0x8a, 0xfe, 0x41, 0xd1, 0x83, 0x64, 0x3b, 0x89, 0x10, 0x5b,
-	0x56, 0x29, 0x25, 0x45, 0x7b, 0x21, 0x28, 0x71, 0x23, 0xb1, 0xa1, 0xb8, 0x04, 0xb9, 0x72, 0xed,
-	0x3c, 0x41, 0xe1, 0x53, 0xfa, 0x00, 0x3a, 0xb5, 0x87, 0xde, 0xfb, 0x06, 0x3d, 0xe5, 0x98, 0x5b,
-	0x7b, 0x69, 0x5a, 0x38, 0x40, 0xd1, 0xc7, 0x28, 0xf6, 0x8f, 0x28, 0xca, 0xb2, 0xdb, 0x20, 0x08,
-	0x7a, 0x11, 0x76, 0x67, 0xbe, 0x99, 0xdd, 0xf9, 0xf6, 0xdb, 0x1d, 0x0a, 0xae, 0x53, 0xec, 0xd9,
-	0x38, 0x18, 0x3a, 0x1e, 0xad, 0xd2, 0x63, 0x1f, 0x87, 0xe2, 0x57, 0xf7, 0x03, 0x42, 0x09, 0x2a,
-	0x4c, 0xbd, 0x3a, 0xb7, 0x97, 0xd6, 0xfa, 0xa4, 0x4f, 0xb8, 0xb3, 0xca, 0x46, 0x02, 0x57, 0xda,
-	0xe8, 0x13, 0xd2, 0x77, 0x71, 0x95, 0xcf, 0xba, 0xa3, 0xc7, 0x55, 0xea, 0x0c, 0x71, 0x48, 0xad,
-	0xa1, 0x2f, 0x01, 0x5a, 0x6c, 0x19, 0xd7, 0xe9, 0x86, 0xd5, 0xae, 0x43, 0x67, 0x96, 0x2a, 0xad,
-	0xc7, 0x10, 0xbd, 0xe0, 0xd8, 0xa7, 0x84, 0x65, 0x23, 0x8f, 0xa5, 0xbb, 0x1c, 0x73, 0x1f, 0xe2,
-	0x20, 0x74, 0x88, 0x17, 0x0f, 0xaf, 0x7c, 0x02, 0xf9, 0x96, 0x15, 0xd0, 0x36, 0xa6, 0xf7, 0xb1,
-	0x65, 0xe3, 0x00, 0xad, 0x41, 0x8a, 0x12, 0x6a, 0xb9, 0x45, 0x45, 0x53, 0x36, 0xf3, 0x86, 0x98,
-	0x20, 0x04, 0xea, 0xc0, 0x0a, 0x07, 0xc5, 0x84, 0xa6, 0x6c, 0x2e, 0x19, 0x7c, 0x5c, 0x19, 0x80,
-	0xca, 0x42, 0x59, 0x84, 0xe3, 0xd9, 0xf8, 0x68, 0x12, 0xc1, 0x27, 0xcc, 0xda, 0x3d, 0xa6, 0x38,
-	0x94, 0x21, 0x62, 0x82, 0x3e, 0x80, 0x14, 0xdf, 0x5d, 0x31, 0xa9, 0x29, 0x9b, 0xb9, 0xad, 0xa2,
-	0x1e, 0x23, 0x4a, 0xec, 0x5e, 0x6f, 0x31, 0x7f, 0x5d, 0x7d, 0xfe, 0x72, 0x63, 0xc1, 0x10, 0xe0,
-	0x8a, 0x0b, 0xe9, 0xba, 0x4b, 0x7a, 0x4f, 0x1a, 0x3b, 0xd1, 0x46, 0x94, 0xe9, 0x46, 0xd0, 0x3e,
-	0xac, 0xf8, 0x56, 0x40, 0xcd, 0x10, 0x53, 0x73, 0xc0, 0xab, 0xe0, 0x8b, 0xe6, 0xb6, 0x36, 0xf4,
-	0xb3, 0xe7, 0xa0, 0xcf, 0x14, 0x2b, 0x57, 0xc9, 0xfb, 0x71, 0x63, 0xe5, 0x4f, 0x15, 0x16, 0x25,
-	0x19, 0x9f, 0x41, 0x5a, 0x92, 0xc6, 0x17, 0xcc, 0x6d, 0xad, 0xc7, 0x33, 0x4a, 0x97, 0xbe, 0x4d,
-	0xbc, 0x10, 0x7b, 0xe1, 0x28, 0x94, 0xf9, 0x26, 0x31, 0xe8, 0x1d, 0xc8, 0xf4, 0x06, 0x96, 0xe3,
-	0x99, 0x8e, 0xcd, 0x77, 0x94, 0xad, 0xe7, 0x4e, 0x5f, 0x6e, 0xa4, 0xb7, 0x99, 0xad, 0xb1, 0x63,
-	0xa4, 0xb9, 0xb3, 0x61, 0xa3, 0xcb, 0xb0, 0x38, 0xc0, 0x4e, 0x7f, 0x40, 0x39, 0x2d, 0x49, 0x43,
-	0xce, 0xd0, 0xc7, 0xa0, 0x32, 0x41, 0x14, 0x55, 0xbe, 0x76, 0x49, 0x17, 0x6a, 0xd1, 0x27, 0x6a,
-	0xd1, 0x3b, 0x13, 0xb5, 0xd4, 0x33, 0x6c, 0xe1, 0x67, 0xbf, 0x6f, 0x28, 0x06, 0x8f, 0x40, 0xdb,
-	0x90, 0x77, 0xad, 0x90, 0x9a, 0x5d, 0x46, 0x1b, 0x5b, 0x3e, 0xc5, 0x53, 0x5c, 0x9d, 0x27, 0x44,
-	0x12, 0x2b, 0xb7, 0x9e, 0x63, 0x51, 0xc2, 0x64, 0xa3, 0x4d, 0x28, 0xf0, 0x24, 0x3d, 0x32, 0x1c,
-	0x3a, 0xd4, 0xe4, 0xbc, 0x2f, 0x72, 0xde, 0x97, 0x99, 0x7d, 0x9b, 0x9b, 0xef, 0xb3, 0x13, 0xb8,
-	0x06, 0x59, 0xdb, 0xa2, 0x96, 0x80, 0xa4, 0x39, 0x24, 0xc3, 0x0c, 0xdc, 0xf9, 0x2e, 0xac, 0x1c,
-	0x5a, 0xae, 0x63, 0x5b, 0x94, 0x04, 0xa1, 0x80, 0x64, 0x44, 0x96, 0xa9, 0x99, 0x03, 0x6f, 0xc3,
-	0x9a, 0x87, 0x8f, 0xa8, 0x79, 0x16, 0x9d, 0xe5, 0x68, 0xc4, 0x7c, 0x8f, 0x66, 0x23, 0xfe, 0x0f,
-	0xcb, 0xbd, 0x09, 0xf9, 0x02, 0x0b, 0x1c, 0x9b, 0x8f, 0xac, 0x1c, 0x76, 0x15, 0x32, 0x96, 0xef,
-	0x0b, 0x40, 0x8e, 0x03, 0xd2, 0x96, 0xef, 0x73, 0xd7, 0x4d, 0x58, 0xe5, 0x35, 0x06, 0x38, 0x1c,
-	0xb9, 0x54, 0x26, 0x59, 0xe2, 0x98, 0x15, 0xe6, 0x30, 0x84, 0x9d, 0x63, 0xff, 0x07, 0x79, 0x7c,
-	0xe8, 0xd8, 0xd8, 0xeb, 0x61, 0x81, 0xcb, 0x73, 0xdc, 0xd2, 0xc4, 0xc8, 0x41, 0x37, 0xa0, 0xe0,
-	0x07, 0xc4, 0x27, 0x21, 0x0e, 0x4c, 0xcb, 0xb6, 0x03, 0x1c, 0x86, 0xc5, 0x65, 0x91, 0x6f, 0x62,
-	0xaf, 0x09, 0x73, 0xe5, 0x16, 0xa8, 0x3b, 0x16, 0xb5, 0x50, 0x01, 0x92, 0xf4, 0x28, 0x2c, 0x2a,
-	0x5a, 0x72, 0x73, 0xc9, 0x60, 0xc3, 0x73, 0xaf, 0xdb, 0x5f, 0x09, 0x50, 0x1f, 0x11, 0x8a, 0xd1,
-	0x1d, 0x50, 0xd9, 0xd1, 0x71, 0x45, 0x2e, 0x9f, 0xa7, 0xf1, 0xb6, 0xd3, 0xf7, 0xb0, 0xbd, 0x1f,
-	0xf6, 0x3b, 0xc7, 0x3e, 0x36, 0x38, 0x38, 0x26, 0xb1, 0xc4, 0x8c, 0xc4, 0xd6, 0x20, 0x15, 0x90,
-	0x91, 0x67, 0x73, 0xe5, 0xa5, 0x0c, 0x31, 0x41, 0xbb, 0x90, 0x89, 0x94, 0xa3, 0xfe, 0x9b, 0x72,
-	0x56, 0x98, 0x72, 0x98, 0xae, 0xa5, 0xc1, 0x48, 0x77, 0xa5, 0x80, 0xea, 0x90, 0x8d, 0x1e, 0x34,
-	0xa9, 0xc0, 0xd7, 0x13, 0xf1, 0x34, 0x0c, 0xbd, 0x07, 0xab, 0x91, 0x1e, 0x22, 0x42, 0x85, 0x0a,
-	0x0b, 0x91, 0x43, 0x32, 0x3a, 0x23, 0x35, 0x53, 0x3c, 0x4a, 0x69, 0x5e, 0xd7, 0x54, 0x6a, 0x0d,
-	0xfe, 0x3a, 0x5d, 0x87, 0x6c, 0xe8, 0xf4, 0x3d, 0x8b, 0x8e, 0x02, 0x2c, 0xd5, 0x38, 0x35, 0x54,
-	0xbe, 0x4b, 0xc0, 0xa2, 0x50, 0x77, 0x8c, 0x37, 0xe5, 0x7c, 0xde, 0x12, 0x17, 0xf1, 0x96, 0x7c,
-	0x73, 0xde, 0x6a, 0x00, 0xd1, 0x66, 0xc2, 0xa2, 0xaa, 0x25, 0x37, 0x73, 0x5b, 0xd7, 0xe6, 0x13,
-	0x89, 0x2d, 0xb6, 0x9d, 0xbe, 0xbc, 0xbc, 0xb1, 0xa0, 0x48, 0x41, 0xa9, 0xd8, 0x3b, 0xf9, 0x29,
-	0x64, 0xbb, 0x0e, 0x35, 0xad, 0x20, 0xb0, 0x8e, 0x39, 0x85, 0xb9, 0xad, 0x72, 0x3c, 0x2b, 0x6b,
-	0x30, 0x3a, 0x6b, 0x30, 0x7a, 0xdd, 0xa1, 0x35, 0x86, 0x32, 0x32, 0x5d, 0x39, 0xaa, 0xfc, 0xa6,
-	0x40, 0x36, 0x5a, 0x10, 0xd5, 0x20, 0x3f, 0x29, 0xd4, 0x7c, 0xec, 0x5a, 0x7d, 0x29, 0xc6, 0xf5,
-	0x0b, 0xab, 0xbd, 0xeb, 0x5a, 0x7d, 0x23, 0x27, 0x0b, 0x64, 0x93, 0xf3, 0x0f, 0x36, 0x71, 0xc1,
-	0xc1, 0xce, 0x28, 0x29, 0xf9, 0x66, 0x4a, 0x9a, 0x39, 0x73, 0xf5, 0xec, 0x99, 0xff, 0x94, 0x80,
-	0x4c, 0x8b, 0x5f, 0x50, 0xcb, 0xfd, 0x2f, 0xae, 0xd8, 0x35, 0xc8, 0xfa, 0xc4, 0x35, 0x85, 0x47,
-	0xe5, 0x9e, 0x8c, 0x4f, 0x5c, 0x63, 0x4e, 0x47, 0xa9, 0xb7, 0x74, 0xff, 0x16, 0xdf, 0x02, 0x6b,
-	0xe9, 0xb3, 0xac, 0x05, 0xb0, 0x24, 0xa8, 0x90, 0x0d, 0xf3, 0x36, 0xe3, 0x80, 0x77, 0x60, 0x65,
-	0xbe, 0xc1, 0x8b, 0x6d, 0x0b, 0xa4, 0x21, 0x71, 0x2c, 0x42, 0xf4, 0x17, 0xd9, 0xb3, 0x8b, 0x17,
-	0xe9, 0xdc, 0x90, 0xb8, 0xca, 0xcf, 0x0a, 0x64, 0x79, 0xa9, 0xfb, 0x98, 0x5a, 0x33, 0x54, 0x29,
-	0x6f, 0x4e, 0xd5, 0x3a, 0x80, 0x48, 0x13, 0x3a, 0x4f, 0xb1, 0x3c, 0xc0, 0x2c, 0xb7, 0xb4, 0x9d,
-	0xa7, 0x18, 0x7d, 0x18, 0xd5, 0x95, 0xfc, 0xe7, 0xba, 0xe4, 0x55, 0x9c, 0x54, 0x77, 0x05, 0xd2,
-	0xde, 0x68, 0x68, 0xb2, 0xe7, 0x5d, 0x15, 0xa2, 0xf0, 0x46, 0xc3, 0xce, 0x51, 0x58, 0xf9, 0x1a,
-	0xd2, 0x9d, 0x23, 0xfe, 0xa9, 0xc3, 0x94, 0x10, 0x10, 0x22, 0xfb, 0xab, 0xf8, 0xae, 0xc9, 0x30,
-	0x03, 0x6f, 0x27, 0x08, 0x54, 0xd6, 0x48, 0x27, 0x9d, 0x80, 0x8d, 0x91, 0xfe, 0x9a, 0x1f, 0x51,
-	0xf2, 0xf3, 0xe9, 0xe6, 0x2f, 0x0a, 0xe4, 0x62, 0xd7, 0x10, 0xbd, 0x0f, 0x97, 0xea, 0x7b, 0x07,
-	0xdb, 0x0f, 0xcc, 0xc6, 0x8e, 0x79, 0x77, 0xaf, 0x76, 0xcf, 0x7c, 0xd8, 0x7c, 0xd0, 0x3c, 0xf8,
-	0xa2, 0x59, 0x58, 0x28, 0x5d, 0x3e, 0x19, 0x6b, 0x28, 0x86, 0x7d, 0xe8, 0x3d, 0xf1, 0xc8, 0x37,
-	0x1e, 0xaa, 0xc2, 0xda, 0x6c, 0x48, 0xad, 0xde, 0xde, 0x6d, 0x76, 0x0a, 0x4a, 0xe9, 0xd2, 0xc9,
-	0x58, 0x5b, 0x8d, 0x45, 0xd4, 0xba, 0x21, 0xf6, 0xe8, 0x7c, 0xc0, 0xf6, 0xc1, 0xfe, 0x7e, 0xa3,
-	0x53, 0x48, 0xcc, 0x05, 0xc8, 0x87, 0xf6, 0x06, 0xac, 0xce, 0x06, 0x34, 0x1b, 0x7b, 0x85, 0x64,
-	0x09, 0x9d, 0x8c, 0xb5, 0xe5, 0x18, 0xba, 0xe9, 0xb8, 0xa5, 0xcc, 0xb7, 0xdf, 0x97, 0x17, 0x7e,
-	0xfc, 0xa1, 0xac, 0xb0, 0xca, 0xf2, 0x33, 0x57, 0x11, 0xdd, 0x82, 0x2b, 0xed, 0xc6, 0xbd, 0xe6,
-	0xee, 0x8e, 0xb9, 0xdf, 0xbe, 0x67, 0x76, 0xbe, 0x6c, 0xed, 0xc6, 0xaa, 0x5b, 0x39, 0x19, 0x6b,
-	0x39, 0x59, 0xd2, 0x45, 0xe8, 0x96, 0xb1, 0xfb, 0xe8, 0xa0, 0xb3, 0x5b, 0x50, 0x04, 0xba, 0x15,
-	0xe0, 0x43, 0x42, 0x31, 0x47, 0xdf, 0x86, 0xab, 0xe7, 0xa0, 0xa3, 0xc2, 0x56, 0x4f, 0xc6, 0x5a,
-	0xbe, 0x15, 0x60, 0x21, 0x53, 0x1e, 0xa1, 0x43, 0x71, 0x3e, 0xe2, 0xa0, 0x75, 0xd0, 0xae, 0xed,
-	0x15, 0xb4, 0x52, 0xe1, 0x64, 0xac, 0x2d, 0x4d, 0xde, 0x1c, 0x86, 0x9f, 0x56, 0x56, 0xff, 0xfc,
-	0xf9, 0x69, 0x59, 0x79, 0x71, 0x5a, 0x56, 0xfe, 0x38, 0x2d, 0x2b, 0xcf, 0x5e, 0x95, 0x17, 0x5e,
-	0xbc, 0x2a, 0x2f, 0xfc, 0xfa, 0xaa, 0xbc, 0xf0, 0xd5, 0x47, 0x7d, 0x87, 0x0e, 0x46, 0x5d, 0xbd,
-	0x47, 0x86, 0xd5, 0xf8, 0x9f, 0x90, 0xe9, 0x50, 0xfc, 0xcd, 0x38, 0xfb, 0x07, 0xa5, 0xbb, 0xc8,
-	0xed, 0x77, 0xfe, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xbd, 0x5b, 0x78, 0xe7, 0xbb, 0x0c, 0x00, 0x00,
+	// 1337 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4b, 0x6f, 0xdb, 0xc6,
+	0x16, 0x36, 0x25, 0xea, 0x75, 0x64, 0xd9, 0xf2, 0x5c, 0x27, 0x51, 0x94, 0x58, 0x26, 0x74, 0x71,
+	0x6f, 0x9d, 0x34, 0xa0, 0x52, 0xa7, 0xe8, 0x03, 0x45, 0x17, 0x92, 0xed, 0x24, 0x42, 0x6c, 0x59,
+	0xa5, 0x94, 0x14, 0xed, 0x86, 0xa0, 0xc4, 0x89, 0xc4, 0x86, 0xe2, 0x10, 0xe4, 0xc8, 0xb5, 0xf3,
+	0x0b, 0x0a, 0xaf, 0xd2, 0x1f, 0xe0, 0x55, 0xbb, 0xe8, 0xbe, 0x8b, 0xee, 0xbb, 0xca, 0x32, 0xbb,
+	0x76, 0xd3, 0xb4, 0x70, 0x80, 0xfe, 0x8e, 0x62, 0x1e, 0xa2, 0x28, 0xcb, 0xee, 0x23, 0x08, 0xba,
+	0x11, 0x66, 0xbe, 0xf3, 0x9d, 0x33, 0x73, 0xce, 0x7c, 0x33, 0x87, 0x82, 0xeb, 0x14, 0x7b, 0x36,
+	0x0e, 0x46, 0x8e, 0x47, 0x6b, 0xf4, 0xc8, 0xc7, 0xa1, 0xf8, 0xd5, 0xfd, 0x80, 0x50, 0x82, 0x8a,
+	0x53, 0xab, 0xce, 0xf1, 0xf2, 0xea, 0x80, 0x0c, 0x08, 0x37, 0xd6, 0xd8, 0x48, 0xf0, 0xca, 0xeb,
+	0x03, 0x42, 0x06, 0x2e, 0xae, 0xf1, 0x59, 0x6f, 0xfc, 0xb8, 0x46, 0x9d, 0x11, 0x0e, 0xa9, 0x35,
+	0xf2, 0x25, 0x41, 0x8b, 0x2d, 0xe3, 0x3a, 0xbd, 0xb0, 0xd6, 0x73, 0xe8, 0xcc, 0x52, 0xe5, 0xb5,
+	0x18, 0xa3, 0x1f, 0x1c, 0xf9, 0x94, 0xb0, 0x68, 0xe4, 0xb1, 0x34, 0x57, 0x62, 0xe6, 0x03, 0x1c,
+	0x84, 0x0e, 0xf1, 0xe2, 0xee, 0xd5, 0x0f, 0xa1, 0xd0, 0xb6, 0x02, 0xda, 0xc1, 0xf4, 0x3e, 0xb6,
+	0x6c, 0x1c, 0xa0, 0x55, 0x48, 0x51, 0x42, 0x2d, 0xb7, 0xa4, 0x68, 0xca, 0x46, 0xc1, 0x10, 0x13,
+	0x84, 0x40, 0x1d, 0x5a, 0xe1, 0xb0, 0x94, 0xd0, 0x94, 0x8d, 0x45, 0x83, 0x8f, 0xab, 0x43, 0x50,
+	0x99, 0x2b, 0xf3, 0x70, 0x3c, 0x1b, 0x1f, 0x4e, 0x3c, 0xf8, 0x84, 0xa1, 0xbd, 0x23, 0x8a, 0x43,
+	0xe9, 0x22, 0x26, 0xe8, 0x5d, 0x48, 0xf1, 0xdd, 0x95, 0x92, 0x9a, 0xb2, 0x91, 0xdf, 0x2c, 0xe9,
+	0xb1, 0x42, 0x89, 0xdd, 0xeb, 0x6d, 0x66, 0x6f, 0xa8, 0xcf, 0x5f, 0xae, 0x2f, 0x18, 0x82, 0x5c,
+	0x75, 0x21, 0xd3, 0x70, 0x49, 0xff, 0x49, 0x73, 0x3b, 0xda, 0x88, 0x32, 0xdd, 0x08, 0xda, 0x83,
+	0x65, 0xdf, 0x0a, 0xa8, 0x19, 0x62, 0x6a, 0x0e, 0x79, 0x16, 0x7c, 0xd1, 0xfc, 0xe6, 0xba, 0x7e,
+	0xf6, 0x1c, 0xf4, 0x99, 0x64, 0xe5, 0x2a, 0x05, 0x3f, 0x0e, 0x56, 0x7f, 0x57, 0x21, 0x2d, 0x86,
+	0xe8, 0x63, 0xc8, 0xc8, 0xa2, 0xf1, 0x05, 0xf3, 0x9b, 0x6b, 0xf1, 0x88, 0xd2, 0xa4, 0x6f, 0x11,
+	0x2f, 0xc4, 0x5e, 0x38, 0x0e, 0x65, 0xbc, 0x89, 0x0f, 0xfa, 0x3f, 0x64, 0xfb, 0x43, 0xcb, 0xf1,
+	0x4c, 0xc7, 0xe6, 0x3b, 0xca, 0x35, 0xf2, 0xa7, 0x2f, 0xd7, 0x33, 0x5b, 0x0c, 0x6b, 0x6e, 0x1b,
+	0x19, 0x6e, 0x6c, 0xda, 0xe8, 0x32, 0xa4, 0x87, 0xd8, 0x19, 0x0c, 0x29, 0x2f, 0x4b, 0xd2, 0x90,
+	0x33, 0xf4, 0x01, 0xa8, 0x4c, 0x10, 0x25, 0x95, 0xaf, 0x5d, 0xd6, 0x85, 0x5a, 0xf4, 0x89, 0x5a,
+	0xf4, 0xee, 0x44, 0x2d, 0x8d, 0x2c, 0x5b, 0xf8, 0xd9, 0xaf, 0xeb, 0x8a, 0xc1, 0x3d, 0xd0, 0x16,
+	0x14, 0x5c, 0x2b, 0xa4, 0x66, 0x8f, 0x95, 0x8d, 0x2d, 0x9f, 0xe2, 0x21, 0xae, 0xce, 0x17, 0x44,
+	0x16, 0x56, 0x6e, 0x3d, 0xcf, 0xbc, 0x04, 0x64, 0xa3, 0x0d, 0x28, 0xf2, 0x20, 0x7d, 0x32, 0x1a,
+	0x39, 0xd4, 0xe4, 0x75, 0x4f, 0xf3, 0xba, 0x2f, 0x31, 0x7c, 0x8b, 0xc3, 0xf7, 0xd9, 0x09, 0x5c,
+	0x83, 0x9c, 0x6d, 0x51, 0x4b, 0x50, 0x32, 0x9c, 0x92, 0x65, 0x00, 0x37, 0xbe, 0x05, 0xcb, 0x07,
+	0x96, 0xeb, 0xd8, 0x16, 0x25, 0x41, 0x28, 0x28, 0x59, 0x11, 0x65, 0x0a, 0x73, 0xe2, 0x6d, 0x58,
+	0xf5, 0xf0, 0x21, 0x35, 0xcf, 0xb2, 0x73, 0x9c, 0x8d, 0x98, 0xed, 0xd1, 0xac, 0xc7, 0xff, 0x60,
+	0xa9, 0x3f, 0x29, 0xbe, 0xe0, 0x02, 0xe7, 0x16, 0x22, 0x94, 0xd3, 0xae, 0x42, 0xd6, 0xf2, 0x7d,
+	0x41, 0xc8, 0x73, 0x42, 0xc6, 0xf2, 0x7d, 0x6e, 0xba, 0x09, 0x2b, 0x3c, 0xc7, 0x00, 0x87, 0x63,
+	0x97, 0xca, 0x20, 0x8b, 0x9c, 0xb3, 0xcc, 0x0c, 0x86, 0xc0, 0x39, 0xf7, 0xbf, 0x50, 0xc0, 0x07,
+	0x8e, 0x8d, 0xbd, 0x3e, 0x16, 0xbc, 0x02, 0xe7, 0x2d, 0x4e, 0x40, 0x4e, 0xba, 0x01, 0x45, 0x3f,
+	0x20, 0x3e, 0x09, 0x71, 0x60, 0x5a, 0xb6, 0x1d, 0xe0, 0x30, 0x2c, 0x2d, 0x89, 0x78, 0x13, 0xbc,
+	0x2e, 0xe0, 0xea, 0x2d, 0x50, 0xb7, 0x2d, 0x6a, 0xa1, 0x22, 0x24, 0xe9, 0x61, 0x58, 0x52, 0xb4,
+	0xe4, 0xc6, 0xa2, 0xc1, 0x86, 0xe7, 0x5e, 0xb7, 0x1f, 0x92, 0xa0, 0x3e, 0x22, 0x14, 0xa3, 0x3b,
+	0xa0, 0xb2, 0xa3, 0xe3, 0x8a, 0x5c, 0x3a, 0x4f, 0xe3, 0x1d, 0x67, 0xe0, 0x61, 0x7b, 0x2f, 0x1c,
+	0x74, 0x8f, 0x7c, 0x6c, 0x70, 0x72, 0x4c, 0x62, 0x89, 0x19, 0x89, 0xad, 0x42, 0x2a, 0x20, 0x63,
+	0xcf, 0xe6, 0xca, 0x4b, 0x19, 0x62, 0x82, 0x76, 0x20, 0x1b, 0x29, 0x47, 0xfd, 0x2b, 0xe5, 0x2c,
+	0x33, 0xe5, 0x30, 0x5d, 0x4b, 0xc0, 0xc8, 0xf4, 0xa4, 0x80, 0x1a, 0x90, 0x8b, 0x1e, 0xb4, 0x52,
+	0xea, 0x1f, 0x88, 0x78, 0xea, 0x86, 0xde, 0x86, 0x95, 0x48, 0x0f, 0x51, 0x41, 0x85, 0x0a, 0x8b,
+	0x91, 0x41, 0x56, 0x74, 0x46, 0x6a, 0xa6, 0x78, 0x94, 0x32, 0x3c, 0xaf, 0xa9, 0xd4, 0x9a, 0x0c,
+	0x45, 0xd7, 0x21, 0x17, 0x3a, 0x03, 0xcf, 0xa2, 0xe3, 0x00, 0x4b, 0x35, 0x4e, 0x01, 0x66, 0xc5,
+	0x87, 0x14, 0x7b, 0xfc, 0xe2, 0x0b, 0xf5, 0x4d, 0x01, 0x54, 0x83, 0xff, 0x44, 0x13, 0x73, 0x1a,
+	0x45, 0x28, 0x0f, 0x45, 0xa6, 0xce, 0xc4, 0x52, 0xfd, 0x3a, 0x01, 0x69, 0x71, 0x59, 0x62, 0xc7,
+	0xa0, 0x9c, 0x7f, 0x0c, 0x89, 0x8b, 0x8e, 0x21, 0xf9, 0xfa, 0xc7, 0x50, 0x07, 0x88, 0xb6, 0x19,
+	0x96, 0x54, 0x2d, 0xb9, 0x91, 0xdf, 0xbc, 0x36, 0x1f, 0x48, 0x6c, 0xb1, 0xe3, 0x0c, 0xe4, 0x5b,
+	0x10, 0x73, 0x8a, 0x04, 0x99, 0x8a, 0x3d, 0xbb, 0x1f, 0x41, 0xae, 0xe7, 0x50, 0xd3, 0x0a, 0x02,
+	0xeb, 0x88, 0x9f, 0x48, 0x7e, 0xb3, 0x12, 0x8f, 0xca, 0xfa, 0x95, 0xce, 0xfa, 0x95, 0xde, 0x70,
+	0x68, 0x9d, 0xb1, 0x8c, 0x6c, 0x4f, 0x8e, 0xaa, 0xbf, 0x28, 0x90, 0x8b, 0x16, 0x44, 0x75, 0x28,
+	0x4c, 0x12, 0x35, 0x1f, 0xbb, 0xd6, 0x40, 0x6a, 0x7b, 0xed, 0xc2, 0x6c, 0xef, 0xba, 0xd6, 0xc0,
+	0xc8, 0xcb, 0x04, 0xd9, 0xe4, 0x7c, 0x9d, 0x24, 0x2e, 0xd0, 0xc9, 0x8c, 0x30, 0x93, 0xaf, 0x27,
+	0xcc, 0x19, 0x09, 0xa9, 0x67, 0x24, 0x54, 0xfd, 0x3e, 0x01, 0xd9, 0x36, 0xbf, 0xef, 0x96, 0xfb,
+	0x6f, 0xdc, 0xd8, 0x6b, 0x90, 0xf3, 0x89, 0x6b, 0x0a, 0x8b, 0xca, 0x2d, 0x59, 0x9f, 0xb8, 0xc6,
+	0x9c, 0x8e, 0x52, 0x6f, 0xe8, 0x3a, 0xa7, 0xdf, 0x40, 0xd5, 0x32, 0x67, 0xab, 0x16, 0xc0, 0xa2,
+	0x28, 0x85, 0xec, 0xbf, 0xb7, 0x59, 0x0d, 0xd8, 0xa8, 0xa4, 0xcc, 0x7f, 0x2f, 0x88, 0x6d, 0x0b,
+	0xa6, 0x91, 0x1e, 0x46, 0x1e, 0xa2, 0x5d, 0x95, 0x12, 0x17, 0x79, 0x08, 0xd9, 0x19, 0x92, 0x57,
+	0xfd, 0x51, 0x81, 0x1c, 0x4f, 0x75, 0x0f, 0x53, 0x6b, 0xa6, 0x54, 0xca, 0xeb, 0x97, 0x6a, 0x0d,
+	0x40, 0x84, 0x09, 0x9d, 0xa7, 0x58, 0x1e, 0x60, 0x8e, 0x23, 0x1d, 0xe7, 0x29, 0x46, 0xef, 0x45,
+	0x79, 0x25, 0xff, 0x3c, 0x2f, 0x79, 0x15, 0x27, 0xd9, 0x5d, 0x81, 0x8c, 0x37, 0x1e, 0x99, 0xac,
+	0x5b, 0xa8, 0x42, 0x14, 0xde, 0x78, 0xd4, 0x3d, 0x0c, 0xab, 0x5f, 0x40, 0xa6, 0x7b, 0xc8, 0xbf,
+	0x9c, 0x98, 0x12, 0x02, 0x42, 0x64, 0xbb, 0x16, 0x9f, 0x49, 0x59, 0x06, 0xf0, 0xee, 0x84, 0x40,
+	0x65, 0x7d, 0x79, 0xd2, 0x58, 0xd8, 0x18, 0xe9, 0x7f, 0xf3, 0x9b, 0x4c, 0x7e, 0x8d, 0xdd, 0xfc,
+	0x49, 0x81, 0x7c, 0xec, 0x1a, 0xa2, 0x77, 0xe0, 0x52, 0x63, 0x77, 0x7f, 0xeb, 0x81, 0xd9, 0xdc,
+	0x36, 0xef, 0xee, 0xd6, 0xef, 0x99, 0x0f, 0x5b, 0x0f, 0x5a, 0xfb, 0x9f, 0xb6, 0x8a, 0x0b, 0xe5,
+	0xcb, 0xc7, 0x27, 0x1a, 0x8a, 0x71, 0x1f, 0x7a, 0x4f, 0x3c, 0xf2, 0x25, 0x7b, 0x42, 0x57, 0x67,
+	0x5d, 0xea, 0x8d, 0xce, 0x4e, 0xab, 0x5b, 0x54, 0xca, 0x97, 0x8e, 0x4f, 0xb4, 0x95, 0x98, 0x47,
+	0xbd, 0x17, 0x62, 0x8f, 0xce, 0x3b, 0x6c, 0xed, 0xef, 0xed, 0x35, 0xbb, 0xc5, 0xc4, 0x9c, 0x83,
+	0x7c, 0x68, 0x6f, 0xc0, 0xca, 0xac, 0x43, 0xab, 0xb9, 0x5b, 0x4c, 0x96, 0xd1, 0xf1, 0x89, 0xb6,
+	0x14, 0x63, 0xb7, 0x1c, 0xb7, 0x9c, 0xfd, 0xea, 0x9b, 0xca, 0xc2, 0x77, 0xdf, 0x56, 0x14, 0x96,
+	0x59, 0x61, 0xe6, 0x2a, 0xa2, 0x5b, 0x70, 0xa5, 0xd3, 0xbc, 0xd7, 0xda, 0xd9, 0x36, 0xf7, 0x3a,
+	0xf7, 0xcc, 0xee, 0x67, 0xed, 0x9d, 0x58, 0x76, 0xcb, 0xc7, 0x27, 0x5a, 0x5e, 0xa6, 0x74, 0x11,
+	0xbb, 0x6d, 0xec, 0x3c, 0xda, 0xef, 0xee, 0x14, 0x15, 0xc1, 0x6e, 0x07, 0xf8, 0x80, 0x50, 0xcc,
+	0xd9, 0xb7, 0xe1, 0xea, 0x39, 0xec, 0x28, 0xb1, 0x95, 0xe3, 0x13, 0xad, 0xd0, 0x0e, 0xb0, 0x90,
+	0x29, 0xf7, 0xd0, 0xa1, 0x34, 0xef, 0xb1, 0xdf, 0xde, 0xef, 0xd4, 0x77, 0x8b, 0x5a, 0xb9, 0x78,
+	0x7c, 0xa2, 0x2d, 0x4e, 0xde, 0x1c, 0xc6, 0x9f, 0x66, 0xd6, 0xf8, 0xe4, 0xf9, 0x69, 0x45, 0x79,
+	0x71, 0x5a, 0x51, 0x7e, 0x3b, 0xad, 0x28, 0xcf, 0x5e, 0x55, 0x16, 0x5e, 0xbc, 0xaa, 0x2c, 0xfc,
+	0xfc, 0xaa, 0xb2, 0xf0, 0xf9, 0xfb, 0x03, 0x87, 0x0e, 0xc7, 0x3d, 0xbd, 0x4f, 0x46, 0xb5, 0xf8,
+	0x7f, 0x9a, 0xe9, 0x50, 0xfc, 0x6b, 0x39, 0xfb, 0x7f, 0xa7, 0x97, 0xe6, 0xf8, 0x9d, 0x3f, 0x06,
+	0x00, 0xaa, 0x9e, 0x5b, 0x39, 0x0a, 0x0d, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1412,6 +1432,20 @@ func (m *Vote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ExtensionSignature) > 0 {
+		i -= len(m.ExtensionSignature)
+		copy(dAtA[i:], m.ExtensionSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ExtensionSignature)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Extension) > 0 {
+		i -= len(m.Extension)
+		copy(dAtA[i:], m.Extension)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Extension)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.Signature) > 0 {
 		i -= len(m.Signature)
 		copy(dAtA[i:], m.Signature)
@@ -1977,6 +2011,14 @@ func (m *Vote) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.Extension)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ExtensionSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -3357,6 +3399,74 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 				m.Signature = []byte{}
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extension = append(m.Extension[:0], dAtA[iNdEx:postIndex]...)
+			if m.Extension == nil {
+				m.Extension = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtensionSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExtensionSignature = append(m.ExtensionSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.ExtensionSignature == nil {
+				m.ExtensionSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
@@ -966,6 +968,69 @@ func TestStateMakeBlock(t *testing.T) {
 	assert.Equal(t, proposerAddress, block.ProposerAddress)
 }
 
+func TestMedianTime(t *testing.T) {
+	var (
+		genesisTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		pubKeys     = []crypto.PubKey{
+			ed25519.GenPrivKey().PubKey(),
+			ed25519.GenPrivKey().PubKey(),
+			ed25519.GenPrivKey().PubKey(),
+			ed25519.GenPrivKey().PubKey(),
+		}
+	)
+	makeValSet := func(powers ...int64) *types.ValidatorSet {
+		vals := make([]*types.Validator, len(powers))
+		for i, power := range powers {
+			vals[i] = types.NewValidator(pubKeys[i], power)
+		}
+		return types.NewValidatorSet(vals)
+	}
+	// makeCommit returns a commit where the i-th validator signed at
+	// genesisTime + offsets[i] seconds, or is absent if offsets[i] < 0.
+	makeCommit := func(offsets ...int) *types.Commit {
+		sigs := make([]types.CommitSig, len(offsets))
+		for i, offset := range offsets {
+			if offset < 0 {
+				sigs[i] = types.NewCommitSigAbsent()
+				continue
+			}
+			sigs[i] = types.CommitSig{
+				BlockIDFlag:      types.BlockIDFlagCommit,
+				ValidatorAddress: pubKeys[i].Address(),
+				Timestamp:        genesisTime.Add(time.Duration(offset) * time.Second),
+				Signature:        []byte("signature"),
+			}
+		}
+		return types.NewCommit(1, 0, types.BlockID{}, sigs)
+	}
+	at := func(offset int) time.Time {
+		return genesisTime.Add(time.Duration(offset) * time.Second)
+	}
+
+	testCases := []struct {
+		name   string
+		valSet *types.ValidatorSet
+		commit *types.Commit
+		exp    time.Time
+	}{
+		// 40 + 30 > 100 / 2
+		{"weighted", makeValSet(40, 30, 20, 10), makeCommit(1, 2, 3, 4), at(2)},
+		// 10 + 20 + 30 > 100 / 2
+		{"ordered by timestamp", makeValSet(40, 30, 20, 10), makeCommit(4, 3, 2, 1), at(3)},
+		// 10 + 10 = 40 / 2: the earlier of the two middle timestamps wins
+		{"tie", makeValSet(10, 10, 10, 10), makeCommit(1, 2, 3, 4), at(2)},
+		// absent signatures don't count: 40 > 60 / 2
+		{"absent", makeValSet(40, 30, 20, 10), makeCommit(1, -1, 3, -1), at(1)},
+		{"equal timestamps", makeValSet(10, 10, 10, 10), makeCommit(5, 5, 5, 6), at(5)},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.exp, sm.MedianTime(tc.commit, tc.valSet))
+		})
+	}
+}
+
 // TestConsensusParamsChangesSaveLoad tests saving and loading consensus params
 // with changes.
 func TestConsensusParamsChangesSaveLoad(t *testing.T) {
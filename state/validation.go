@@ -196,12 +196,41 @@ func validateBlock(evidencePool EvidencePool, stateDB dbm.DB, state State, block
 	return nil
 }
 
+// ErrEvidenceStateUnavailable is returned by VerifyEvidenceWithResolver when
+// the validator set at the height of the evidence is unavailable (e.g. it was
+// pruned), so the evidence can't be checked, which doesn't make it invalid.
+var ErrEvidenceStateUnavailable = errors.New("state needed to verify the evidence is unavailable")
+
+// ValidatorSetResolver returns the validator set at the given height, or
+// ErrNoValSetForHeight if it isn't available (e.g. because it was pruned).
+type ValidatorSetResolver interface {
+	ValidatorSet(height int64) (*types.ValidatorSet, error)
+}
+
+// dbValidatorSetResolver resolves validator sets from the state DB.
+type dbValidatorSetResolver struct {
+	db dbm.DB
+}
+
+func (r dbValidatorSetResolver) ValidatorSet(height int64) (*types.ValidatorSet, error) {
+	return LoadValidators(r.db, height)
+}
+
 // VerifyEvidence verifies the evidence fully by checking:
 // - it is sufficiently recent (MaxAge)
 // - it is from a key who was a validator at the given height
 // - it is internally consistent
 // - it was properly signed by the alleged equivocator
 func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence, committedHeader *types.Header) error {
+	return VerifyEvidenceWithResolver(dbValidatorSetResolver{stateDB}, state, evidence, committedHeader)
+}
+
+// VerifyEvidenceWithResolver is like VerifyEvidence, but gets the validator
+// set at the height of the evidence from resolver. If it's unavailable (see
+// ValidatorSetResolver), ErrEvidenceStateUnavailable is returned; other errors
+// of the resolver are returned as is.
+func VerifyEvidenceWithResolver(resolver ValidatorSetResolver, state State, evidence types.Evidence,
+	committedHeader *types.Header) error {
 	var (
 		height         = state.LastBlockHeight
 		evidenceParams = state.ConsensusParams.Evidence
@@ -232,16 +261,16 @@ func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence, commit
 		}
 	}
 
-	valset, err := LoadValidators(stateDB, evidence.Height())
+	valset, err := resolver.ValidatorSet(evidence.Height())
 	if err != nil {
-		// TODO: if err is just that we cant find it cuz we pruned, ignore.
+		var errNoValSet ErrNoValSetForHeight
+		if errors.As(err, &errNoValSet) {
+			return fmt.Errorf("%w: %v", ErrEvidenceStateUnavailable, err)
+		}
 		// TODO: if its actually bad evidence, punish peer
 		return err
 	}
 
-	addr := evidence.Address()
-	var val *types.Validator
-
 	if ae, ok := evidence.(*types.AmnesiaEvidence); ok {
 		// check the validator set against the polc to make sure that a majority of valid votes was reached
 		if !ae.Polc.IsAbsent() {
@@ -254,14 +283,6 @@ func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence, commit
 
 	// For all other types, expect evidence.Address to be a validator at height
 	// evidence.Height.
-	_, val = valset.GetByAddress(addr)
-	if val == nil {
-		return fmt.Errorf("address %X was not a validator at height %d", addr, evidence.Height())
-	}
-
-	if err := evidence.Verify(state.ChainID, val.PubKey); err != nil {
-		return err
-	}
-
-	return nil
+	_, err = types.VerifyEvidenceWithValidatorSet(evidence, state.ChainID, valset)
+	return err
 }
@@ -1,6 +1,7 @@
 package state_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -544,6 +545,50 @@ func TestVerifyEvidenceInvalidTime(t *testing.T) {
 	}
 }
 
+// mapValidatorSetResolver resolves the validator sets in the map, failing with
+// err if set or ErrNoValSetForHeight otherwise.
+type mapValidatorSetResolver struct {
+	valSets map[int64]*types.ValidatorSet
+	err     error
+}
+
+func (r mapValidatorSetResolver) ValidatorSet(height int64) (*types.ValidatorSet, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	valSet, ok := r.valSets[height]
+	if !ok {
+		return nil, sm.ErrNoValSetForHeight{Height: height}
+	}
+	return valSet, nil
+}
+
+func TestVerifyEvidenceWithResolver(t *testing.T) {
+	var height int64 = 4
+	state, _, privVals := makeState(1, int(height))
+	var privVal types.PrivValidator
+	for _, pv := range privVals {
+		privVal = pv
+	}
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(height, defaultTestTime, privVal, chainID)
+	header := &types.Header{Time: defaultTestTime}
+
+	// the resolver has the validator set
+	resolver := mapValidatorSetResolver{valSets: map[int64]*types.ValidatorSet{height: state.Validators}}
+	assert.NoError(t, sm.VerifyEvidenceWithResolver(resolver, state, ev, header))
+
+	// it was pruned
+	resolver = mapValidatorSetResolver{valSets: map[int64]*types.ValidatorSet{}}
+	err := sm.VerifyEvidenceWithResolver(resolver, state, ev, header)
+	assert.True(t, errors.Is(err, sm.ErrEvidenceStateUnavailable), err)
+
+	// other errors are propagated
+	errResolver := errors.New("resolver error")
+	resolver = mapValidatorSetResolver{err: errResolver}
+	err = sm.VerifyEvidenceWithResolver(resolver, state, ev, header)
+	assert.Equal(t, errResolver, err)
+}
+
 func TestVerifyEvidenceWithAmnesiaEvidence(t *testing.T) {
 	var height int64 = 1
 	state, stateDB, vals := makeState(4, int(height))
@@ -643,7 +688,7 @@ func TestVerifyEvidenceWithLunaticValidatorEvidence(t *testing.T) {
 	err := vals[val.Address.String()].SignVote(chainID, v)
 	vote.Signature = v.Signature
 	require.NoError(t, err)
-	ev := types.NewLunaticValidatorEvidence(h, vote, "ConsensusHash", defaultTestTime)
+	ev := types.NewLunaticValidatorEvidence(h, vote, []string{"ConsensusHash"}, defaultTestTime)
 	err = ev.ValidateBasic()
 	require.NoError(t, err)
 	err = sm.VerifyEvidence(stateDB, state, ev, h)
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"container/list"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// BlockLoader loads blocks by height. It's implemented by BlockStore.
+type BlockLoader interface {
+	LoadBlock(height int64) *types.Block
+}
+
+var _ BlockLoader = (*BlockStore)(nil)
+
+// BlockStoreCache wraps a BlockLoader and keeps a LRU cache of the decoded
+// blocks, so that blocks requested repeatedly (e.g. via RPC) are only loaded
+// and decoded once.
+//
+// Missing blocks are not cached. The returned blocks are shared between
+// callers and must not be modified.
+//
+// BlockStoreCache is safe for concurrent use.
+type BlockStoreCache struct {
+	store BlockLoader
+
+	mtx      tmsync.Mutex
+	size     int
+	cacheMap map[int64]*list.Element
+	list     *list.List // of cachedBlock, least recently used first
+}
+
+type cachedBlock struct {
+	height int64
+	block  *types.Block
+}
+
+// NewBlockStoreCache returns a BlockStoreCache caching up to size blocks
+// loaded from store. Panics if size is not positive.
+func NewBlockStoreCache(store BlockLoader, size int) *BlockStoreCache {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+	return &BlockStoreCache{
+		store:    store,
+		size:     size,
+		cacheMap: make(map[int64]*list.Element, size),
+		list:     list.New(),
+	}
+}
+
+// Get returns the block at the given height or nil if it's not found.
+func (bsc *BlockStoreCache) Get(height int64) *types.Block {
+	bsc.mtx.Lock()
+	if e, ok := bsc.cacheMap[height]; ok {
+		bsc.list.MoveToBack(e)
+		bsc.mtx.Unlock()
+		return e.Value.(cachedBlock).block
+	}
+	bsc.mtx.Unlock()
+
+	block := bsc.store.LoadBlock(height)
+	if block == nil {
+		return nil
+	}
+
+	return bsc.push(height, block)
+}
+
+// push adds the block to the cache, evicting the least recently used one if
+// the cache is full. If a concurrent Get already added a block at the same
+// height, that one is returned instead.
+func (bsc *BlockStoreCache) push(height int64, block *types.Block) *types.Block {
+	bsc.mtx.Lock()
+	defer bsc.mtx.Unlock()
+
+	if e, ok := bsc.cacheMap[height]; ok {
+		bsc.list.MoveToBack(e)
+		return e.Value.(cachedBlock).block
+	}
+
+	if bsc.list.Len() >= bsc.size {
+		popped := bsc.list.Front()
+		delete(bsc.cacheMap, popped.Value.(cachedBlock).height)
+		bsc.list.Remove(popped)
+	}
+	bsc.cacheMap[height] = bsc.list.PushBack(cachedBlock{height, block})
+	return block
+}
+
+// Len returns the number of cached blocks.
+func (bsc *BlockStoreCache) Len() int {
+	bsc.mtx.Lock()
+	defer bsc.mtx.Unlock()
+	return bsc.list.Len()
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// countingBlockLoader creates blocks up to maxHeight and counts the loads.
+type countingBlockLoader struct {
+	maxHeight int64
+
+	mtx   tmsync.Mutex
+	loads map[int64]int
+}
+
+func (l *countingBlockLoader) LoadBlock(height int64) *types.Block {
+	l.mtx.Lock()
+	l.loads[height]++
+	l.mtx.Unlock()
+	if height < 1 || height > l.maxHeight {
+		return nil
+	}
+	return types.MakeBlock(height, makeTxs(height), nil, nil)
+}
+
+func (l *countingBlockLoader) numLoads(height int64) int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.loads[height]
+}
+
+func TestBlockStoreCache(t *testing.T) {
+	loader := &countingBlockLoader{maxHeight: 10, loads: make(map[int64]int)}
+	bsc := NewBlockStoreCache(loader, 2)
+
+	// cache hits don't load the block again
+	block1 := bsc.Get(1)
+	require.NotNil(t, block1)
+	assert.EqualValues(t, 1, block1.Height)
+	assert.True(t, block1 == bsc.Get(1))
+	assert.Equal(t, 1, loader.numLoads(1))
+
+	// missing blocks are not cached
+	assert.Nil(t, bsc.Get(11))
+	assert.Nil(t, bsc.Get(11))
+	assert.Equal(t, 2, loader.numLoads(11))
+
+	// 1 was used more recently than 2, so 2 gets evicted
+	require.NotNil(t, bsc.Get(2))
+	require.NotNil(t, bsc.Get(1))
+	require.NotNil(t, bsc.Get(3))
+	assert.Equal(t, 2, bsc.Len())
+
+	bsc.Get(1)
+	bsc.Get(3)
+	assert.Equal(t, 1, loader.numLoads(1))
+	assert.Equal(t, 1, loader.numLoads(3))
+	bsc.Get(2)
+	assert.Equal(t, 2, loader.numLoads(2))
+
+	assert.Panics(t, func() { NewBlockStoreCache(loader, 0) })
+}
+
+func TestBlockStoreCacheConcurrentAccess(t *testing.T) {
+	loader := &countingBlockLoader{maxHeight: 100, loads: make(map[int64]int)}
+	bsc := NewBlockStoreCache(loader, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for h := int64(1); h <= 100; h++ {
+				block := bsc.Get((h*int64(i+1))%100 + 1)
+				assert.NotNil(t, block)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 10, bsc.Len())
+}
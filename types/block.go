@@ -175,6 +175,25 @@ func (b *Block) HashesTo(hash []byte) bool {
 	return bytes.Equal(b.Hash(), hash)
 }
 
+// ValidateForCommit returns an error if the commit is not for this block, i.e.
+// if the block's height or hash doesn't match the commit's.
+func (b *Block) ValidateForCommit(commit *Commit) error {
+	if b == nil {
+		return errors.New("nil block")
+	}
+	if commit == nil {
+		return errors.New("nil commit")
+	}
+	if b.Height != commit.Height {
+		return fmt.Errorf("block height %d does not match commit height %d", b.Height, commit.Height)
+	}
+	if hash := b.Hash(); !bytes.Equal(hash, commit.BlockID.Hash) {
+		return fmt.Errorf("block hash mismatch: block hashes to %v, but commit is for %v",
+			hash, commit.BlockID.Hash)
+	}
+	return nil
+}
+
 // Size returns size of the block in bytes.
 func (b *Block) Size() int {
 	pbb, err := b.ToProto()
@@ -185,6 +204,31 @@ func (b *Block) Size() int {
 	return pbb.Size()
 }
 
+// ValidateSize returns an error if the serialized block (header, data,
+// evidence and last commit) is bigger than maxBytes or if any piece of its
+// evidence is bigger than the bound of its type (see MaxBytesForEvidence).
+func (b *Block) ValidateSize(maxBytes int64) error {
+	if b == nil {
+		return errors.New("nil block")
+	}
+
+	for i, ev := range b.Evidence.Evidence {
+		if err := checkEvidenceSize(ev); err != nil {
+			return fmt.Errorf("evidence (#%d): %w", i, err)
+		}
+	}
+
+	pbb, err := b.ToProto()
+	if err != nil {
+		return err
+	}
+	if size := int64(pbb.Size()); size > maxBytes {
+		return fmt.Errorf("block is too big: %d bytes, max: %d", size, maxBytes)
+	}
+
+	return nil
+}
+
 // String returns a string representation of the block
 //
 // See StringIndented.
@@ -479,6 +523,33 @@ func (h *Header) Hash() tmbytes.HexBytes {
 	})
 }
 
+// VerifyLastCommitHash returns an error if the header's LastCommitHash is not
+// the hash of the given commit.
+func (h *Header) VerifyLastCommitHash(commit *Commit) error {
+	if hash := commit.Hash(); !bytes.Equal(h.LastCommitHash, hash) {
+		return fmt.Errorf("wrong Header.LastCommitHash. Expected %v, got %v", hash, h.LastCommitHash)
+	}
+	return nil
+}
+
+// VerifyDataHash returns an error if the header's DataHash is not the hash of
+// the given transactions.
+func (h *Header) VerifyDataHash(txs Txs) error {
+	if hash := txs.Hash(); !bytes.Equal(h.DataHash, hash) {
+		return fmt.Errorf("wrong Header.DataHash. Expected %v, got %v", hash, h.DataHash)
+	}
+	return nil
+}
+
+// VerifyEvidenceHash returns an error if the header's EvidenceHash is not the
+// hash of the given evidence.
+func (h *Header) VerifyEvidenceHash(evList EvidenceList) error {
+	if hash := evList.Hash(); !bytes.Equal(h.EvidenceHash, hash) {
+		return fmt.Errorf("wrong Header.EvidenceHash. Expected %v, got %v", hash, h.EvidenceHash)
+	}
+	return nil
+}
+
 // StringIndented returns an indented string representation of the header.
 func (h *Header) StringIndented(indent string) string {
 	if h == nil {
@@ -769,16 +840,32 @@ func CommitToVoteSet(chainID string, commit *Commit, vals *ValidatorSet) *VoteSe
 	return voteSet
 }
 
-// GetVote converts the CommitSig for the given valIdx to a Vote.
-// Returns nil if the precommit at valIdx is nil.
+// GetVote converts the CommitSig for the given valIdx to a Vote, the inverse
+// of Vote.CommitSig. Returns nil if the signature at valIdx is absent.
 // Panics if valIdx >= commit.Size().
+//
+// NOTE: the CommitSig's Timestamp is carried over to the Vote and is part of
+// its sign bytes, so a tampered timestamp fails signature verification.
 func (commit *Commit) GetVote(valIdx int32) *Vote {
-	commitSig := commit.Signatures[valIdx]
+	if commit.Signatures[valIdx].Absent() {
+		return nil
+	}
+	return commit.getVote(valIdx)
+}
+
+// getVote is like GetVote, but also converts absent CommitSigs.
+func (commit *Commit) getVote(valIdx int32) *Vote {
+	return commitSigVote(commit.Signatures[valIdx], commit.Height, commit.Round, commit.BlockID, valIdx)
+}
+
+// commitSigVote converts the CommitSig of the validator with index valIdx in a
+// commit for blockID at the given height and round to a Vote.
+func commitSigVote(commitSig CommitSig, height int64, round int32, blockID BlockID, valIdx int32) *Vote {
 	return &Vote{
 		Type:             tmproto.PrecommitType,
-		Height:           commit.Height,
-		Round:            commit.Round,
-		BlockID:          commitSig.BlockID(commit.BlockID),
+		Height:           height,
+		Round:            round,
+		BlockID:          commitSig.BlockID(blockID),
 		Timestamp:        commitSig.Timestamp,
 		ValidatorAddress: commitSig.ValidatorAddress,
 		ValidatorIndex:   valIdx,
@@ -796,7 +883,7 @@ func (commit *Commit) GetVote(valIdx int32) *Vote {
 //
 // See VoteSignBytes
 func (commit *Commit) VoteSignBytes(chainID string, valIdx int32) []byte {
-	v := commit.GetVote(valIdx).ToProto()
+	v := commit.getVote(valIdx).ToProto()
 	return VoteSignBytes(chainID, v)
 }
 
@@ -841,6 +928,42 @@ func (commit *Commit) BitArray() *bits.BitArray {
 	return commit.bitArray
 }
 
+// AbsentValidators returns the addresses, in set order, of the validators
+// which didn't sign the commit (i.e. whose CommitSig is absent). valSet must be
+// the validator set which signed the commit.
+func (commit *Commit) AbsentValidators(valSet *ValidatorSet) [][]byte {
+	var (
+		signed = commit.BitArray()
+		absent [][]byte
+	)
+	for i := 0; i < signed.Size(); i++ {
+		if signed.GetIndex(i) {
+			continue
+		}
+		if addr, _ := valSet.GetByIndex(int32(i)); addr != nil {
+			absent = append(absent, addr)
+		}
+	}
+	return absent
+}
+
+// SignedVotingPower returns the total voting power of the validators which
+// signed the commit for its block (see CommitSig.ForBlock). Votes for nil
+// don't count. valSet must be the validator set which signed the commit.
+// Signatures aren't verified.
+func (commit *Commit) SignedVotingPower(valSet *ValidatorSet) int64 {
+	var power int64
+	for i, cs := range commit.Signatures {
+		if !cs.ForBlock() {
+			continue
+		}
+		if _, val := valSet.GetByIndex(int32(i)); val != nil {
+			power += val.VotingPower
+		}
+	}
+	return power
+}
+
 // GetByIndex returns the vote corresponding to a given validator index.
 // Panics if `index >= commit.Size()`.
 // Implements VoteSetReader.
@@ -854,8 +977,14 @@ func (commit *Commit) IsCommit() bool {
 	return len(commit.Signatures) != 0
 }
 
+// ErrCommitDuplicateSigner is returned for a commit in which the same
+// validator address appears in more than one signature.
+var ErrCommitDuplicateSigner = errors.New("duplicate signer in commit")
+
 // ValidateBasic performs basic validation that doesn't involve state data.
-// Does not actually check the cryptographic signatures.
+// Does not actually check the cryptographic signatures, but rejects commits
+// where a validator signed more than once (ErrCommitDuplicateSigner), so that
+// its voting power can't be counted twice.
 func (commit *Commit) ValidateBasic() error {
 	if commit.Height < 0 {
 		return errors.New("negative Height")
@@ -868,14 +997,27 @@ func (commit *Commit) ValidateBasic() error {
 		if commit.BlockID.IsZero() {
 			return errors.New("commit cannot be for nil block")
 		}
+		if err := commit.BlockID.ValidateBasic(); err != nil {
+			return fmt.Errorf("wrong BlockID: %v", err)
+		}
 
 		if len(commit.Signatures) == 0 {
 			return errors.New("no signatures in commit")
 		}
+		signers := make(map[string]int, len(commit.Signatures))
 		for i, commitSig := range commit.Signatures {
 			if err := commitSig.ValidateBasic(); err != nil {
 				return fmt.Errorf("wrong CommitSig #%d: %v", i, err)
 			}
+			if commitSig.Absent() {
+				continue
+			}
+			addr := string(commitSig.ValidatorAddress)
+			if j, ok := signers[addr]; ok {
+				return fmt.Errorf("%w: %X in CommitSig #%d and #%d", ErrCommitDuplicateSigner,
+					commitSig.ValidatorAddress, j, i)
+			}
+			signers[addr] = i
 		}
 	}
 	return nil
@@ -1031,6 +1173,26 @@ func (sh SignedHeader) ValidateBasic(chainID string) error {
 	return nil
 }
 
+// VerifyCommitAgainstHeader verifies that the commit is for the given header
+// (same height and block hash, see SignedHeader.ValidateBasic) and that +2/3
+// of valSet, which must be the header's validator set, signed it.
+func VerifyCommitAgainstHeader(chainID string, header *Header, commit *Commit, valSet *ValidatorSet) error {
+	sh := SignedHeader{Header: header, Commit: commit}
+	if err := sh.ValidateBasic(chainID); err != nil {
+		return err
+	}
+
+	if valSet == nil {
+		return errors.New("nil validator set")
+	}
+	if !bytes.Equal(header.ValidatorsHash, valSet.Hash()) {
+		return fmt.Errorf("expected validators hash %X (header), got %X (validator set)",
+			header.ValidatorsHash, valSet.Hash())
+	}
+
+	return valSet.VerifyCommit(chainID, commit.BlockID, header.Height, commit)
+}
+
 // String returns a string representation of SignedHeader.
 func (sh SignedHeader) String() string {
 	return sh.StringIndented("")
@@ -1267,12 +1429,36 @@ func (data *EvidenceData) FromProto(eviData *tmproto.EvidenceData) error {
 
 //--------------------------------------------------------------------------------
 
+// ErrBlockIDIncomplete is returned for a BlockID which is neither zero nor
+// complete, e.g. one with a hash but no part set header.
+var ErrBlockIDIncomplete = errors.New("blockID must be either empty or complete")
+
 // BlockID
 type BlockID struct {
 	Hash          tmbytes.HexBytes `json:"hash"`
 	PartSetHeader PartSetHeader    `json:"parts"`
 }
 
+// NewBlockID returns a new BlockID, or an error if the hashes are neither
+// empty nor tmhash.Size bytes long, or if a block hash is given with zero
+// parts.
+func NewBlockID(hash []byte, partsTotal uint32, partsHash []byte) (BlockID, error) {
+	blockID := BlockID{
+		Hash: hash,
+		PartSetHeader: PartSetHeader{
+			Total: partsTotal,
+			Hash:  partsHash,
+		},
+	}
+	if err := blockID.ValidateBasic(); err != nil {
+		return BlockID{}, err
+	}
+	if len(hash) > 0 && partsTotal == 0 {
+		return BlockID{}, errors.New("block hash is set but the part set is empty")
+	}
+	return blockID, nil
+}
+
 // Equals returns true if the BlockID matches the given BlockID
 func (blockID BlockID) Equals(other BlockID) bool {
 	return bytes.Equal(blockID.Hash, other.Hash) &&
@@ -1315,6 +1501,15 @@ func (blockID BlockID) IsComplete() bool {
 		len(blockID.PartSetHeader.Hash) == tmhash.Size
 }
 
+// ValidateZeroOrComplete returns ErrBlockIDIncomplete unless the BlockID is
+// either zero (nil block) or complete.
+func (blockID BlockID) ValidateZeroOrComplete() error {
+	if blockID.IsZero() || blockID.IsComplete() {
+		return nil
+	}
+	return fmt.Errorf("%w, got: %v", ErrBlockIDIncomplete, blockID)
+}
+
 // String returns a human readable string representation of the BlockID.
 //
 // 1. hash
@@ -5,6 +5,7 @@ import (
 	// number generator here and we can run the tests a bit faster
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"math"
 	"os"
 	"reflect"
@@ -20,6 +21,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/libs/bytes"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/proto/tendermint/version"
@@ -156,6 +158,67 @@ func TestBlockHashesTo(t *testing.T) {
 	assert.True(t, block.HashesTo(block.Hash()))
 }
 
+func TestHeaderVerifyContentHashes(t *testing.T) {
+	h := int64(3)
+	voteSet, _, vals := randVoteSet(h-1, 1, tmproto.PrecommitType, 10, 1)
+	commit, err := MakeCommit(makeBlockIDRandom(), h-1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	evList := EvidenceList{
+		NewMockDuplicateVoteEvidenceWithValidator(h, time.Now(), vals[0], "block-test-chain"),
+		NewMockDuplicateVoteEvidenceWithValidator(h, time.Now(), vals[1], "block-test-chain"),
+	}
+	txs := Txs{Tx("foo"), Tx("bar")}
+	header := MakeBlock(h, txs, commit, evList).Header
+
+	assert.NoError(t, header.VerifyEvidenceHash(evList))
+	assert.NoError(t, header.VerifyDataHash(txs))
+	assert.NoError(t, header.VerifyLastCommitHash(commit))
+
+	err = header.VerifyEvidenceHash(evList[:1])
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "EvidenceHash")
+	}
+	err = header.VerifyDataHash(Txs{Tx("foo"), Tx("baz")})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "DataHash")
+	}
+	otherCommit := randCommit(time.Now())
+	err = header.VerifyLastCommitHash(otherCommit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "LastCommitHash")
+	}
+}
+
+func TestBlockValidateForCommit(t *testing.T) {
+	lastID := makeBlockIDRandom()
+	h := int64(3)
+	voteSet, valSet, vals := randVoteSet(h-1, 1, tmproto.PrecommitType, 4, 1)
+	lastCommit, err := MakeCommit(lastID, h-1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	block := MakeBlock(h, []Tx{Tx("Hello World")}, lastCommit, nil)
+	block.ValidatorsHash = valSet.Hash()
+	blockID := BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(BlockPartSizeBytes).Header()}
+	voteSet, _, vals = randVoteSet(h, 1, tmproto.PrecommitType, 4, 1)
+	commit, err := MakeCommit(blockID, h, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	assert.NoError(t, block.ValidateForCommit(commit))
+
+	// tampered block
+	block.Data = Data{Txs: []Tx{Tx("Goodbye World")}}
+	block.DataHash = nil
+	err = block.ValidateForCommit(commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "block hash mismatch")
+		assert.Contains(t, err.Error(), blockID.Hash.String())
+	}
+
+	assert.Error(t, block.ValidateForCommit(lastCommit), "wrong height")
+	assert.Error(t, block.ValidateForCommit(nil))
+	assert.Error(t, (*Block)(nil).ValidateForCommit(commit))
+}
+
 func TestBlockSize(t *testing.T) {
 	size := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, nil).Size()
 	if size <= 0 {
@@ -163,6 +226,41 @@ func TestBlockSize(t *testing.T) {
 	}
 }
 
+func TestBlockValidateSize(t *testing.T) {
+	require.Error(t, (*Block)(nil).ValidateSize(math.MaxInt64))
+
+	txs := []Tx{Tx("foo"), Tx("bar")}
+	lastID := makeBlockIDRandom()
+	h := int64(3)
+
+	voteSet, _, vals := randVoteSet(h-1, 1, tmproto.PrecommitType, 10, 1)
+	commit, err := MakeCommit(lastID, h-1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	ev := NewMockDuplicateVoteEvidenceWithValidator(h, time.Now(), vals[0], "block-test-chain")
+	block := MakeBlock(h, txs, commit, []Evidence{ev})
+	size := int64(block.Size())
+
+	assert.NoError(t, block.ValidateSize(size)) // just under the limit
+	assert.Error(t, block.ValidateSize(size-1)) // just over the limit
+
+	// oversized evidence is rejected even if the block as a whole is small enough
+	bigEv := NewMockDuplicateVoteEvidenceWithValidator(h, time.Now(), vals[0], "block-test-chain")
+	bigEv.VoteA.Signature = make([]byte, MaxEvidenceBytes)
+	block = MakeBlock(h, txs, commit, []Evidence{bigEv})
+	err = block.ValidateSize(math.MaxInt64)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "evidence (#0): *types.DuplicateVoteEvidence is too big")
+	}
+
+	// evidence of every type, bigger than MaxEvidenceBytes for some
+	block = MakeBlock(h, txs, commit, makeEvidenceOfEveryType(t))
+	assert.NoError(t, block.ValidateSize(math.MaxInt64))
+	lunatic := block.Evidence.Evidence[4]
+	require.IsType(t, &LunaticValidatorEvidence{}, lunatic)
+	assert.Greater(t, int64(len(lunatic.Bytes())), MaxEvidenceBytes)
+}
+
 func TestBlockString(t *testing.T) {
 	assert.Equal(t, "nil-Block", (*Block)(nil).String())
 	assert.Equal(t, "nil-Block", (*Block)(nil).StringIndented(""))
@@ -238,6 +336,58 @@ func TestCommit(t *testing.T) {
 	assert.True(t, commit.IsCommit())
 }
 
+func TestCommitGetVote(t *testing.T) {
+	const chainID = "test_chain_id"
+	voteSet, valSet, vals := randVoteSet(1, 1, tmproto.PrecommitType, 4, 1)
+	commit, err := MakeCommit(makeBlockIDRandom(), 1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	sigs := append([]CommitSig(nil), commit.Signatures...)
+	sigs[2] = NewCommitSigAbsent()
+	commit = NewCommit(commit.Height, commit.Round, commit.BlockID, sigs)
+
+	for i, val := range valSet.Validators {
+		vote := commit.GetVote(int32(i))
+		if i == 2 {
+			assert.Nil(t, vote)
+			continue
+		}
+		require.NotNil(t, vote)
+		assert.Equal(t, voteSet.GetByIndex(int32(i)), vote)
+		assert.Equal(t, commit.Signatures[i], vote.CommitSig())
+		assert.NoError(t, vote.Verify(chainID, val.PubKey))
+	}
+}
+
+func TestCommitAbsentValidators(t *testing.T) {
+	voteSet, valSet, vals := randVoteSet(1, 1, tmproto.PrecommitType, 5, 1)
+	commit, err := MakeCommit(makeBlockIDRandom(), 1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, commit.AbsentValidators(valSet))
+
+	sigs := append([]CommitSig(nil), commit.Signatures...)
+	sigs[1] = NewCommitSigAbsent()
+	sigs[3] = NewCommitSigAbsent()
+	commit = NewCommit(commit.Height, commit.Round, commit.BlockID, sigs)
+
+	assert.Equal(t, [][]byte{valSet.Validators[1].Address, valSet.Validators[3].Address},
+		commit.AbsentValidators(valSet))
+}
+
+func TestCommitSignedVotingPower(t *testing.T) {
+	voteSet, valSet, vals := randVoteSet(1, 1, tmproto.PrecommitType, 5, 10)
+	commit, err := MakeCommit(makeBlockIDRandom(), 1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	assert.EqualValues(t, 50, commit.SignedVotingPower(valSet))
+
+	sigs := append([]CommitSig(nil), commit.Signatures...)
+	sigs[1].BlockIDFlag = BlockIDFlagNil
+	sigs[3] = NewCommitSigAbsent()
+	commit = NewCommit(commit.Height, commit.Round, commit.BlockID, sigs)
+
+	assert.EqualValues(t, 30, commit.SignedVotingPower(valSet))
+}
+
 func TestCommitValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName       string
@@ -248,6 +398,34 @@ func TestCommitValidateBasic(t *testing.T) {
 		{"Incorrect signature", func(com *Commit) { com.Signatures[0].Signature = []byte{0} }, false},
 		{"Incorrect height", func(com *Commit) { com.Height = int64(-100) }, true},
 		{"Incorrect round", func(com *Commit) { com.Round = -100 }, true},
+		{"Genesis commit", func(com *Commit) { *com = Commit{} }, false},
+		{"Nil block", func(com *Commit) { com.BlockID = BlockID{} }, true},
+		{"Invalid BlockID", func(com *Commit) { com.BlockID.Hash = []byte{1} }, true},
+		{"No signatures", func(com *Commit) { com.Signatures = nil }, true},
+		{"Unknown BlockIDFlag", func(com *Commit) { com.Signatures[0].BlockIDFlag = 10 }, true},
+		{"Absent with signature", func(com *Commit) {
+			com.Signatures[0] = NewCommitSigAbsent()
+			com.Signatures[0].Signature = []byte{0}
+		}, true},
+		{"Absent with timestamp", func(com *Commit) {
+			com.Signatures[0] = NewCommitSigAbsent()
+			com.Signatures[0].Timestamp = time.Now()
+		}, true},
+		{"Absent with address", func(com *Commit) {
+			com.Signatures[0] = NewCommitSigAbsent()
+			com.Signatures[0].ValidatorAddress = com.Signatures[1].ValidatorAddress
+		}, true},
+		{"Absent", func(com *Commit) { com.Signatures[0] = NewCommitSigAbsent() }, false},
+		{"For block without signature", func(com *Commit) { com.Signatures[0].Signature = nil }, true},
+		{"Signature too big", func(com *Commit) {
+			com.Signatures[0].Signature = make([]byte, MaxSignatureSize+1)
+		}, true},
+		{"Invalid address", func(com *Commit) { com.Signatures[0].ValidatorAddress = []byte{1} }, true},
+		{"Duplicate signer", func(com *Commit) { com.Signatures[0] = com.Signatures[1] }, true},
+		{"Duplicate signer for nil", func(com *Commit) {
+			com.Signatures[0] = com.Signatures[1]
+			com.Signatures[0].BlockIDFlag = BlockIDFlagNil
+		}, true},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -259,6 +437,125 @@ func TestCommitValidateBasic(t *testing.T) {
 	}
 }
 
+func TestCommitValidateBasicDuplicateSigner(t *testing.T) {
+	commit := randCommit(time.Now())
+	require.NoError(t, commit.ValidateBasic())
+
+	// the same validator's signature twice, under another index
+	commit.Signatures[3] = commit.Signatures[7]
+	err := commit.ValidateBasic()
+	assert.True(t, errors.Is(err, ErrCommitDuplicateSigner), err)
+}
+
+func TestVerifyLastCommit(t *testing.T) {
+	const h = int64(3)
+	lastID := makeBlockIDRandom()
+
+	makeBlock := func(t *testing.T, absent int) (*Block, *ValidatorSet) {
+		voteSet, valSet, vals := randVoteSet(h-1, 1, tmproto.PrecommitType, 4, 1)
+		commit, err := MakeCommit(lastID, h-1, 1, voteSet, vals, time.Now())
+		require.NoError(t, err)
+		for i := 0; i < absent; i++ {
+			commit.Signatures[i] = NewCommitSigAbsent()
+		}
+		block := MakeBlock(h, []Tx{Tx("foo")}, commit, nil)
+		block.LastBlockID = lastID
+		return block, valSet
+	}
+
+	block, valSet := makeBlock(t, 0)
+	assert.NoError(t, VerifyLastCommit(block, valSet, "test_chain_id"))
+	assert.Error(t, VerifyLastCommit(block, valSet, "other_chain_id"))
+
+	block, valSet = makeBlock(t, 0)
+	block.LastBlockID = makeBlockIDRandom()
+	assert.Error(t, VerifyLastCommit(block, valSet, "test_chain_id"), "mismatched LastBlockID")
+
+	block, valSet = makeBlock(t, 0)
+	block.LastCommitHash = tmrand.Bytes(tmhash.Size)
+	assert.Error(t, VerifyLastCommit(block, valSet, "test_chain_id"), "mismatched LastCommitHash")
+
+	// 2 of 4 validators signed
+	block, valSet = makeBlock(t, 2)
+	err := VerifyLastCommit(block, valSet, "test_chain_id")
+	assert.True(t, errors.As(err, &ErrNotEnoughVotingPowerSigned{}), "expected not enough voting power, got %v", err)
+
+	assert.Error(t, VerifyLastCommit(&Block{}, valSet, "test_chain_id"))
+}
+
+func TestVerifyCommitWithMaxAbsent(t *testing.T) {
+	const chainID = "test_chain_id"
+	blockID := makeBlockIDRandom()
+	voteSet, valSet, vals := randVoteSet(1, 1, tmproto.PrecommitType, 10, 1)
+	commit, err := MakeCommit(blockID, 1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	// 30% absent
+	sigs := append([]CommitSig(nil), commit.Signatures...)
+	for _, i := range []int{2, 5, 7} {
+		sigs[i] = NewCommitSigAbsent()
+	}
+	commit = NewCommit(commit.Height, commit.Round, commit.BlockID, sigs)
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, 1, commit))
+
+	assert.NoError(t, VerifyCommitWithMaxAbsent(chainID, blockID, 1, commit, valSet,
+		tmmath.Fraction{Numerator: 2, Denominator: 5}))
+	assert.NoError(t, VerifyCommitWithMaxAbsent(chainID, blockID, 1, commit, valSet,
+		tmmath.Fraction{Numerator: 3, Denominator: 10}))
+	err = VerifyCommitWithMaxAbsent(chainID, blockID, 1, commit, valSet, tmmath.Fraction{Numerator: 1, Denominator: 5})
+	assert.True(t, errors.Is(err, ErrTooMuchAbsentVotingPower), err)
+
+	// the 2/3 rule still applies
+	sigs[0] = NewCommitSigAbsent()
+	commit = NewCommit(commit.Height, commit.Round, commit.BlockID, sigs)
+	err = VerifyCommitWithMaxAbsent(chainID, blockID, 1, commit, valSet, tmmath.Fraction{Numerator: 1, Denominator: 1})
+	assert.True(t, errors.As(err, &ErrNotEnoughVotingPowerSigned{}), err)
+
+	assert.Error(t, VerifyCommitWithMaxAbsent(chainID, blockID, 1, commit, valSet, tmmath.Fraction{}))
+}
+
+func TestVerifyBlockEvidence(t *testing.T) {
+	const chainID = "mychain"
+	var (
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		pv1    = NewMockPV()
+		pv2    = NewMockPV()
+		// pv2 only joins the set at height 5
+		valSets = map[int64]*ValidatorSet{
+			3: NewValidatorSet([]*Validator{pv1.ExtractIntoValidator(10)}),
+			5: NewValidatorSet([]*Validator{pv1.ExtractIntoValidator(10), pv2.ExtractIntoValidator(10)}),
+		}
+		valSetForHeight = func(h int64) (*ValidatorSet, error) {
+			if vals, ok := valSets[h]; ok {
+				return vals, nil
+			}
+			return nil, errors.New("no validator set")
+		}
+	)
+
+	block := MakeBlock(10, nil, nil, []Evidence{
+		NewMockDuplicateVoteEvidenceWithValidator(3, evTime, pv1, chainID),
+		NewMockDuplicateVoteEvidenceWithValidator(5, evTime, pv2, chainID),
+	})
+	assert.NoError(t, VerifyBlockEvidence(block, valSetForHeight, chainID))
+	assert.NoError(t, VerifyBlockEvidence(MakeBlock(10, nil, nil, nil), valSetForHeight, chainID))
+
+	block = MakeBlock(10, nil, nil, []Evidence{
+		NewMockDuplicateVoteEvidenceWithValidator(3, evTime, pv1, chainID),
+		NewMockDuplicateVoteEvidenceWithValidator(3, evTime, pv2, chainID),
+	})
+	err := VerifyBlockEvidence(block, valSetForHeight, chainID)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "evidence #1: address")
+	}
+
+	block = MakeBlock(10, nil, nil, []Evidence{NewMockDuplicateVoteEvidenceWithValidator(4, evTime, pv1, chainID)})
+	assert.Error(t, VerifyBlockEvidence(block, valSetForHeight, chainID))
+
+	block = MakeBlock(10, nil, nil, []Evidence{NewMockDuplicateVoteEvidenceWithValidator(3, evTime, pv1, "other")})
+	assert.True(t, errors.Is(VerifyBlockEvidence(block, valSetForHeight, chainID), ErrEvidenceInvalidSignature))
+}
+
 func TestHeaderHash(t *testing.T) {
 	testCases := []struct {
 		desc       string
@@ -344,6 +641,16 @@ func TestHeaderHash(t *testing.T) {
 }
 
 func TestMaxHeaderBytes(t *testing.T) {
+	h := makeMaxHeader()
+
+	bz, err := h.ToProto().Marshal()
+	require.NoError(t, err)
+
+	assert.EqualValues(t, MaxHeaderBytes, int64(len(bz)))
+}
+
+// makeMaxHeader returns a header with every field at its maximum size.
+func makeMaxHeader() *Header {
 	// Construct a UTF-8 string of MaxChainIDLen length using the supplementary
 	// characters.
 	// Each supplementary character takes 4 bytes.
@@ -357,7 +664,7 @@ func TestMaxHeaderBytes(t *testing.T) {
 	// year int, month Month, day, hour, min, sec, nsec int, loc *Location
 	timestamp := time.Date(math.MaxInt64, 0, 0, 0, 0, 0, math.MaxInt64, time.UTC)
 
-	h := Header{
+	return &Header{
 		Version:            version.Consensus{Block: math.MaxInt64, App: math.MaxInt64},
 		ChainID:            maxChainID,
 		Height:             math.MaxInt64,
@@ -373,11 +680,6 @@ func TestMaxHeaderBytes(t *testing.T) {
 		EvidenceHash:       tmhash.Sum([]byte("evidence_hash")),
 		ProposerAddress:    crypto.AddressHash([]byte("proposer_address")),
 	}
-
-	bz, err := h.ToProto().Marshal()
-	require.NoError(t, err)
-
-	assert.EqualValues(t, MaxHeaderBytes, int64(len(bz)))
 }
 
 func randCommit(now time.Time) *Commit {
@@ -487,6 +789,39 @@ func TestCommitToVoteSet(t *testing.T) {
 	}
 }
 
+func TestCommitToVoteSetRoundTrip(t *testing.T) {
+	var (
+		blockID = makeBlockIDRandom()
+		height  = int64(3)
+		round   = int32(1)
+	)
+
+	voteSet, valSet, vals := randVoteSet(height, round, tmproto.PrecommitType, 4, 1)
+	// the last validator doesn't vote
+	for i := int32(0); i < 3; i++ {
+		pubKey, err := vals[i].GetPubKey()
+		require.NoError(t, err)
+		vote := &Vote{
+			ValidatorAddress: pubKey.Address(),
+			ValidatorIndex:   i,
+			Height:           height,
+			Round:            round,
+			Type:             tmproto.PrecommitType,
+			BlockID:          blockID,
+			Timestamp:        tmtime.Now(),
+		}
+		added, err := signAddVote(vals[i], vote, voteSet)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	commit := voteSet.MakeCommit()
+	require.True(t, commit.Signatures[3].Absent())
+
+	commit2 := CommitToVoteSet(voteSet.ChainID(), commit, valSet).MakeCommit()
+	assert.Equal(t, commit, commit2)
+	assert.Equal(t, commit.Hash(), commit2.Hash())
+}
+
 func TestCommitToVoteSetWithVotesForNilBlock(t *testing.T) {
 	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
 
@@ -596,6 +931,83 @@ func TestSignedHeaderValidateBasic(t *testing.T) {
 	}
 }
 
+func TestVerifyCommitAgainstHeader(t *testing.T) {
+	const (
+		height = int64(3)
+		round  = int32(1)
+	)
+	voteSet, valSet, vals := randVoteSet(height, round, tmproto.PrecommitType, 4, 1)
+	chainID := voteSet.ChainID()
+
+	header := makeHeaderRandom()
+	header.ChainID = chainID
+	header.Height = height
+	header.ValidatorsHash = valSet.Hash()
+	blockID := makeBlockID(header.Hash(), 1, tmhash.Sum([]byte("partshash")))
+
+	commit, err := MakeCommit(blockID, height, round, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	assert.NoError(t, VerifyCommitAgainstHeader(chainID, header, commit, valSet))
+	assert.Error(t, VerifyCommitAgainstHeader("other-chain", header, commit, valSet))
+	assert.Error(t, VerifyCommitAgainstHeader(chainID, header, commit, nil))
+	otherValSet, _ := RandValidatorSet(4, 1)
+	assert.Error(t, VerifyCommitAgainstHeader(chainID, header, commit, otherValSet))
+
+	// the commit is for another block
+	otherHeader := *header
+	otherHeader.AppHash = tmhash.Sum([]byte("other_app_hash"))
+	assert.Error(t, VerifyCommitAgainstHeader(chainID, &otherHeader, commit, valSet))
+
+	// or another height
+	otherHeader = *header
+	otherHeader.Height++
+	assert.Error(t, VerifyCommitAgainstHeader(chainID, &otherHeader, commit, valSet))
+
+	// only 2/4 signed
+	weakCommit := *commit
+	weakCommit.Signatures = append([]CommitSig(nil), commit.Signatures...)
+	weakCommit.Signatures[0] = NewCommitSigAbsent()
+	weakCommit.Signatures[1] = NewCommitSigAbsent()
+	err = VerifyCommitAgainstHeader(chainID, header, &weakCommit, valSet)
+	assert.True(t, IsErrNotEnoughVotingPowerSigned(err), err)
+}
+
+func TestBlockIDZeroOrComplete(t *testing.T) {
+	hash := tmhash.Sum([]byte("block"))
+	partsHash := tmhash.Sum([]byte("parts"))
+
+	testCases := []struct {
+		testName string
+		blockID  BlockID
+		zero     bool
+		complete bool
+	}{
+		{"zero", BlockID{}, true, false},
+		{"empty slices", BlockID{Hash: []byte{}, PartSetHeader: PartSetHeader{Hash: []byte{}}}, true, false},
+		{"complete", BlockID{hash, PartSetHeader{1, partsHash}}, false, true},
+		{"hash only", BlockID{Hash: hash}, false, false},
+		{"part set header only", BlockID{PartSetHeader: PartSetHeader{1, partsHash}}, false, false},
+		{"zero total", BlockID{hash, PartSetHeader{0, partsHash}}, false, false},
+		{"short hash", BlockID{hash[:10], PartSetHeader{1, partsHash}}, false, false},
+		{"short parts hash", BlockID{hash, PartSetHeader{1, partsHash[:10]}}, false, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.zero, tc.blockID.IsZero())
+			assert.Equal(t, tc.complete, tc.blockID.IsComplete())
+			err := tc.blockID.ValidateZeroOrComplete()
+			if tc.zero || tc.complete {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrBlockIDIncomplete), err)
+			}
+		})
+	}
+}
+
 func TestBlockIDValidateBasic(t *testing.T) {
 	validBlockID := BlockID{
 		Hash: bytes.HexBytes{},
@@ -622,6 +1034,8 @@ func TestBlockIDValidateBasic(t *testing.T) {
 		{"Valid BlockID", validBlockID.Hash, validBlockID.PartSetHeader, false},
 		{"Invalid BlockID", invalidBlockID.Hash, validBlockID.PartSetHeader, true},
 		{"Invalid BlockID", validBlockID.Hash, invalidBlockID.PartSetHeader, true},
+		{"Zero PartSetHeader Total", validBlockID.Hash,
+			PartSetHeader{Total: 0, Hash: tmhash.Sum([]byte("partshash"))}, true},
 	}
 
 	for _, tc := range testCases {
@@ -636,6 +1050,42 @@ func TestBlockIDValidateBasic(t *testing.T) {
 	}
 }
 
+func TestNewBlockID(t *testing.T) {
+	var (
+		hash      = tmhash.Sum([]byte("blockhash"))
+		partsHash = tmhash.Sum([]byte("partshash"))
+	)
+
+	testCases := []struct {
+		testName   string
+		hash       []byte
+		partsTotal uint32
+		partsHash  []byte
+		expectErr  bool
+	}{
+		{"valid", hash, 1, partsHash, false},
+		{"valid empty", nil, 0, nil, false},
+		{"wrong hash length", hash[:10], 1, partsHash, true},
+		{"wrong parts hash length", hash, 1, append(partsHash, 0), true},
+		{"hash set but zero parts", hash, 0, partsHash, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			blockID, err := NewBlockID(tc.hash, tc.partsTotal, tc.partsHash)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.EqualValues(t, tc.hash, blockID.Hash)
+			assert.Equal(t, tc.partsTotal, blockID.PartSetHeader.Total)
+			assert.EqualValues(t, tc.partsHash, blockID.PartSetHeader.Hash)
+		})
+	}
+}
+
 func TestBlockProtoBuf(t *testing.T) {
 	h := tmrand.Int63()
 	c1 := randCommit(time.Now())
@@ -651,6 +1101,25 @@ func TestBlockProtoBuf(t *testing.T) {
 
 	b3 := MakeBlock(h, []Tx{}, c1, []Evidence{})
 	b3.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
+
+	b4 := MakeBlock(h, []Tx{}, c1, []Evidence{})
+	b4.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
+	val := NewMockPV()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	dpe := NewDuplicateProposalEvidence(
+		makeProposal(t, val, "block-test-chain", h, 0, makeBlockIDRandom()),
+		makeProposal(t, val, "block-test-chain", h, 0, makeBlockIDRandom()),
+		pubKey.Address(),
+		evidenceTime,
+	)
+	fhe := NewFutureHeightEvidence(
+		makeVote(t, val, "block-test-chain", 0, h+1, 0, 2, makeBlockIDRandom(), evidenceTime),
+		h,
+		evidenceTime,
+	)
+	b4.Evidence = EvidenceData{Evidence: EvidenceList{evi, dpe, fhe}}
+	b4.EvidenceHash = b4.Evidence.Hash()
 	testCases := []struct {
 		msg      string
 		b1       *Block
@@ -661,6 +1130,7 @@ func TestBlockProtoBuf(t *testing.T) {
 		{"b1", b1, true, true},
 		{"b2", b2, true, true},
 		{"b3", b3, true, true},
+		{"b4", b4, true, true},
 	}
 	for _, tc := range testCases {
 		pb, err := tc.b1.ToProto()
@@ -714,7 +1184,7 @@ func TestEvidenceDataProtoBuf(t *testing.T) {
 	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
 	const chainID = "mychain"
 	v := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, 1, 0x01, blockID, time.Now())
-	v2 := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, 2, 0x01, blockID2, time.Now())
+	v2 := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, 1, 0x01, blockID2, time.Now())
 	ev := NewDuplicateVoteEvidence(v2, v, v2.Timestamp)
 	data := &EvidenceData{Evidence: EvidenceList{ev}}
 	_ = data.Hash()
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChainIDSeparator separates the segments of a hierarchical chain ID, e.g.
+// "cosmoshub/3/testnet".
+const ChainIDSeparator = "/"
+
+// ParseChainID parses a hierarchical chain ID of the form
+// "root[/revision[/namespace...]]" and returns its root and revision. A chain
+// ID without a revision (e.g. "cosmoshub") has revision 0. Any segments after
+// the revision are free-form namespaces.
+//
+// It returns an error if the chain ID is empty, longer than MaxChainIDLen,
+// contains an empty segment (including a leading or trailing separator) or if
+// the revision is not an unsigned 64-bit integer.
+func ParseChainID(id string) (root string, revision uint64, err error) {
+	if id == "" {
+		return "", 0, errors.New("empty chain ID")
+	}
+	if len(id) > MaxChainIDLen {
+		return "", 0, fmt.Errorf("chain ID is too long; got: %d, max: %d", len(id), MaxChainIDLen)
+	}
+
+	segments := strings.Split(id, ChainIDSeparator)
+	for i, segment := range segments {
+		if segment == "" {
+			return "", 0, fmt.Errorf("chain ID %q has an empty segment (#%d)", id, i)
+		}
+	}
+
+	if len(segments) > 1 {
+		revision, err = strconv.ParseUint(segments[1], 10, 64)
+		if err != nil {
+			return "", 0, fmt.Errorf("chain ID %q has an invalid revision: %w", id, err)
+		}
+	}
+
+	return segments[0], revision, nil
+}
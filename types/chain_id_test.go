@@ -0,0 +1,61 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChainID(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		id          string
+		expRoot     string
+		expRevision uint64
+		expErr      bool
+	}{
+		{"plain", "cosmoshub", "cosmoshub", 0, false},
+		{"with revision", "cosmoshub/3", "cosmoshub", 3, false},
+		{"with namespace", "cosmoshub/3/testnet", "cosmoshub", 3, false},
+		{"max revision", "chain/18446744073709551615", "chain", 18446744073709551615, false},
+		{"empty", "", "", 0, true},
+		{"too long", strings.Repeat("a", MaxChainIDLen+1), "", 0, true},
+		{"empty segment", "cosmoshub//testnet", "", 0, true},
+		{"leading slash", "/cosmoshub", "", 0, true},
+		{"trailing slash", "cosmoshub/3/", "", 0, true},
+		{"invalid revision", "cosmoshub/three", "", 0, true},
+		{"negative revision", "cosmoshub/-3", "", 0, true},
+		{"revision overflow", "chain/18446744073709551616", "", 0, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			root, revision, err := ParseChainID(tc.id)
+			if tc.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expRoot, root)
+			assert.Equal(t, tc.expRevision, revision)
+		})
+	}
+}
+
+func TestDuplicateVoteEvidenceNamespacedChainID(t *testing.T) {
+	const chainID = "cosmoshub/3/testnet"
+	_, _, err := ParseChainID(chainID)
+	require.NoError(t, err)
+
+	val := NewMockPV()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+
+	ev := NewMockDuplicateVoteEvidenceWithValidator(10, defaultVoteTime, val, chainID)
+	require.NoError(t, ev.ValidateBasic())
+	assert.NoError(t, ev.Verify(chainID, pubKey))
+	assert.Error(t, ev.Verify("cosmoshub/4/testnet", pubKey))
+}
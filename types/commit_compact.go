@@ -0,0 +1,65 @@
+package types
+
+import (
+	"github.com/tendermint/tendermint/libs/bits"
+)
+
+// CompactCommit is a Commit without the absent signatures, to save space when
+// storing many commits. Present records, for every validator of the set which
+// signed the commit, whether its signature is present (i.e. for the block or
+// for nil); Signatures holds only those, in set order.
+type CompactCommit struct {
+	Height     int64          `json:"height"`
+	Round      int32          `json:"round"`
+	BlockID    BlockID        `json:"block_id"`
+	Present    *bits.BitArray `json:"present"`
+	Signatures []CommitSig    `json:"signatures"`
+}
+
+// Compact returns the commit without its absent signatures. See
+// CompactCommit.Expand.
+func (commit *Commit) Compact() *CompactCommit {
+	cc := &CompactCommit{
+		Height:     commit.Height,
+		Round:      commit.Round,
+		BlockID:    commit.BlockID,
+		Present:    bits.NewBitArray(len(commit.Signatures)),
+		Signatures: make([]CommitSig, 0, len(commit.Signatures)),
+	}
+	for i, commitSig := range commit.Signatures {
+		if commitSig.Absent() {
+			continue
+		}
+		cc.Present.SetIndex(i, true)
+		cc.Signatures = append(cc.Signatures, commitSig)
+	}
+	return cc
+}
+
+// Expand reconstructs the full commit, which has the same hash as the
+// original one. valSet must be the validator set which signed the commit. It
+// returns nil if the size of valSet or the number of signatures doesn't match
+// Present.
+func (cc *CompactCommit) Expand(valSet *ValidatorSet) *Commit {
+	if cc.Present == nil || cc.Present.Size() != valSet.Size() {
+		return nil
+	}
+
+	sigs := make([]CommitSig, cc.Present.Size())
+	next := 0
+	for i := range sigs {
+		if !cc.Present.GetIndex(i) {
+			sigs[i] = NewCommitSigAbsent()
+			continue
+		}
+		if next >= len(cc.Signatures) {
+			return nil
+		}
+		sigs[i] = cc.Signatures[next]
+		next++
+	}
+	if next != len(cc.Signatures) {
+		return nil
+	}
+	return NewCommit(cc.Height, cc.Round, cc.BlockID, sigs)
+}
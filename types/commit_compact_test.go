@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestCommitCompactExpand(t *testing.T) {
+	const (
+		chainID = "test_chain_id"
+		h       = int64(3)
+	)
+	blockID := makeBlockIDRandom()
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 10, 1)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	// 3 absent validators, one voting nil
+	for _, idx := range []int{1, 4, 9} {
+		commit.Signatures[idx] = NewCommitSigAbsent()
+	}
+	nilVote := makeVote(t, vals[5], chainID, 5, h, 0, 2, BlockID{}, time.Now())
+	commit.Signatures[5] = nilVote.CommitSig()
+	commit = NewCommit(commit.Height, commit.Round, commit.BlockID, commit.Signatures)
+
+	cc := commit.Compact()
+	assert.Len(t, cc.Signatures, 7)
+	assert.Equal(t, 10, cc.Present.Size())
+	assert.False(t, cc.Present.GetIndex(1))
+	assert.True(t, cc.Present.GetIndex(5))
+
+	expanded := cc.Expand(valSet)
+	require.NotNil(t, expanded)
+	assert.Equal(t, commit.Hash(), expanded.Hash())
+	assert.Equal(t, commit.Signatures, expanded.Signatures)
+	assert.NoError(t, expanded.ValidateBasic())
+
+	// wrong validator set
+	otherValSet, _ := RandValidatorSet(9, 1)
+	assert.Nil(t, cc.Expand(otherValSet))
+
+	// signatures missing
+	cc.Signatures = cc.Signatures[1:]
+	assert.Nil(t, cc.Expand(valSet))
+}
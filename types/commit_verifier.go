@@ -0,0 +1,102 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// CommitVerifier verifies the signatures of a commit as they come in, e.g.
+// while streaming them from peers, tallying the voting power which signed for
+// the block until it exceeds 2/3 of the set. It's a streaming variant of
+// ValidatorSet.VerifyCommit.
+//
+// CommitVerifier is safe for concurrent use.
+type CommitVerifier struct {
+	chainID string
+	blockID BlockID
+	height  int64
+	round   int32
+	valSet  *ValidatorSet
+
+	mtx         tmsync.Mutex
+	added       []bool
+	tallied     int64
+	powerNeeded int64
+	err         error // the verifier can't be used
+}
+
+// NewCommitVerifier returns a CommitVerifier for the commit of valSet for
+// blockID at the given height and round. Unlike the other fields, the round
+// isn't part of a CommitSig, but it's needed to verify its signature.
+func NewCommitVerifier(chainID string, blockID BlockID, height int64, round int32,
+	valSet *ValidatorSet) *CommitVerifier {
+	powerNeeded, err := valSet.twoThirdsVotingPower()
+	return &CommitVerifier{
+		chainID:     chainID,
+		blockID:     blockID,
+		height:      height,
+		round:       round,
+		valSet:      valSet,
+		added:       make([]bool, valSet.Size()),
+		powerNeeded: powerNeeded,
+		err:         err,
+	}
+}
+
+// AddSignature verifies the signature of the validator with the given index
+// in the set and, if it's for the block, adds its voting power to the tally.
+// Absent signatures are accepted but not verified. An error is returned if the
+// index is out of range or was already added, or if the signature is invalid,
+// in which case the index can be added again.
+func (cv *CommitVerifier) AddSignature(valIndex int, sig CommitSig) error {
+	cv.mtx.Lock()
+	defer cv.mtx.Unlock()
+
+	if cv.err != nil {
+		return cv.err
+	}
+	if valIndex < 0 || valIndex >= len(cv.added) {
+		return fmt.Errorf("validator index %d out of range [0, %d)", valIndex, len(cv.added))
+	}
+	if cv.added[valIndex] {
+		return fmt.Errorf("signature of validator #%d already added", valIndex)
+	}
+	if err := sig.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid signature of validator #%d: %w", valIndex, err)
+	}
+
+	if !sig.Absent() {
+		val := cv.valSet.Validators[valIndex]
+		if !bytes.Equal(sig.ValidatorAddress, val.Address) {
+			return fmt.Errorf("wrong validator address (#%d): expected %v, got %v",
+				valIndex, val.Address, sig.ValidatorAddress)
+		}
+		vote := commitSigVote(sig, cv.height, cv.round, cv.blockID, int32(valIndex))
+		if err := verifyCommitSig(cv.chainID, valIndex, val, vote); err != nil {
+			return err
+		}
+		tallied, err := tallyCommitSig(cv.tallied, val, sig)
+		if err != nil {
+			return err
+		}
+		cv.tallied = tallied
+	}
+
+	cv.added[valIndex] = true
+	return nil
+}
+
+// Done returns true once the validators which signed for the block have more
+// than 2/3 of the voting power of the set. An error is returned if the set is
+// invalid, e.g. its total voting power overflows.
+func (cv *CommitVerifier) Done() (bool, error) {
+	cv.mtx.Lock()
+	defer cv.mtx.Unlock()
+
+	if cv.err != nil {
+		return false, cv.err
+	}
+	return cv.tallied > cv.powerNeeded, nil
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestCommitVerifier(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+	voteSet, valSet, vals := randVoteSet(h, 1, tmproto.PrecommitType, 7, 1)
+	commit, err := MakeCommit(blockID, h, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	cv := NewCommitVerifier(chainID, blockID, h, 1, valSet)
+	done, err := cv.Done()
+	require.NoError(t, err)
+	assert.False(t, done)
+
+	// absent signatures don't count
+	require.NoError(t, cv.AddSignature(6, NewCommitSigAbsent()))
+
+	// a bad signature is rejected, but the index can be added again
+	badSig := commit.Signatures[0]
+	badSig.Signature = commit.Signatures[1].Signature
+	assert.Error(t, cv.AddSignature(0, badSig))
+	// so is a signature of another validator
+	assert.Error(t, cv.AddSignature(0, commit.Signatures[1]))
+
+	for idx := 0; idx < 5; idx++ {
+		require.NoError(t, cv.AddSignature(idx, commit.Signatures[idx]), idx)
+		done, err = cv.Done()
+		require.NoError(t, err)
+		assert.Equal(t, idx == 4, done, idx)
+	}
+
+	// double adds and out of range indexes
+	assert.Error(t, cv.AddSignature(2, commit.Signatures[2]))
+	assert.Error(t, cv.AddSignature(6, commit.Signatures[6]))
+	assert.Error(t, cv.AddSignature(-1, commit.Signatures[0]))
+	assert.Error(t, cv.AddSignature(7, commit.Signatures[0]))
+
+	require.NoError(t, cv.AddSignature(5, commit.Signatures[5]))
+	done, err = cv.Done()
+	require.NoError(t, err)
+	assert.True(t, done)
+
+	// signatures for another round don't verify
+	cv = NewCommitVerifier(chainID, blockID, h, 0, valSet)
+	assert.Error(t, cv.AddSignature(0, commit.Signatures[0]))
+}
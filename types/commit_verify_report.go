@@ -0,0 +1,79 @@
+package types
+
+// CommitSigReport describes the signature of a validator in a commit, as
+// verified by ValidatorSet.VerifyCommitVerbose.
+type CommitSigReport struct {
+	Address     Address     `json:"address"`
+	BlockIDFlag BlockIDFlag `json:"block_id_flag"` // for the block, for nil or absent
+	Present     bool        `json:"present"`       // not absent
+	Valid       bool        `json:"valid"`         // correctly signed, if present
+	// voting power of the validators which signed for the block so far,
+	// including this one
+	TalliedPower int64 `json:"tallied_power"`
+}
+
+// CommitVerifyReport is a breakdown of the verification of a commit, to help
+// debugging why it failed.
+type CommitVerifyReport struct {
+	Signatures   []CommitSigReport `json:"signatures"` // in set order
+	TalliedPower int64             `json:"tallied_power"`
+	PowerNeeded  int64             `json:"power_needed"` // the tallied power must be greater
+	// index of the signature with which the tallied power exceeded
+	// PowerNeeded, or -1 if it never did
+	CrossingIndex int `json:"crossing_index"`
+}
+
+// VerifyCommitVerbose is like VerifyCommit, but also returns a report of the
+// verification of each signature. All signatures are verified even if some
+// are invalid, and the report is returned along with the error, if any; the
+// error is the one VerifyCommit would return. If the commit doesn't match the
+// set, height or blockID, the report has no signatures.
+func (vals *ValidatorSet) VerifyCommitVerbose(chainID string, blockID BlockID,
+	height int64, commit *Commit) (*CommitVerifyReport, error) {
+	report := &CommitVerifyReport{CrossingIndex: -1}
+
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return report, err
+	}
+
+	votingPowerNeeded, err := vals.twoThirdsVotingPower()
+	if err != nil {
+		return report, err
+	}
+	report.PowerNeeded = votingPowerNeeded
+
+	var firstErr error
+	report.Signatures = make([]CommitSigReport, len(commit.Signatures))
+	// The vals and commit have a 1-to-1 correspondance.
+	vals.ForEach(func(idx int, val *Validator) bool {
+		commitSig := commit.Signatures[idx]
+		sigReport := &report.Signatures[idx]
+		sigReport.Address = val.Address
+		sigReport.BlockIDFlag = commitSig.BlockIDFlag
+		sigReport.Present = !commitSig.Absent()
+
+		if sigReport.Present {
+			err := verifyCommitSig(chainID, idx, val, commit.getVote(int32(idx)))
+			sigReport.Valid = err == nil
+			if sigReport.Valid {
+				report.TalliedPower, err = tallyCommitSig(report.TalliedPower, val, commitSig)
+				if report.TalliedPower > votingPowerNeeded && report.CrossingIndex == -1 {
+					report.CrossingIndex = idx
+				}
+			}
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		sigReport.TalliedPower = report.TalliedPower
+		return false
+	})
+
+	if firstErr != nil {
+		return report, firstErr
+	}
+	if got, needed := report.TalliedPower, votingPowerNeeded; got <= needed {
+		return report, ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+	return report, nil
+}
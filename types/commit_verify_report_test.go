@@ -0,0 +1,64 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestValidatorSetVerifyCommitVerbose(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 10, 1)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	commit.Signatures[9] = NewCommitSigAbsent()
+
+	report, err := valSet.VerifyCommitVerbose(chainID, blockID, h, commit)
+	require.NoError(t, err)
+	assert.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	assert.EqualValues(t, 6, report.PowerNeeded)
+	assert.EqualValues(t, 9, report.TalliedPower)
+	assert.Equal(t, 6, report.CrossingIndex)
+	require.Len(t, report.Signatures, 10)
+	for idx, sigReport := range report.Signatures[:9] {
+		assert.Equal(t, valSet.Validators[idx].Address, sigReport.Address)
+		assert.Equal(t, BlockIDFlagCommit, sigReport.BlockIDFlag)
+		assert.True(t, sigReport.Present)
+		assert.True(t, sigReport.Valid)
+		assert.EqualValues(t, idx+1, sigReport.TalliedPower)
+	}
+	assert.Equal(t, CommitSigReport{
+		Address:      valSet.Validators[9].Address,
+		BlockIDFlag:  BlockIDFlagAbsent,
+		TalliedPower: 9,
+	}, report.Signatures[9])
+
+	// one bad signature
+	commit.Signatures[3].Signature = commit.Signatures[4].Signature
+	report, err = valSet.VerifyCommitVerbose(chainID, blockID, h, commit)
+	assert.Equal(t, valSet.VerifyCommit(chainID, blockID, h, commit), err)
+	require.NotNil(t, report)
+	require.Len(t, report.Signatures, 10)
+	for idx, sigReport := range report.Signatures[:9] {
+		assert.True(t, sigReport.Present)
+		assert.Equal(t, idx != 3, sigReport.Valid, idx)
+	}
+	assert.EqualValues(t, 3, report.Signatures[2].TalliedPower)
+	assert.EqualValues(t, 3, report.Signatures[3].TalliedPower)
+	assert.EqualValues(t, 8, report.TalliedPower)
+	assert.Equal(t, 7, report.CrossingIndex)
+
+	// wrong height
+	report, err = valSet.VerifyCommitVerbose(chainID, blockID, h+1, commit)
+	assert.Error(t, err)
+	assert.Empty(t, report.Signatures)
+	assert.Equal(t, -1, report.CrossingIndex)
+}
@@ -0,0 +1,185 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/libs/reedsolomon"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// ErrErasurePartSetHashMismatch is returned by ErasurePartSet.Reconstruct if
+// the reconstructed bytes don't hash to the expected PartSetHeader.
+var ErrErasurePartSetHashMismatch = errors.New("reconstructed bytes don't match the part set header")
+
+// ErasurePartSet is an alternative to PartSet, which splits the encoded block
+// into dataShards shards and adds parityShards Reed-Solomon parity shards, so
+// that the block can be reconstructed from any dataShards of them.
+//
+// The shards are checked against the PartSetHeader of the block's regular
+// PartSet (BlockPartSizeBytes parts), i.e. the one included in its BlockID,
+// only once the block is reconstructed.
+type ErasurePartSet struct {
+	header  PartSetHeader
+	dataLen int
+	enc     *reedsolomon.Encoder
+
+	mtx    tmsync.Mutex
+	shards [][]byte // nil if missing
+	count  int
+}
+
+// MakeErasureParts encodes the block into dataShards data shards and
+// parityShards parity shards. It returns nil if the block is nil.
+// Panics if dataShards is not positive, parityShards is negative or their sum
+// exceeds reedsolomon.MaxShards.
+func MakeErasureParts(block *Block, dataShards, parityShards int) *ErasurePartSet {
+	if block == nil {
+		return nil
+	}
+	enc, err := reedsolomon.New(dataShards, parityShards)
+	if err != nil {
+		panic(err)
+	}
+
+	block.mtx.Lock()
+	pbb, err := block.ToProto()
+	block.mtx.Unlock()
+	if err != nil {
+		panic(err)
+	}
+	bz, err := proto.Marshal(pbb)
+	if err != nil {
+		panic(err)
+	}
+
+	// split bz into equally sized shards, padding the last ones with zeros
+	shardSize := (len(bz) + dataShards - 1) / dataShards
+	padded := make([]byte, shardSize*dataShards)
+	copy(padded, bz)
+	shards := make([][]byte, dataShards+parityShards)
+	for i := 0; i < dataShards; i++ {
+		shards[i] = padded[i*shardSize : (i+1)*shardSize]
+	}
+	if err := enc.Encode(shards); err != nil {
+		panic(err)
+	}
+
+	return &ErasurePartSet{
+		header:  NewPartSetFromData(bz, BlockPartSizeBytes).Header(),
+		dataLen: len(bz),
+		enc:     enc,
+		shards:  shards,
+		count:   len(shards),
+	}
+}
+
+// NewErasurePartSet returns an empty ErasurePartSet, ready to be populated
+// with AddShard, for a block of dataLen encoded bytes whose PartSet has the
+// given header.
+func NewErasurePartSet(header PartSetHeader, dataLen, dataShards, parityShards int) (*ErasurePartSet, error) {
+	if err := header.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("wrong header: %w", err)
+	}
+	if dataLen < 0 {
+		return nil, errors.New("negative dataLen")
+	}
+	enc, err := reedsolomon.New(dataShards, parityShards)
+	if err != nil {
+		return nil, err
+	}
+	return &ErasurePartSet{
+		header:  header,
+		dataLen: dataLen,
+		enc:     enc,
+		shards:  make([][]byte, dataShards+parityShards),
+	}, nil
+}
+
+// Header returns the header of the block's regular PartSet.
+func (eps *ErasurePartSet) Header() PartSetHeader {
+	return eps.header
+}
+
+// DataLen returns the length of the encoded block.
+func (eps *ErasurePartSet) DataLen() int {
+	return eps.dataLen
+}
+
+// Total returns the number of data and parity shards.
+func (eps *ErasurePartSet) Total() int {
+	return eps.enc.DataShards() + eps.enc.ParityShards()
+}
+
+// Count returns the number of shards present.
+func (eps *ErasurePartSet) Count() int {
+	eps.mtx.Lock()
+	defer eps.mtx.Unlock()
+	return eps.count
+}
+
+// GetShard returns the shard at the given index or nil if it's missing or the
+// index is out of range. Data shards come first, followed by the parity shards.
+func (eps *ErasurePartSet) GetShard(index int) []byte {
+	eps.mtx.Lock()
+	defer eps.mtx.Unlock()
+	if index < 0 || index >= len(eps.shards) {
+		return nil
+	}
+	return eps.shards[index]
+}
+
+// AddShard adds the shard at the given index. It returns false if the shard is
+// already present.
+func (eps *ErasurePartSet) AddShard(index int, shard []byte) (bool, error) {
+	eps.mtx.Lock()
+	defer eps.mtx.Unlock()
+
+	if index < 0 || index >= len(eps.shards) {
+		return false, ErrPartSetUnexpectedIndex
+	}
+	if want := eps.shardSize(); len(shard) != want {
+		return false, fmt.Errorf("wrong shard size: got %d, want %d", len(shard), want)
+	}
+	if eps.shards[index] != nil {
+		return false, nil
+	}
+
+	eps.shards[index] = shard
+	eps.count++
+	return true, nil
+}
+
+// Reconstruct recovers the missing shards and returns the encoded block. It
+// returns an error if less than dataShards shards are present or
+// ErrErasurePartSetHashMismatch if the result doesn't match Header.
+func (eps *ErasurePartSet) Reconstruct() ([]byte, error) {
+	eps.mtx.Lock()
+	defer eps.mtx.Unlock()
+
+	shards := append([][]byte(nil), eps.shards...)
+	if err := eps.enc.Reconstruct(shards); err != nil {
+		return nil, err
+	}
+
+	bz := make([]byte, 0, eps.shardSize()*eps.enc.DataShards())
+	for _, shard := range shards[:eps.enc.DataShards()] {
+		bz = append(bz, shard...)
+	}
+	bz = bz[:eps.dataLen]
+
+	if !NewPartSetFromData(bz, BlockPartSizeBytes).HasHeader(eps.header) {
+		return nil, ErrErasurePartSetHashMismatch
+	}
+
+	eps.shards = shards
+	eps.count = len(shards)
+	return bz, nil
+}
+
+func (eps *ErasurePartSet) shardSize() int {
+	dataShards := eps.enc.DataShards()
+	return (eps.dataLen + dataShards - 1) / dataShards
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestErasurePartSet(t *testing.T) {
+	assert.Nil(t, MakeErasureParts(nil, 4, 2))
+	assert.Panics(t, func() { MakeErasureParts(&Block{}, 0, 2) })
+
+	txs := []Tx{Tx(tmrand.Bytes(1000)), Tx(tmrand.Bytes(3000))}
+	block := MakeBlock(3, txs, nil, nil)
+	pbb, err := block.ToProto()
+	require.NoError(t, err)
+	blockBytes, err := proto.Marshal(pbb)
+	require.NoError(t, err)
+
+	sent := MakeErasureParts(block, 4, 2)
+	require.Equal(t, 6, sent.Total())
+	require.Equal(t, 6, sent.Count())
+	require.Equal(t, block.MakePartSet(BlockPartSizeBytes).Header(), sent.Header())
+
+	for _, dropped := range [][2]int{{0, 1}, {1, 4}, {3, 5}, {4, 5}} {
+		received, err := NewErasurePartSet(sent.Header(), sent.DataLen(), 4, 2)
+		require.NoError(t, err)
+		for i := 0; i < sent.Total(); i++ {
+			if i == dropped[0] || i == dropped[1] {
+				continue
+			}
+			added, err := received.AddShard(i, sent.GetShard(i))
+			require.NoError(t, err)
+			assert.True(t, added)
+		}
+
+		bz, err := received.Reconstruct()
+		require.NoError(t, err, "dropped %v", dropped)
+		assert.Equal(t, 6, received.Count())
+		assert.Equal(t, sent.GetShard(dropped[0]), received.GetShard(dropped[0]))
+		assert.Equal(t, blockBytes, bz)
+	}
+}
+
+func TestErasurePartSetReconstructErrors(t *testing.T) {
+	block := MakeBlock(3, []Tx{Tx(tmrand.Bytes(1000))}, nil, nil)
+	sent := MakeErasureParts(block, 4, 2)
+
+	received, err := NewErasurePartSet(sent.Header(), sent.DataLen(), 4, 2)
+	require.NoError(t, err)
+
+	_, err = received.AddShard(6, sent.GetShard(0))
+	assert.Equal(t, ErrPartSetUnexpectedIndex, err)
+	assert.Nil(t, sent.GetShard(-1))
+	assert.Nil(t, sent.GetShard(6))
+	_, err = received.AddShard(0, sent.GetShard(0)[1:])
+	assert.Error(t, err)
+
+	// too few shards
+	for i := 0; i < 3; i++ {
+		_, err := received.AddShard(i, sent.GetShard(i))
+		require.NoError(t, err)
+	}
+	_, err = received.Reconstruct()
+	assert.Error(t, err)
+
+	// corrupted shard
+	corrupted := append([]byte(nil), sent.GetShard(4)...)
+	corrupted[0] ^= 0xff
+	_, err = received.AddShard(4, corrupted)
+	require.NoError(t, err)
+	_, err = received.Reconstruct()
+	assert.Equal(t, ErrErasurePartSetHashMismatch, err)
+	assert.Nil(t, received.GetShard(3))
+}
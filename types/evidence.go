@@ -2,8 +2,11 @@ package types
 
 import (
 	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,11 +18,49 @@ import (
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 const (
 	// MaxEvidenceBytes is a maximum size of any evidence (including amino overhead).
 	MaxEvidenceBytes int64 = 444
+	// MaxFutureHeightEvidenceBytes is the maximum size of FutureHeightEvidence
+	// (see MaxBytesForEvidence).
+	MaxFutureHeightEvidenceBytes int64 = 240
+	// MaxDuplicateProposalEvidenceBytes is the maximum size of
+	// DuplicateProposalEvidence (see MaxBytesForEvidence).
+	MaxDuplicateProposalEvidenceBytes int64 = 400
+	// MaxLunaticValidatorEvidenceBytes is the maximum size of
+	// LunaticValidatorEvidence (see MaxBytesForEvidence).
+	MaxLunaticValidatorEvidenceBytes int64 = 926
+	// MaxPotentialAmnesiaEvidenceBytes is the maximum size of
+	// PotentialAmnesiaEvidence (see MaxBytesForEvidence).
+	MaxPotentialAmnesiaEvidenceBytes int64 = 441
+	// MaxConflictingHeadersEvidenceBytes is the maximum size of
+	// ConflictingHeadersEvidence, whose commits have at most MaxVotesCount
+	// signatures of at most MaxVoteBytes each (see MaxBytesForEvidence).
+	MaxConflictingHeadersEvidenceBytes int64 = 2 * (MaxHeaderBytes + maxCommitOverheadBytes +
+		MaxVotesCount*MaxVoteBytes + maxSignedHeaderOverheadBytes)
+	// MaxAmnesiaEvidenceBytes is the maximum size of AmnesiaEvidence, whose
+	// proof of lock change has at most MaxVotesCount votes (see
+	// MaxBytesForEvidence).
+	MaxAmnesiaEvidenceBytes int64 = MaxPotentialAmnesiaEvidenceBytes + maxAmnesiaEvidenceOverheadBytes +
+		MaxVotesCount*(MaxVoteBytes+3)
+
+	// maxCommitOverheadBytes is the maximum size of a commit without its
+	// signatures.
+	maxCommitOverheadBytes int64 = 94
+	// maxSignedHeaderOverheadBytes is the size of the field keys and lengths
+	// of a signed header in ConflictingHeadersEvidence.
+	maxSignedHeaderOverheadBytes int64 = 11
+	// maxAmnesiaEvidenceOverheadBytes is the size of the field keys and
+	// lengths of AmnesiaEvidence and the public key of its proof of lock
+	// change.
+	maxAmnesiaEvidenceOverheadBytes int64 = 44
+	// maxEncodedEvidenceBytes is the maximum size of evidence encoded by
+	// EvidenceToProto, i.e. of the largest type (ConflictingHeadersEvidence)
+	// with the field key and length of the Sum oneof.
+	maxEncodedEvidenceBytes int64 = MaxConflictingHeadersEvidenceBytes + 5
 
 	// An invalid field in the header from LunaticValidatorEvidence.
 	// Must be a function of the ABCI application state.
@@ -62,6 +103,52 @@ func (err *ErrEvidenceOverflow) Error() string {
 	return fmt.Sprintf("Too much evidence: Max %d, got %d", err.MaxNum, err.GotNum)
 }
 
+var (
+	// ErrEvidenceWrongChainID is returned when the evidence is for another chain.
+	ErrEvidenceWrongChainID = errors.New("chainID do not match")
+	// ErrEvidenceInvalidSignature is returned when a vote or proposal contained
+	// in the evidence is not correctly signed.
+	ErrEvidenceInvalidSignature = fmt.Errorf("evidence has %w", ErrVoteInvalidSignature)
+	// ErrEvidencePubKeyMismatch is returned when the public key used to verify
+	// the evidence is not the one of the accused validator.
+	ErrEvidencePubKeyMismatch = errors.New("address doesn't match pubkey")
+	// ErrEvidenceNilPubKey is returned when the evidence is verified without
+	// a public key, e.g. because the accused validator couldn't be found.
+	ErrEvidenceNilPubKey = errors.New("nil pubkey")
+	// ErrEvidenceVoteMismatch is returned when the votes of the evidence are not
+	// for the same height, round and step.
+	ErrEvidenceVoteMismatch = errors.New("h/r/s does not match")
+	// ErrEvidenceAddressMismatch is returned when the votes of the evidence are
+	// not from the same validator.
+	ErrEvidenceAddressMismatch = errors.New("validator addresses do not match")
+	// ErrEvidenceSameBlockID is returned when the votes of the evidence are for
+	// the same block, i.e. they don't conflict.
+	ErrEvidenceSameBlockID = errors.New("block IDs are the same")
+	// ErrEvidenceVoteOrder is returned when the votes of the evidence are not
+	// in canonical order.
+	ErrEvidenceVoteOrder = errors.New("duplicate votes in invalid order")
+	// ErrEvidenceProposalMismatch is returned when the proposals of the evidence
+	// are not for the same height and round.
+	ErrEvidenceProposalMismatch = errors.New("h/r of proposals does not match")
+	// ErrEvidenceProposalOrder is returned when the proposals of the evidence are
+	// not in canonical order.
+	ErrEvidenceProposalOrder = errors.New("duplicate proposals in invalid order")
+	// ErrEvidenceNotFutureHeight is returned when the vote of a
+	// FutureHeightEvidence is not beyond the claimed chain height.
+	ErrEvidenceNotFutureHeight = errors.New("vote height is not beyond the claimed chain height")
+	// ErrEvidenceNotPrecommit is returned when precommit-only evidence is built
+	// from votes which are not precommits.
+	ErrEvidenceNotPrecommit = errors.New("votes must be precommits")
+	// ErrEvidenceUnknownValidator is returned when the accused validator is not
+	// in the validator set at the height of the evidence.
+	ErrEvidenceUnknownValidator = errors.New("not a validator")
+	// ErrEvidenceVoteExtension is returned when a vote contained in the evidence
+	// has an extension. Extensions prove nothing about equivocation and would
+	// exceed the size bound of the evidence, so they're stripped by the
+	// constructors of evidence.
+	ErrEvidenceVoteExtension = errors.New("vote extensions are not allowed in evidence")
+)
+
 //-------------------------------------------
 
 // Evidence represents any provable malicious activity by a validator.
@@ -139,6 +226,28 @@ func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
 			},
 		}
 
+		return tp, nil
+
+	case *DuplicateProposalEvidence:
+		pbevi := evi.ToProto()
+
+		tp := &tmproto.Evidence{
+			Sum: &tmproto.Evidence_DuplicateProposalEvidence{
+				DuplicateProposalEvidence: pbevi,
+			},
+		}
+
+		return tp, nil
+
+	case *FutureHeightEvidence:
+		pbevi := evi.ToProto()
+
+		tp := &tmproto.Evidence{
+			Sum: &tmproto.Evidence_FutureHeightEvidence{
+				FutureHeightEvidence: pbevi,
+			},
+		}
+
 		return tp, nil
 	default:
 		return nil, fmt.Errorf("toproto: evidence is not recognized: %T", evi)
@@ -161,19 +270,185 @@ func EvidenceFromProto(evidence *tmproto.Evidence) (Evidence, error) {
 		return PotentialAmnesiaEvidenceFromProto(evi.PotentialAmnesiaEvidence)
 	case *tmproto.Evidence_AmnesiaEvidence:
 		return AmnesiaEvidenceFromProto(evi.AmnesiaEvidence)
+	case *tmproto.Evidence_DuplicateProposalEvidence:
+		return DuplicateProposalEvidenceFromProto(evi.DuplicateProposalEvidence)
+	case *tmproto.Evidence_FutureHeightEvidence:
+		return FutureHeightEvidenceFromProto(evi.FutureHeightEvidence)
 	default:
 		return nil, errors.New("evidence is not recognized")
 	}
 }
 
+// MaxBytesForEvidence returns the maximum size of the evidence's Bytes, which
+// depends on its type. Application-defined evidence (see RegisterEvidence) has
+// no bound, for which 0 is returned.
+func MaxBytesForEvidence(ev Evidence) int64 {
+	switch ev.(type) {
+	case *DuplicateVoteEvidence, *UnknownEvidence:
+		return MaxEvidenceBytes
+	case *FutureHeightEvidence:
+		return MaxFutureHeightEvidenceBytes
+	case *DuplicateProposalEvidence:
+		return MaxDuplicateProposalEvidenceBytes
+	case *LunaticValidatorEvidence:
+		return MaxLunaticValidatorEvidenceBytes
+	case *PotentialAmnesiaEvidence:
+		return MaxPotentialAmnesiaEvidenceBytes
+	case *ConflictingHeadersEvidence:
+		return MaxConflictingHeadersEvidenceBytes
+	case *AmnesiaEvidence:
+		return MaxAmnesiaEvidenceBytes
+	default:
+		return 0
+	}
+}
+
+// validateEvidenceVote performs basic validation of a vote contained in
+// evidence, which must not have an extension (see ErrEvidenceVoteExtension).
+func validateEvidenceVote(vote *Vote) error {
+	if err := vote.ValidateBasic(); err != nil {
+		return err
+	}
+	if len(vote.Extension) > 0 {
+		return ErrEvidenceVoteExtension
+	}
+	return nil
+}
+
+// checkEvidenceSize returns an error if the evidence is bigger than
+// MaxBytesForEvidence, unless its type has no bound.
+func checkEvidenceSize(ev Evidence) error {
+	maxSize := MaxBytesForEvidence(ev)
+	if maxSize == 0 {
+		return nil
+	}
+	if size := int64(len(ev.Bytes())); size > maxSize {
+		return fmt.Errorf("%T is too big: %d bytes, max: %d", ev, size, maxSize)
+	}
+	return nil
+}
+
+// DecodeEvidence decodes the protobuf-encoded evidence (see EvidenceToProto)
+// received from an untrusted source. It never panics: input bigger than the
+// largest type of evidence is rejected before decoding, as is evidence bigger
+// than MaxBytesForEvidence after, and any panic raised while decoding is
+// converted to an error.
+func DecodeEvidence(bz []byte) (ev Evidence, err error) {
+	if int64(len(bz)) > maxEncodedEvidenceBytes {
+		return nil, fmt.Errorf("evidence is too big: %d bytes, max: %d", len(bz), maxEncodedEvidenceBytes)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ev, err = nil, fmt.Errorf("failed to decode evidence: %v", r)
+		}
+	}()
+
+	var pbev tmproto.Evidence
+	if err := pbev.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+
+	ev, err = EvidenceFromProto(&pbev)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEvidenceSize(ev); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// EvidenceFormatV1 is the version byte of evidence encoded with
+// MarshalEvidenceVersioned, followed by its protobuf encoding (see
+// EvidenceToProto).
+const EvidenceFormatV1 byte = 1
+
+// ErrUnknownEvidenceFormat is returned by UnmarshalEvidenceVersioned when the
+// version byte is not a known one.
+var ErrUnknownEvidenceFormat = errors.New("unknown evidence format")
+
+// MarshalEvidenceVersioned encodes the evidence prefixed with a version byte
+// (EvidenceFormatV1), so that it remains decodable if the encoding changes.
+func MarshalEvidenceVersioned(ev Evidence) ([]byte, error) {
+	pbev, err := EvidenceToProto(ev)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := pbev.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{EvidenceFormatV1}, bz...), nil
+}
+
+// UnmarshalEvidenceVersioned decodes evidence encoded with
+// MarshalEvidenceVersioned, dispatching on the version byte.
+func UnmarshalEvidenceVersioned(bz []byte) (Evidence, error) {
+	if len(bz) == 0 {
+		return nil, errors.New("empty evidence bytes")
+	}
+	switch bz[0] {
+	case EvidenceFormatV1:
+		return DecodeEvidence(bz[1:])
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownEvidenceFormat, bz[0])
+	}
+}
+
+// CanonicalEvidenceBytes returns the canonical encoding of the evidence (see
+// MarshalEvidenceVersioned), e.g. to re-encode evidence received from a peer
+// before forwarding it. An error is returned unless the evidence decoded from
+// it is Equal to ev and encodes to the same bytes again.
+func CanonicalEvidenceBytes(ev Evidence) ([]byte, error) {
+	bz, err := MarshalEvidenceVersioned(ev)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := UnmarshalEvidenceVersioned(bz)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode encoded evidence: %w", err)
+	}
+	if !decoded.Equal(ev) {
+		return nil, fmt.Errorf("decoded evidence %v differs from %v", decoded, ev)
+	}
+	reencoded, err := MarshalEvidenceVersioned(decoded)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(bz, reencoded) {
+		return nil, fmt.Errorf("encoding of evidence %v is not stable", ev)
+	}
+	return bz, nil
+}
+
 func init() {
 	tmjson.RegisterType(&DuplicateVoteEvidence{}, "tendermint/DuplicateVoteEvidence")
+	tmjson.RegisterType(&DuplicateProposalEvidence{}, "tendermint/DuplicateProposalEvidence")
+	tmjson.RegisterType(&FutureHeightEvidence{}, "tendermint/FutureHeightEvidence")
 	tmjson.RegisterType(&ConflictingHeadersEvidence{}, "tendermint/ConflictingHeadersEvidence")
 	tmjson.RegisterType(&LunaticValidatorEvidence{}, "tendermint/LunaticValidatorEvidence")
 	tmjson.RegisterType(&PotentialAmnesiaEvidence{}, "tendermint/PotentialAmnesiaEvidence")
 	tmjson.RegisterType(&AmnesiaEvidence{}, "tendermint/AmnesiaEvidence")
 }
 
+// RegisterEvidence registers an application-defined evidence type under the
+// given name, so that it can be JSON-encoded as Evidence (e.g. over RPC). The
+// type of prototype is registered; its value is ignored. An error is returned
+// if prototype is nil or if the name or the type is already registered.
+//
+// NOTE: the protobuf encoding (see EvidenceToProto) only supports the
+// evidence types defined in this package.
+func RegisterEvidence(name string, prototype Evidence) error {
+	if prototype == nil {
+		return errors.New("nil evidence prototype")
+	}
+	if err := tmjson.TryRegisterType(prototype, name); err != nil {
+		return fmt.Errorf("failed to register evidence %q: %w", name, err)
+	}
+	return nil
+}
+
 //-------------------------------------------
 
 // DuplicateVoteEvidence contains evidence a validator signed two conflicting
@@ -202,13 +477,89 @@ func NewDuplicateVoteEvidence(vote1, vote2 *Vote, time time.Time) *DuplicateVote
 		voteB = vote1
 	}
 	return &DuplicateVoteEvidence{
-		VoteA: voteA,
-		VoteB: voteB,
+		VoteA: voteA.withoutExtension(),
+		VoteB: voteB.withoutExtension(),
 
 		Timestamp: time,
 	}
 }
 
+// NewDuplicateVoteEvidenceFromCommits returns one DuplicateVoteEvidence for
+// every validator of valSet which signed different block IDs in two commits
+// for the same height and round. Both commits must be signed by valSet. An
+// error is returned if the commits can't be compared or if any of the
+// conflicting votes fails verification.
+//
+// The evidence time is the latest timestamp of the two votes, as the time of
+// the block at that height is not known.
+func NewDuplicateVoteEvidenceFromCommits(chainID string, c1, c2 *Commit,
+	valSet *ValidatorSet) ([]*DuplicateVoteEvidence, error) {
+	if c1 == nil || c2 == nil {
+		return nil, errors.New("nil commit")
+	}
+	if c1.Height != c2.Height || c1.Round != c2.Round {
+		return nil, fmt.Errorf("commits must be for the same height and round: %d/%d vs %d/%d",
+			c1.Height, c1.Round, c2.Height, c2.Round)
+	}
+	if valSet.Size() != c1.Size() || valSet.Size() != c2.Size() {
+		return nil, fmt.Errorf("commits must have one signature per validator (%d): %d and %d",
+			valSet.Size(), c1.Size(), c2.Size())
+	}
+
+	evList := make([]*DuplicateVoteEvidence, 0)
+	for idx, val := range valSet.Validators {
+		vote1, vote2 := c1.GetVote(int32(idx)), c2.GetVote(int32(idx))
+		if vote1 == nil || vote2 == nil || vote1.BlockID.Equals(vote2.BlockID) {
+			continue
+		}
+
+		evTime := vote1.Timestamp
+		if vote2.Timestamp.After(evTime) {
+			evTime = vote2.Timestamp
+		}
+		ev := NewDuplicateVoteEvidence(vote1, vote2, evTime)
+		if err := ev.Verify(chainID, val.PubKey); err != nil {
+			return nil, fmt.Errorf("votes of validator #%d: %w", idx, err)
+		}
+		evList = append(evList, ev)
+	}
+	return evList, nil
+}
+
+// NewPrecommitDuplicateVoteEvidence is like NewDuplicateVoteEvidence, but only
+// accepts two conflicting precommits (ErrEvidenceNotPrecommit otherwise) of
+// the validator with the given pubkey, for policies punishing only precommit
+// equivocation. Signatures aren't verified.
+//
+// The evidence time is the latest timestamp of the two votes.
+func NewPrecommitDuplicateVoteEvidence(pubKey crypto.PubKey, vote1, vote2 *Vote) (*DuplicateVoteEvidence, error) {
+	if pubKey == nil {
+		return nil, ErrEvidenceNilPubKey
+	}
+	if vote1 == nil || vote2 == nil {
+		return nil, ErrVoteNil
+	}
+	if vote1.Type != tmproto.PrecommitType || vote2.Type != tmproto.PrecommitType {
+		return nil, fmt.Errorf("%w, got %v and %v", ErrEvidenceNotPrecommit, vote1.Type, vote2.Type)
+	}
+	if !bytes.Equal(pubKey.Address(), vote1.ValidatorAddress) {
+		return nil, ErrEvidencePubKeyMismatch
+	}
+	ok, err := IsEquivocation(vote1, vote2)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrEvidenceVoteMismatch
+	}
+
+	evTime := vote1.Timestamp
+	if vote2.Timestamp.After(evTime) {
+		evTime = vote2.Timestamp
+	}
+	return NewDuplicateVoteEvidence(vote1, vote2, evTime), nil
+}
+
 // String returns a string representation of the evidence.
 func (dve *DuplicateVoteEvidence) String() string {
 	return fmt.Sprintf("DuplicateVoteEvidence{VoteA: %v, VoteB: %v, Time: %v}", dve.VoteA, dve.VoteB, dve.Timestamp)
@@ -219,9 +570,17 @@ func (dve *DuplicateVoteEvidence) Height() int64 {
 	return dve.VoteA.Height
 }
 
-// Time returns time of the latest vote.
+// Time returns the time of the evidence, i.e. Timestamp, which is set to the
+// time of the block it was committed in. If Timestamp isn't set, it falls back
+// to the time of the latest vote.
 func (dve *DuplicateVoteEvidence) Time() time.Time {
-	return dve.Timestamp
+	if !dve.Timestamp.IsZero() || dve.VoteA == nil || dve.VoteB == nil {
+		return dve.Timestamp
+	}
+	if dve.VoteB.Timestamp.After(dve.VoteA.Timestamp) {
+		return dve.VoteB.Timestamp
+	}
+	return dve.VoteA.Timestamp
 }
 
 // Address returns the address of the validator.
@@ -254,52 +613,56 @@ func (dve *DuplicateVoteEvidence) Hash() []byte {
 // Verify returns an error if the two votes aren't conflicting.
 //
 // To be conflicting, they must be from the same validator, for the same H/R/S,
-// but for different blocks.
+// but for different blocks. Votes for the same block hash, but different part
+// set headers, are conflicting too (see IsPartSetConflict).
 func (dve *DuplicateVoteEvidence) Verify(chainID string, pubKey crypto.PubKey) error {
-	// H/R/S must be the same
-	if dve.VoteA.Height != dve.VoteB.Height ||
-		dve.VoteA.Round != dve.VoteB.Round ||
-		dve.VoteA.Type != dve.VoteB.Type {
-		return fmt.Errorf("h/r/s does not match: %d/%d/%v vs %d/%d/%v",
-			dve.VoteA.Height, dve.VoteA.Round, dve.VoteA.Type,
-			dve.VoteB.Height, dve.VoteB.Round, dve.VoteB.Type)
-	}
+	return dve.VerifyVersion(chainID, pubKey, version.BlockProtocol)
+}
 
-	// Address must be the same
-	if !bytes.Equal(dve.VoteA.ValidatorAddress, dve.VoteB.ValidatorAddress) {
-		return fmt.Errorf("validator addresses do not match: %X vs %X",
-			dve.VoteA.ValidatorAddress,
-			dve.VoteB.ValidatorAddress,
-		)
+// VerifyVersion is like Verify, but checks the vote signatures against the
+// sign bytes of the given block protocol version. Use it to verify evidence of
+// votes signed under an earlier version of the protocol.
+func (dve *DuplicateVoteEvidence) VerifyVersion(chainID string, pubKey crypto.PubKey, blockVersion uint64) error {
+	// same validator and H/R/S, but different BlockIDs
+	if err := dve.verifyEquivocation(); err != nil {
+		return err
 	}
 
-	// BlockIDs must be different
-	if dve.VoteA.BlockID.Equals(dve.VoteB.BlockID) {
-		return fmt.Errorf(
-			"block IDs are the same (%v) - not a real duplicate vote",
-			dve.VoteA.BlockID,
-		)
+	if pubKey == nil {
+		return fmt.Errorf("%w for validator %X", ErrEvidenceNilPubKey, dve.VoteA.ValidatorAddress)
 	}
 
 	// pubkey must match address (this should already be true, sanity check)
 	addr := dve.VoteA.ValidatorAddress
 	if !bytes.Equal(pubKey.Address(), addr) {
-		return fmt.Errorf("address (%X) doesn't match pubkey (%v - %X)",
+		return fmt.Errorf("%w: %X vs (%v - %X)", ErrEvidencePubKeyMismatch,
 			addr, pubKey, pubKey.Address())
 	}
-	va := dve.VoteA.ToProto()
-	vb := dve.VoteB.ToProto()
+	signBytesA, err := VoteSignBytesVersion(chainID, dve.VoteA.ToProto(), blockVersion)
+	if err != nil {
+		return err
+	}
+	signBytesB, err := VoteSignBytesVersion(chainID, dve.VoteB.ToProto(), blockVersion)
+	if err != nil {
+		return err
+	}
 	// Signatures must be valid
-	if !pubKey.VerifySignature(VoteSignBytes(chainID, va), dve.VoteA.Signature) {
-		return fmt.Errorf("verifying VoteA: %w", ErrVoteInvalidSignature)
+	if !pubKey.VerifySignature(signBytesA, dve.VoteA.Signature) {
+		return fmt.Errorf("verifying VoteA: %w", ErrEvidenceInvalidSignature)
 	}
-	if !pubKey.VerifySignature(VoteSignBytes(chainID, vb), dve.VoteB.Signature) {
-		return fmt.Errorf("verifying VoteB: %w", ErrVoteInvalidSignature)
+	if !pubKey.VerifySignature(signBytesB, dve.VoteB.Signature) {
+		return fmt.Errorf("verifying VoteB: %w", ErrEvidenceInvalidSignature)
 	}
 
 	return nil
 }
 
+// IsPartSetConflict returns true if the votes are for the same block hash, but
+// different part set headers, as opposed to votes for different blocks.
+func (dve *DuplicateVoteEvidence) IsPartSetConflict() bool {
+	return IsPartSetConflict(dve.VoteA, dve.VoteB)
+}
+
 // Equal checks if two pieces of evidence are equal.
 func (dve *DuplicateVoteEvidence) Equal(ev Evidence) bool {
 	if _, ok := ev.(*DuplicateVoteEvidence); !ok {
@@ -326,7 +689,9 @@ func (dve *DuplicateVoteEvidence) Equal(ev Evidence) bool {
 	return bytes.Equal(dveHash, evHash)
 }
 
-// ValidateBasic performs basic validation.
+// ValidateBasic performs basic validation. Votes for the same block, including
+// two copies of the same vote, prove nothing and are rejected with
+// ErrEvidenceSameBlockID.
 func (dve *DuplicateVoteEvidence) ValidateBasic() error {
 	if dve == nil {
 		return errors.New("empty duplicate vote evidence")
@@ -335,15 +700,33 @@ func (dve *DuplicateVoteEvidence) ValidateBasic() error {
 	if dve.VoteA == nil || dve.VoteB == nil {
 		return fmt.Errorf("one or both of the votes are empty %v, %v", dve.VoteA, dve.VoteB)
 	}
-	if err := dve.VoteA.ValidateBasic(); err != nil {
+	if err := validateEvidenceVote(dve.VoteA); err != nil {
 		return fmt.Errorf("invalid VoteA: %w", err)
 	}
-	if err := dve.VoteB.ValidateBasic(); err != nil {
+	if err := validateEvidenceVote(dve.VoteB); err != nil {
 		return fmt.Errorf("invalid VoteB: %w", err)
 	}
+	if err := dve.verifyEquivocation(); err != nil {
+		return err
+	}
 	// Enforce Votes are lexicographically sorted on blockID
 	if strings.Compare(dve.VoteA.BlockID.Key(), dve.VoteB.BlockID.Key()) >= 0 {
-		return errors.New("duplicate votes in invalid order")
+		return ErrEvidenceVoteOrder
+	}
+	return nil
+}
+
+// verifyEquivocation returns an error unless the votes are an equivocation
+// (see IsEquivocation).
+func (dve *DuplicateVoteEvidence) verifyEquivocation() error {
+	ok, err := IsEquivocation(dve.VoteA, dve.VoteB)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %d/%d/%v vs %d/%d/%v", ErrEvidenceVoteMismatch,
+			dve.VoteA.Height, dve.VoteA.Round, dve.VoteA.Type,
+			dve.VoteB.Height, dve.VoteB.Round, dve.VoteB.Type)
 	}
 	return nil
 }
@@ -379,6 +762,353 @@ func DuplicateVoteEvidenceFromProto(pb *tmproto.DuplicateVoteEvidence) (*Duplica
 	return dve, dve.ValidateBasic()
 }
 
+//-------------------------------------------
+
+// DuplicateProposalEvidence contains evidence a validator signed two
+// conflicting proposals, i.e. proposals for the same height and round but for
+// different blocks.
+//
+// Proposals don't carry the address of the proposer, so it's part of the
+// evidence.
+type DuplicateProposalEvidence struct {
+	ProposalA        *Proposal `json:"proposal_a"`
+	ProposalB        *Proposal `json:"proposal_b"`
+	ValidatorAddress Address   `json:"validator_address"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+var _ Evidence = &DuplicateProposalEvidence{}
+
+// NewDuplicateProposalEvidence creates DuplicateProposalEvidence with right
+// ordering given two conflicting proposals signed by the validator with the
+// given address. If one of the proposals is nil, evidence returned is nil as
+// well.
+func NewDuplicateProposalEvidence(proposal1, proposal2 *Proposal, address Address,
+	time time.Time) *DuplicateProposalEvidence {
+	if proposal1 == nil || proposal2 == nil {
+		return nil
+	}
+	proposalA, proposalB := proposal1, proposal2
+	if strings.Compare(proposal1.BlockID.Key(), proposal2.BlockID.Key()) >= 0 {
+		proposalA, proposalB = proposal2, proposal1
+	}
+	return &DuplicateProposalEvidence{
+		ProposalA:        proposalA,
+		ProposalB:        proposalB,
+		ValidatorAddress: address,
+
+		Timestamp: time,
+	}
+}
+
+// String returns a string representation of the evidence.
+func (dpe *DuplicateProposalEvidence) String() string {
+	return fmt.Sprintf("DuplicateProposalEvidence{ProposalA: %v, ProposalB: %v, Address: %v, Time: %v}",
+		dpe.ProposalA, dpe.ProposalB, dpe.ValidatorAddress, dpe.Timestamp)
+}
+
+// Height returns the height this evidence refers to.
+func (dpe *DuplicateProposalEvidence) Height() int64 {
+	return dpe.ProposalA.Height
+}
+
+// Time returns the time of the evidence.
+func (dpe *DuplicateProposalEvidence) Time() time.Time {
+	return dpe.Timestamp
+}
+
+// Address returns the address of the validator.
+func (dpe *DuplicateProposalEvidence) Address() []byte {
+	return dpe.ValidatorAddress
+}
+
+// Bytes returns the proto-encoded evidence as a byte array.
+func (dpe *DuplicateProposalEvidence) Bytes() []byte {
+	pbe := dpe.ToProto()
+	bz, err := pbe.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// Hash returns the hash of the evidence.
+func (dpe *DuplicateProposalEvidence) Hash() []byte {
+	return tmhash.Sum(dpe.Bytes())
+}
+
+// Verify returns an error if the two proposals aren't conflicting or weren't
+// both signed by pubKey.
+func (dpe *DuplicateProposalEvidence) Verify(chainID string, pubKey crypto.PubKey) error {
+	if err := dpe.verifyConflicting(); err != nil {
+		return err
+	}
+
+	if pubKey == nil {
+		return fmt.Errorf("%w for validator %X", ErrEvidenceNilPubKey, dpe.ValidatorAddress)
+	}
+	if !bytes.Equal(pubKey.Address(), dpe.ValidatorAddress) {
+		return fmt.Errorf("%w: %X vs (%v - %X)", ErrEvidencePubKeyMismatch,
+			dpe.ValidatorAddress, pubKey, pubKey.Address())
+	}
+
+	// Signatures must be valid
+	if err := dpe.ProposalA.Verify(chainID, pubKey); err != nil {
+		return fmt.Errorf("verifying ProposalA: %w", ErrEvidenceInvalidSignature)
+	}
+	if err := dpe.ProposalB.Verify(chainID, pubKey); err != nil {
+		return fmt.Errorf("verifying ProposalB: %w", ErrEvidenceInvalidSignature)
+	}
+
+	return nil
+}
+
+// verifyConflicting returns an error if the proposals are not for the same
+// H/R or are for the same block.
+func (dpe *DuplicateProposalEvidence) verifyConflicting() error {
+	if dpe.ProposalA.Height != dpe.ProposalB.Height ||
+		dpe.ProposalA.Round != dpe.ProposalB.Round {
+		return fmt.Errorf("%w: %d/%d vs %d/%d", ErrEvidenceProposalMismatch,
+			dpe.ProposalA.Height, dpe.ProposalA.Round,
+			dpe.ProposalB.Height, dpe.ProposalB.Round)
+	}
+
+	if dpe.ProposalA.BlockID.Equals(dpe.ProposalB.BlockID) {
+		return fmt.Errorf(
+			"%w (%v) - not a real duplicate proposal", ErrEvidenceSameBlockID,
+			dpe.ProposalA.BlockID,
+		)
+	}
+
+	return nil
+}
+
+// Equal checks if two pieces of evidence are equal.
+func (dpe *DuplicateProposalEvidence) Equal(ev Evidence) bool {
+	if _, ok := ev.(*DuplicateProposalEvidence); !ok {
+		return false
+	}
+
+	// just check their hashes
+	return bytes.Equal(dpe.Hash(), ev.Hash())
+}
+
+// ValidateBasic performs basic validation.
+func (dpe *DuplicateProposalEvidence) ValidateBasic() error {
+	if dpe == nil {
+		return errors.New("empty duplicate proposal evidence")
+	}
+
+	if dpe.ProposalA == nil || dpe.ProposalB == nil {
+		return fmt.Errorf("one or both of the proposals are empty %v, %v", dpe.ProposalA, dpe.ProposalB)
+	}
+	if err := dpe.ProposalA.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid ProposalA: %w", err)
+	}
+	if err := dpe.ProposalB.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid ProposalB: %w", err)
+	}
+	if len(dpe.ValidatorAddress) != crypto.AddressSize {
+		return fmt.Errorf("expected ValidatorAddress size to be %d bytes, got %d bytes",
+			crypto.AddressSize,
+			len(dpe.ValidatorAddress),
+		)
+	}
+	if err := dpe.verifyConflicting(); err != nil {
+		return err
+	}
+	// Enforce Proposals are lexicographically sorted on blockID
+	if strings.Compare(dpe.ProposalA.BlockID.Key(), dpe.ProposalB.BlockID.Key()) >= 0 {
+		return ErrEvidenceProposalOrder
+	}
+	return nil
+}
+
+func (dpe *DuplicateProposalEvidence) ToProto() *tmproto.DuplicateProposalEvidence {
+	tp := tmproto.DuplicateProposalEvidence{
+		ProposalA:        dpe.ProposalA.ToProto(),
+		ProposalB:        dpe.ProposalB.ToProto(),
+		ValidatorAddress: dpe.ValidatorAddress,
+		Timestamp:        dpe.Timestamp,
+	}
+	return &tp
+}
+
+func DuplicateProposalEvidenceFromProto(pb *tmproto.DuplicateProposalEvidence) (*DuplicateProposalEvidence, error) {
+	if pb == nil {
+		return nil, errors.New("nil duplicate proposal evidence")
+	}
+
+	pA, err := ProposalFromProto(pb.ProposalA)
+	if err != nil {
+		return nil, err
+	}
+
+	pB, err := ProposalFromProto(pb.ProposalB)
+	if err != nil {
+		return nil, err
+	}
+
+	dpe := &DuplicateProposalEvidence{
+		ProposalA:        pA,
+		ProposalB:        pB,
+		ValidatorAddress: pb.ValidatorAddress,
+		Timestamp:        pb.Timestamp,
+	}
+
+	return dpe, dpe.ValidateBasic()
+}
+
+//-------------------------------------------
+
+// FutureHeightEvidence contains evidence a validator signed a vote for a
+// height beyond the chain height (ClaimedHeight) at the time, which indicates
+// misbehaviour or a compromised key.
+type FutureHeightEvidence struct {
+	Vote          *Vote `json:"vote"`
+	ClaimedHeight int64 `json:"claimed_height"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+var _ Evidence = &FutureHeightEvidence{}
+
+// NewFutureHeightEvidence creates FutureHeightEvidence for the vote, signed
+// while the chain was at claimedHeight. If the vote is nil, evidence returned
+// is nil as well.
+func NewFutureHeightEvidence(vote *Vote, claimedHeight int64, time time.Time) *FutureHeightEvidence {
+	if vote == nil {
+		return nil
+	}
+	return &FutureHeightEvidence{
+		Vote:          vote.withoutExtension(),
+		ClaimedHeight: claimedHeight,
+
+		Timestamp: time,
+	}
+}
+
+// String returns a string representation of the evidence.
+func (fhe *FutureHeightEvidence) String() string {
+	return fmt.Sprintf("FutureHeightEvidence{Vote: %v, ClaimedHeight: %d, Time: %v}",
+		fhe.Vote, fhe.ClaimedHeight, fhe.Timestamp)
+}
+
+// Height returns the height of the vote.
+func (fhe *FutureHeightEvidence) Height() int64 {
+	return fhe.Vote.Height
+}
+
+// Time returns the time of the evidence.
+func (fhe *FutureHeightEvidence) Time() time.Time {
+	return fhe.Timestamp
+}
+
+// Address returns the address of the validator.
+func (fhe *FutureHeightEvidence) Address() []byte {
+	return fhe.Vote.ValidatorAddress
+}
+
+// Bytes returns the proto-encoded evidence as a byte array.
+func (fhe *FutureHeightEvidence) Bytes() []byte {
+	pbe := fhe.ToProto()
+	bz, err := pbe.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// Hash returns the hash of the evidence.
+func (fhe *FutureHeightEvidence) Hash() []byte {
+	return tmhash.Sum(fhe.Bytes())
+}
+
+// Verify returns an error if the vote is not beyond the claimed height or
+// wasn't signed by pubKey.
+func (fhe *FutureHeightEvidence) Verify(chainID string, pubKey crypto.PubKey) error {
+	if fhe.Vote.Height <= fhe.ClaimedHeight {
+		return fmt.Errorf("%w: %d <= %d", ErrEvidenceNotFutureHeight, fhe.Vote.Height, fhe.ClaimedHeight)
+	}
+
+	if pubKey == nil {
+		return fmt.Errorf("%w for validator %X", ErrEvidenceNilPubKey, fhe.Vote.ValidatorAddress)
+	}
+	if !bytes.Equal(pubKey.Address(), fhe.Vote.ValidatorAddress) {
+		return fmt.Errorf("%w: %X vs (%v - %X)", ErrEvidencePubKeyMismatch,
+			fhe.Vote.ValidatorAddress, pubKey, pubKey.Address())
+	}
+
+	// Signature must be valid
+	if !pubKey.VerifySignature(VoteSignBytes(chainID, fhe.Vote.ToProto()), fhe.Vote.Signature) {
+		return fmt.Errorf("verifying Vote: %w", ErrEvidenceInvalidSignature)
+	}
+
+	return nil
+}
+
+// Equal checks if two pieces of evidence are equal.
+func (fhe *FutureHeightEvidence) Equal(ev Evidence) bool {
+	if _, ok := ev.(*FutureHeightEvidence); !ok {
+		return false
+	}
+
+	// just check their hashes
+	return bytes.Equal(fhe.Hash(), ev.Hash())
+}
+
+// ValidateBasic performs basic validation.
+func (fhe *FutureHeightEvidence) ValidateBasic() error {
+	if fhe == nil {
+		return errors.New("empty future height evidence")
+	}
+
+	if fhe.Vote == nil {
+		return errors.New("empty vote")
+	}
+	if err := validateEvidenceVote(fhe.Vote); err != nil {
+		return fmt.Errorf("invalid Vote: %w", err)
+	}
+	if fhe.ClaimedHeight < 0 {
+		return errors.New("negative ClaimedHeight")
+	}
+	if fhe.Vote.Height <= fhe.ClaimedHeight {
+		return fmt.Errorf("%w: %d <= %d", ErrEvidenceNotFutureHeight, fhe.Vote.Height, fhe.ClaimedHeight)
+	}
+	return nil
+}
+
+func (fhe *FutureHeightEvidence) ToProto() *tmproto.FutureHeightEvidence {
+	tp := tmproto.FutureHeightEvidence{
+		Vote:          fhe.Vote.ToProto(),
+		ClaimedHeight: fhe.ClaimedHeight,
+		Timestamp:     fhe.Timestamp,
+	}
+	return &tp
+}
+
+func FutureHeightEvidenceFromProto(pb *tmproto.FutureHeightEvidence) (*FutureHeightEvidence, error) {
+	if pb == nil {
+		return nil, errors.New("nil future height evidence")
+	}
+
+	vote, err := VoteFromProto(pb.Vote)
+	if err != nil {
+		return nil, err
+	}
+
+	fhe := &FutureHeightEvidence{
+		Vote:          vote,
+		ClaimedHeight: pb.ClaimedHeight,
+		Timestamp:     pb.Timestamp,
+	}
+
+	return fhe, fhe.ValidateBasic()
+}
+
 // ConflictingHeadersEvidence is primarily used by the light client when it
 // observes two conflicting headers, both having 1/3+ of the voting power of
 // the currently trusted validator set.
@@ -415,20 +1145,14 @@ func (ev *ConflictingHeadersEvidence) Split(committedHeader *Header, valSet *Val
 	// LastResultsHash in alternativeHeader are different (incorrect application
 	// state transition), then it is a lunatic misbehavior => immediately
 	// slashable (#F5).
-	var invalidField string
-	switch {
-	case !bytes.Equal(committedHeader.ValidatorsHash, alternativeHeader.ValidatorsHash):
-		invalidField = "ValidatorsHash"
-	case !bytes.Equal(committedHeader.NextValidatorsHash, alternativeHeader.NextValidatorsHash):
-		invalidField = "NextValidatorsHash"
-	case !bytes.Equal(committedHeader.ConsensusHash, alternativeHeader.ConsensusHash):
-		invalidField = "ConsensusHash"
-	case !bytes.Equal(committedHeader.AppHash, alternativeHeader.AppHash):
-		invalidField = "AppHash"
-	case !bytes.Equal(committedHeader.LastResultsHash, alternativeHeader.LastResultsHash):
-		invalidField = "LastResultsHash"
-	}
-	if invalidField != "" {
+	var invalidFields []string
+	for _, field := range lunaticHeaderFields {
+		if !bytes.Equal(lunaticHeaderFieldValue(committedHeader, field),
+			lunaticHeaderFieldValue(alternativeHeader.Header, field)) {
+			invalidFields = append(invalidFields, field)
+		}
+	}
+	if len(invalidFields) > 0 {
 		for i, sig := range alternativeHeader.Commit.Signatures {
 			if sig.Absent() {
 				continue
@@ -436,7 +1160,7 @@ func (ev *ConflictingHeadersEvidence) Split(committedHeader *Header, valSet *Val
 			evList = append(evList, NewLunaticValidatorEvidence(
 				alternativeHeader.Header,
 				alternativeHeader.Commit.GetVote(int32(i)),
-				invalidField,
+				invalidFields,
 				committedHeader.Time, //take the time of our own trusted header
 			))
 		}
@@ -662,27 +1386,86 @@ func ConflictingHeadersEvidenceFromProto(pb *tmproto.ConflictingHeadersEvidence)
 //-------------------------------------------
 
 type LunaticValidatorEvidence struct {
-	Header             *Header `json:"header"`
-	Vote               *Vote   `json:"vote"`
-	InvalidHeaderField string  `json:"invalid_header_field"`
+	Header              *Header  `json:"header"`
+	Vote                *Vote    `json:"vote"`
+	InvalidHeaderFields []string `json:"invalid_header_fields"`
 
 	Timestamp time.Time `json:"timestamp"`
 }
 
 var _ Evidence = &LunaticValidatorEvidence{}
 
+// lunaticHeaderFields are the header fields which can be reported as invalid by
+// LunaticValidatorEvidence, in the order they are checked.
+var lunaticHeaderFields = []string{
+	ValidatorsHashField,
+	NextValidatorsHashField,
+	ConsensusHashField,
+	AppHashField,
+	LastResultsHashField,
+}
+
+// lunaticHeaderFieldValue returns the value of the given header field, or nil
+// if the field can't be reported by LunaticValidatorEvidence.
+func lunaticHeaderFieldValue(h *Header, field string) []byte {
+	switch field {
+	case ValidatorsHashField:
+		return h.ValidatorsHash
+	case NextValidatorsHashField:
+		return h.NextValidatorsHash
+	case ConsensusHashField:
+		return h.ConsensusHash
+	case AppHashField:
+		return h.AppHash
+	case LastResultsHashField:
+		return h.LastResultsHash
+	default:
+		return nil
+	}
+}
+
 // NewLunaticValidatorEvidence creates a new instance of the respective evidence
 func NewLunaticValidatorEvidence(header *Header,
-	vote *Vote, invalidHeaderField string, time time.Time) *LunaticValidatorEvidence {
+	vote *Vote, invalidHeaderFields []string, time time.Time) *LunaticValidatorEvidence {
 	return &LunaticValidatorEvidence{
-		Header:             header,
-		Vote:               vote,
-		InvalidHeaderField: invalidHeaderField,
+		Header:              header,
+		Vote:                vote.withoutExtension(),
+		InvalidHeaderFields: invalidHeaderFields,
 
 		Timestamp: time,
 	}
 }
 
+// lunaticValidatorEvidenceJSON is the JSON representation of
+// LunaticValidatorEvidence, which also accepts the invalid_header_field of
+// evidence encoded before several fields could be reported.
+type lunaticValidatorEvidenceJSON struct {
+	Header              *Header  `json:"header"`
+	Vote                *Vote    `json:"vote"`
+	InvalidHeaderFields []string `json:"invalid_header_fields"`
+	InvalidHeaderField  string   `json:"invalid_header_field"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *LunaticValidatorEvidence) UnmarshalJSON(bz []byte) error {
+	var ej lunaticValidatorEvidenceJSON
+	if err := tmjson.Unmarshal(bz, &ej); err != nil {
+		return err
+	}
+	if len(ej.InvalidHeaderFields) == 0 && ej.InvalidHeaderField != "" {
+		ej.InvalidHeaderFields = []string{ej.InvalidHeaderField}
+	}
+	*e = LunaticValidatorEvidence{
+		Header:              ej.Header,
+		Vote:                ej.Vote,
+		InvalidHeaderFields: ej.InvalidHeaderFields,
+		Timestamp:           ej.Timestamp,
+	}
+	return nil
+}
+
 func (e *LunaticValidatorEvidence) Height() int64 {
 	return e.Header.Height
 }
@@ -716,8 +1499,8 @@ func (e *LunaticValidatorEvidence) Bytes() []byte {
 
 func (e *LunaticValidatorEvidence) Verify(chainID string, pubKey crypto.PubKey) error {
 	// chainID must be the same
-	if chainID != e.Header.ChainID {
-		return fmt.Errorf("chainID do not match: %s vs %s",
+	if subtle.ConstantTimeCompare([]byte(chainID), []byte(e.Header.ChainID)) != 1 {
+		return fmt.Errorf("%w: %s vs %s", ErrEvidenceWrongChainID,
 			chainID,
 			e.Header.ChainID,
 		)
@@ -725,7 +1508,7 @@ func (e *LunaticValidatorEvidence) Verify(chainID string, pubKey crypto.PubKey)
 
 	v := e.Vote.ToProto()
 	if !pubKey.VerifySignature(VoteSignBytes(chainID, v), e.Vote.Signature) {
-		return errors.New("invalid signature")
+		return ErrEvidenceInvalidSignature
 	}
 
 	return nil
@@ -756,7 +1539,7 @@ func (e *LunaticValidatorEvidence) ValidateBasic() error {
 		return fmt.Errorf("invalid header: %v", err)
 	}
 
-	if err := e.Vote.ValidateBasic(); err != nil {
+	if err := validateEvidenceVote(e.Vote); err != nil {
 		return fmt.Errorf("invalid signature: %v", err)
 	}
 
@@ -771,11 +1554,18 @@ func (e *LunaticValidatorEvidence) ValidateBasic() error {
 		)
 	}
 
-	switch e.InvalidHeaderField {
-	case "ValidatorsHash", "NextValidatorsHash", "ConsensusHash", "AppHash", "LastResultsHash":
-		break
-	default:
-		return errors.New("unknown invalid header field")
+	if len(e.InvalidHeaderFields) == 0 {
+		return errors.New("no invalid header fields")
+	}
+	for i, field := range e.InvalidHeaderFields {
+		if !isLunaticHeaderField(field) {
+			return fmt.Errorf("unknown invalid header field: %q", field)
+		}
+		for _, other := range e.InvalidHeaderFields[i+1:] {
+			if field == other {
+				return fmt.Errorf("duplicate invalid header field: %q", field)
+			}
+		}
 	}
 
 	if !bytes.Equal(e.Header.Hash(), e.Vote.BlockID.Hash) {
@@ -790,7 +1580,7 @@ func (e *LunaticValidatorEvidence) ValidateBasic() error {
 
 func (e *LunaticValidatorEvidence) String() string {
 	return fmt.Sprintf("LunaticValidatorEvidence{%X voted for %d/%X, which contains invalid %s}",
-		e.Vote.ValidatorAddress, e.Header.Height, e.Header.Hash(), e.InvalidHeaderField)
+		e.Vote.ValidatorAddress, e.Header.Height, e.Header.Hash(), strings.Join(e.InvalidHeaderFields, ", "))
 }
 
 func (e *LunaticValidatorEvidence) VerifyHeader(committedHeader *Header) error {
@@ -802,43 +1592,41 @@ func (e *LunaticValidatorEvidence) VerifyHeader(committedHeader *Header) error {
 		return errors.New("committed header is nil")
 	}
 
-	switch e.InvalidHeaderField {
-	case ValidatorsHashField:
-		if bytes.Equal(committedHeader.ValidatorsHash, e.Header.ValidatorsHash) {
-			return matchErr(ValidatorsHashField)
-		}
-	case NextValidatorsHashField:
-		if bytes.Equal(committedHeader.NextValidatorsHash, e.Header.NextValidatorsHash) {
-			return matchErr(NextValidatorsHashField)
-		}
-	case ConsensusHashField:
-		if bytes.Equal(committedHeader.ConsensusHash, e.Header.ConsensusHash) {
-			return matchErr(ConsensusHashField)
-		}
-	case AppHashField:
-		if bytes.Equal(committedHeader.AppHash, e.Header.AppHash) {
-			return matchErr(AppHashField)
+	if len(e.InvalidHeaderFields) == 0 {
+		return errors.New("no InvalidHeaderFields")
+	}
+
+	// every listed field must differ from the committed header
+	for _, field := range e.InvalidHeaderFields {
+		if !isLunaticHeaderField(field) {
+			return fmt.Errorf("unknown InvalidHeaderField: %q", field)
 		}
-	case LastResultsHashField:
-		if bytes.Equal(committedHeader.LastResultsHash, e.Header.LastResultsHash) {
-			return matchErr(LastResultsHashField)
+		if bytes.Equal(lunaticHeaderFieldValue(committedHeader, field), lunaticHeaderFieldValue(e.Header, field)) {
+			return matchErr(field)
 		}
-	default:
-		return errors.New("unknown InvalidHeaderField")
 	}
 
 	return nil
 }
 
+func isLunaticHeaderField(field string) bool {
+	for _, f := range lunaticHeaderFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *LunaticValidatorEvidence) ToProto() *tmproto.LunaticValidatorEvidence {
 	h := e.Header.ToProto()
 	v := e.Vote.ToProto()
 
 	tp := &tmproto.LunaticValidatorEvidence{
-		Header:             h,
-		Vote:               v,
-		InvalidHeaderField: e.InvalidHeaderField,
-		Timestamp:          e.Timestamp,
+		Header:              h,
+		Vote:                v,
+		InvalidHeaderFields: e.InvalidHeaderFields,
+		Timestamp:           e.Timestamp,
 	}
 
 	return tp
@@ -859,11 +1647,17 @@ func LunaticValidatorEvidenceFromProto(pb *tmproto.LunaticValidatorEvidence) (*L
 		return nil, err
 	}
 
+	invalidHeaderFields := pb.InvalidHeaderFields
+	if len(invalidHeaderFields) == 0 && pb.InvalidHeaderField != "" {
+		// evidence encoded before several fields could be reported
+		invalidHeaderFields = []string{pb.InvalidHeaderField}
+	}
+
 	tp := LunaticValidatorEvidence{
-		Header:             &h,
-		Vote:               v,
-		InvalidHeaderField: pb.InvalidHeaderField,
-		Timestamp:          pb.Timestamp,
+		Header:              &h,
+		Vote:                v,
+		InvalidHeaderFields: invalidHeaderFields,
+		Timestamp:           pb.Timestamp,
 	}
 
 	return &tp, tp.ValidateBasic()
@@ -892,10 +1686,10 @@ func NewPotentialAmnesiaEvidence(voteA, voteB *Vote, time time.Time) *PotentialA
 		return nil
 	}
 
-	if voteA.Timestamp.Before(voteB.Timestamp) {
-		return &PotentialAmnesiaEvidence{VoteA: voteA, VoteB: voteB, Timestamp: time}
+	if !voteA.Timestamp.Before(voteB.Timestamp) {
+		voteA, voteB = voteB, voteA
 	}
-	return &PotentialAmnesiaEvidence{VoteA: voteB, VoteB: voteA, Timestamp: time}
+	return &PotentialAmnesiaEvidence{VoteA: voteA.withoutExtension(), VoteB: voteB.withoutExtension(), Timestamp: time}
 }
 
 func (e *PotentialAmnesiaEvidence) Height() int64 {
@@ -940,7 +1734,7 @@ func (e *PotentialAmnesiaEvidence) Verify(chainID string, pubKey crypto.PubKey)
 	// pubkey must match address (this should already be true, sanity check)
 	addr := e.VoteA.ValidatorAddress
 	if !bytes.Equal(pubKey.Address(), addr) {
-		return fmt.Errorf("address (%X) doesn't match pubkey (%v - %X)",
+		return fmt.Errorf("%w: %X vs (%v - %X)", ErrEvidencePubKeyMismatch,
 			addr, pubKey, pubKey.Address())
 	}
 
@@ -949,10 +1743,10 @@ func (e *PotentialAmnesiaEvidence) Verify(chainID string, pubKey crypto.PubKey)
 
 	// Signatures must be valid
 	if !pubKey.VerifySignature(VoteSignBytes(chainID, va), e.VoteA.Signature) {
-		return fmt.Errorf("verifying VoteA: %w", ErrVoteInvalidSignature)
+		return fmt.Errorf("verifying VoteA: %w", ErrEvidenceInvalidSignature)
 	}
 	if !pubKey.VerifySignature(VoteSignBytes(chainID, vb), e.VoteB.Signature) {
-		return fmt.Errorf("verifying VoteB: %w", ErrVoteInvalidSignature)
+		return fmt.Errorf("verifying VoteB: %w", ErrEvidenceInvalidSignature)
 	}
 
 	return nil
@@ -975,10 +1769,10 @@ func (e *PotentialAmnesiaEvidence) ValidateBasic() error {
 		return fmt.Errorf("one or both of the votes are empty %v, %v", e.VoteA, e.VoteB)
 	}
 
-	if err := e.VoteA.ValidateBasic(); err != nil {
+	if err := validateEvidenceVote(e.VoteA); err != nil {
 		return fmt.Errorf("invalid VoteA: %v", err)
 	}
-	if err := e.VoteB.ValidateBasic(); err != nil {
+	if err := validateEvidenceVote(e.VoteB); err != nil {
 		return fmt.Errorf("invalid VoteB: %v", err)
 	}
 
@@ -1489,6 +2283,69 @@ func (evl EvidenceList) Hash() []byte {
 	return merkle.HashFromByteSlices(evidenceBzs)
 }
 
+// Proof returns the evidence at index i together with a merkle proof of its
+// inclusion, verifiable against Hash (i.e. the header's EvidenceHash) using
+// the evidence's Bytes as the leaf.
+// Panics if i < 0 or i >= len(evl)
+func (evl EvidenceList) Proof(i int) (Evidence, *merkle.Proof) {
+	evidenceBzs := make([][]byte, len(evl))
+	for j := 0; j < len(evl); j++ {
+		evidenceBzs[j] = evl[j].Bytes()
+	}
+	_, proofs := merkle.ProofsFromByteSlices(evidenceBzs)
+	return evl[i], proofs[i]
+}
+
+// ByteSize returns the total size of the evidence in the list, each item
+// being counted as its Bytes prefixed with their length (uvarint).
+func (evl EvidenceList) ByteSize() int64 {
+	var size int64
+	for _, ev := range evl {
+		size += evidenceByteSize(ev)
+	}
+	return size
+}
+
+// SplitByByteBudget greedily packs the evidence, in order, into sublists
+// whose ByteSize doesn't exceed maxBytes. An evidence is never split nor
+// dropped: one which alone exceeds maxBytes is put into its own sublist.
+func (evl EvidenceList) SplitByByteBudget(maxBytes int64) []EvidenceList {
+	var (
+		pages    []EvidenceList
+		page     EvidenceList
+		pageSize int64
+	)
+	for _, ev := range evl {
+		size := evidenceByteSize(ev)
+		if len(page) > 0 && pageSize+size > maxBytes {
+			pages = append(pages, page)
+			page, pageSize = nil, 0
+		}
+		page = append(page, ev)
+		pageSize += size
+	}
+	if len(page) > 0 {
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+func evidenceByteSize(ev Evidence) int64 {
+	var buf [binary.MaxVarintLen64]byte
+	n := len(ev.Bytes())
+	return int64(n + binary.PutUvarint(buf[:], uint64(n)))
+}
+
+// SortByPriority sorts the list in place by decreasing powerOf (e.g. the
+// voting power of the accused validator, see EvidenceImpactScore), so that
+// the most impactful evidence comes first. The sort is stable: evidence of
+// equal power keeps its relative order.
+func (evl EvidenceList) SortByPriority(powerOf func(Evidence) int64) {
+	sort.SliceStable(evl, func(i, j int) bool {
+		return powerOf(evl[i]) > powerOf(evl[j])
+	})
+}
+
 func (evl EvidenceList) String() string {
 	s := ""
 	for _, e := range evl {
@@ -1507,6 +2364,76 @@ func (evl EvidenceList) Has(evidence Evidence) bool {
 	return false
 }
 
+// Dedup returns a copy of the list without duplicates, i.e. evidence with the
+// same Hash as an earlier one, keeping the first occurrence. The votes of
+// DuplicateVoteEvidence are put in canonical order (see
+// NewDuplicateVoteEvidence) before hashing, so that the same equivocation
+// included with its votes swapped is also removed.
+func (evl EvidenceList) Dedup() EvidenceList {
+	var (
+		seen  = make(map[string]struct{}, len(evl))
+		dedup = make(EvidenceList, 0, len(evl))
+	)
+	for _, ev := range evl {
+		key := ev
+		if dve, ok := ev.(*DuplicateVoteEvidence); ok {
+			if canonical := NewDuplicateVoteEvidence(dve.VoteA, dve.VoteB, dve.Timestamp); canonical != nil {
+				key = canonical
+			}
+		}
+		hash := string(key.Hash())
+		if _, ok := seen[hash]; ok {
+			continue
+		}
+		seen[hash] = struct{}{}
+		dedup = append(dedup, ev)
+	}
+	return dedup
+}
+
+// ValidateBasic returns an error if any evidence in the list is bigger than
+// MaxBytesForEvidence or fails ValidateBasic.
+func (evl EvidenceList) ValidateBasic() error {
+	for i, ev := range evl {
+		if err := checkEvidenceSize(ev); err != nil {
+			return fmt.Errorf("evidence #%d: %w", i, err)
+		}
+		if err := ev.ValidateBasic(); err != nil {
+			return fmt.Errorf("evidence #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ValidateHeights returns an error if any evidence in the list has a
+// non-positive height or one above blockHeight, i.e. is for a future block.
+func (evl EvidenceList) ValidateHeights(blockHeight int64) error {
+	for i, ev := range evl {
+		if h := ev.Height(); h <= 0 || h > blockHeight {
+			return fmt.Errorf("evidence #%d has invalid height %d (block height: %d)", i, h, blockHeight)
+		}
+	}
+	return nil
+}
+
+// EvidenceImpactScore returns a score used to prioritize evidence for
+// inclusion in a block: the voting power, in vals, of the validator implicated
+// by the evidence. Punishing a validator with more voting power has a bigger
+// impact, so evidence with a higher score should be preferred.
+//
+// Composite evidence must be split first and scores 0, as does evidence
+// against a validator not in vals.
+func EvidenceImpactScore(ev Evidence, vals *ValidatorSet) int64 {
+	if _, ok := ev.(CompositeEvidence); ok {
+		return 0
+	}
+	_, val := vals.GetByAddress(ev.Address())
+	if val == nil {
+		return 0
+	}
+	return val.VotingPower
+}
+
 //-------------------------------------------- MOCKING --------------------------------------
 
 // unstable - use only for testing
@@ -1531,6 +2458,46 @@ func NewMockDuplicateVoteEvidenceWithValidator(height int64, time time.Time,
 	return NewDuplicateVoteEvidence(voteA, voteB, time)
 }
 
+// DuplicateVoteEvidenceFromFixture returns DuplicateVoteEvidence built from a
+// fixed key, time and block IDs, so that its Hash is deterministic. It's meant
+// for test vectors which catch unintended changes to the evidence encoding.
+func DuplicateVoteEvidenceFromFixture() *DuplicateVoteEvidence {
+	const (
+		chainID = "fixture-chain-id"
+		height  = 10
+	)
+	var (
+		pv      = NewMockPVFromSeed(1)
+		evTime  = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		blockID = func(seed string) BlockID {
+			return BlockID{
+				Hash: tmhash.Sum([]byte(seed)),
+				PartSetHeader: PartSetHeader{
+					Total: 1,
+					Hash:  tmhash.Sum([]byte(seed + " parts")),
+				},
+			}
+		}
+	)
+	pubKey, err := pv.GetPubKey()
+	if err != nil {
+		panic(err)
+	}
+	voteA := makeMockVote(height, 0, 0, pubKey.Address(), blockID("block a"), evTime)
+	vA := voteA.ToProto()
+	if err := pv.SignVote(chainID, vA); err != nil {
+		panic(err)
+	}
+	voteA.Signature = vA.Signature
+	voteB := makeMockVote(height, 0, 0, pubKey.Address(), blockID("block b"), evTime)
+	vB := voteB.ToProto()
+	if err := pv.SignVote(chainID, vB); err != nil {
+		panic(err)
+	}
+	voteB.Signature = vB.Signature
+	return NewDuplicateVoteEvidence(voteA, voteB, evTime)
+}
+
 func makeMockVote(height int64, round, index int32, addr Address,
 	blockID BlockID, time time.Time) *Vote {
 	return &Vote{
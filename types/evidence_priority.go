@@ -0,0 +1,36 @@
+package types
+
+// evidencePriorityHorizon is the age (in blocks) beyond which evidence gets
+// no recency bonus, matching the default EvidenceParams.MaxAgeNumBlocks.
+const evidencePriorityHorizon = int64(100000)
+
+// evidenceTypeWeight returns the weight of the evidence type: attacks on
+// light clients first, then the other kinds of equivocation.
+func evidenceTypeWeight(ev Evidence) int64 {
+	switch ev.(type) {
+	case *LunaticValidatorEvidence, *ConflictingHeadersEvidence:
+		return 3
+	case *AmnesiaEvidence, *PotentialAmnesiaEvidence, *DuplicateProposalEvidence:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// EvidencePriority returns the priority with which the evidence should be
+// gossiped at the current height: the higher, the sooner. It's the weight of
+// the evidence type (lunatic and conflicting headers evidence outweigh
+// duplicate votes) multiplied by its recency, so that among evidence of the
+// same type the most recent comes first, and evidence older than
+// evidencePriorityHorizon blocks is only ordered by type.
+func EvidencePriority(ev Evidence, currentHeight int64) int {
+	age := currentHeight - ev.Height()
+	if age < 0 {
+		age = 0
+	}
+	recency := evidencePriorityHorizon - age
+	if recency < 1 {
+		recency = 1
+	}
+	return int(evidenceTypeWeight(ev) * recency)
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvidencePriority(t *testing.T) {
+	const currentHeight = int64(1000)
+	var (
+		lunatic = &LunaticValidatorEvidence{Header: &Header{Height: currentHeight - 1}}
+		oldDVE  = NewMockDuplicateVoteEvidence(currentHeight-900, defaultVoteTime, "mychain")
+		newDVE  = NewMockDuplicateVoteEvidence(currentHeight-10, defaultVoteTime, "mychain")
+	)
+
+	// a recent lunatic evidence outranks an old duplicate vote
+	assert.Greater(t, EvidencePriority(lunatic, currentHeight), EvidencePriority(oldDVE, currentHeight))
+	// even a recent one
+	assert.Greater(t, EvidencePriority(lunatic, currentHeight), EvidencePriority(newDVE, currentHeight))
+
+	// evidence of the same type is ordered by height
+	assert.Greater(t, EvidencePriority(newDVE, currentHeight), EvidencePriority(oldDVE, currentHeight))
+
+	// evidence beyond the horizon is only ordered by type
+	veryOld := currentHeight + 2*evidencePriorityHorizon
+	assert.Equal(t, EvidencePriority(newDVE, veryOld), EvidencePriority(oldDVE, veryOld))
+	assert.Greater(t, EvidencePriority(lunatic, veryOld), EvidencePriority(oldDVE, veryOld))
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"sort"
+)
+
+// EvidenceReport groups evidence by height, so that all misbehavior at a
+// given height (e.g. every equivocation) can be processed together.
+//
+// EvidenceReport is not safe for concurrent use.
+type EvidenceReport struct {
+	hashes   map[string]struct{}
+	byHeight map[int64]EvidenceList
+}
+
+// NewEvidenceReport returns an empty EvidenceReport.
+func NewEvidenceReport() *EvidenceReport {
+	return &EvidenceReport{
+		hashes:   make(map[string]struct{}),
+		byHeight: make(map[int64]EvidenceList),
+	}
+}
+
+// Add adds the evidence to the report. It returns false if evidence with the
+// same hash has already been added.
+func (er *EvidenceReport) Add(ev Evidence) bool {
+	hash := string(ev.Hash())
+	if _, ok := er.hashes[hash]; ok {
+		return false
+	}
+	er.hashes[hash] = struct{}{}
+	er.byHeight[ev.Height()] = append(er.byHeight[ev.Height()], ev)
+	return true
+}
+
+// ByHeight returns the evidence at the given height in the order it was
+// added, or nil if there is none.
+func (er *EvidenceReport) ByHeight(height int64) []Evidence {
+	evl := er.byHeight[height]
+	if len(evl) == 0 {
+		return nil
+	}
+	list := make([]Evidence, len(evl))
+	copy(list, evl)
+	return list
+}
+
+// Heights returns the heights with evidence in ascending order.
+func (er *EvidenceReport) Heights() []int64 {
+	heights := make([]int64, 0, len(er.byHeight))
+	for h := range er.byHeight {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+// Len returns the number of pieces of evidence in the report.
+func (er *EvidenceReport) Len() int {
+	return len(er.hashes)
+}
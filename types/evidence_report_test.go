@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvidenceReport(t *testing.T) {
+	var (
+		er     = NewEvidenceReport()
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		ev10a  = NewMockDuplicateVoteEvidence(10, evTime, "mychain")
+		ev10b  = NewMockDuplicateVoteEvidence(10, evTime, "mychain")
+		ev12   = NewMockDuplicateVoteEvidence(12, evTime, "mychain")
+	)
+
+	assert.Empty(t, er.Heights())
+	assert.Nil(t, er.ByHeight(10))
+
+	assert.True(t, er.Add(ev12))
+	assert.True(t, er.Add(ev10a))
+	assert.True(t, er.Add(ev10b))
+	assert.False(t, er.Add(ev10a), "evidence with the same hash should be rejected")
+
+	assert.Equal(t, 3, er.Len())
+	assert.Equal(t, []int64{10, 12}, er.Heights())
+	assert.Equal(t, []Evidence{ev10a, ev10b}, er.ByHeight(10))
+	assert.Equal(t, []Evidence{ev12}, er.ByHeight(12))
+	assert.Nil(t, er.ByHeight(11))
+}
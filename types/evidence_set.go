@@ -0,0 +1,217 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// EvidenceSet is a lightweight, in-memory set of evidence. Evidence is keyed
+// by its Hash() and deduplicated both by hash and by Equal, so the same
+// misbehavior is only ever stored once. It's also indexed by the address of
+// the implicated validator (composite evidence, which has no single address,
+// is not).
+//
+// Evidence can be marked as committed once it's included in a block, after
+// which it can't be added again.
+//
+// EvidenceSet is safe for concurrent use.
+type EvidenceSet struct {
+	mtx       tmsync.RWMutex
+	evidence  map[string]Evidence   // hash -> evidence
+	list      []Evidence            // insertion order
+	byAddress map[string][]Evidence // address -> evidence, in insertion order
+	committed map[string]struct{}   // hashes of committed evidence
+}
+
+// NewEvidenceSet returns an empty EvidenceSet.
+func NewEvidenceSet() *EvidenceSet {
+	return &EvidenceSet{
+		evidence:  make(map[string]Evidence),
+		byAddress: make(map[string][]Evidence),
+		committed: make(map[string]struct{}),
+	}
+}
+
+// Add adds the evidence to the set. It returns false if the same evidence
+// (either with an identical hash or Equal to an existing entry) is already
+// present, or if it was committed.
+func (es *EvidenceSet) Add(ev Evidence) bool {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	if _, ok := es.committed[string(ev.Hash())]; ok {
+		return false
+	}
+	return es.add(ev)
+}
+
+func (es *EvidenceSet) add(ev Evidence) bool {
+	if es.has(ev) {
+		return false
+	}
+
+	es.evidence[string(ev.Hash())] = ev
+	es.list = append(es.list, ev)
+	if _, ok := ev.(CompositeEvidence); !ok {
+		addr := string(ev.Address())
+		es.byAddress[addr] = append(es.byAddress[addr], ev)
+	}
+	return true
+}
+
+// Has returns true if the evidence is in the set.
+func (es *EvidenceSet) Has(ev Evidence) bool {
+	es.mtx.RLock()
+	defer es.mtx.RUnlock()
+
+	return es.has(ev)
+}
+
+func (es *EvidenceSet) has(ev Evidence) bool {
+	if _, ok := es.evidence[string(ev.Hash())]; ok {
+		return true
+	}
+	return EvidenceList(es.list).Has(ev)
+}
+
+// List returns the evidence in the set in the order it was added.
+func (es *EvidenceSet) List() []Evidence {
+	es.mtx.RLock()
+	defer es.mtx.RUnlock()
+
+	list := make([]Evidence, len(es.list))
+	copy(list, es.list)
+	return list
+}
+
+// ListPending returns the evidence in the set which hasn't been committed, in
+// the order it was added.
+func (es *EvidenceSet) ListPending() []Evidence {
+	es.mtx.RLock()
+	defer es.mtx.RUnlock()
+
+	list := make([]Evidence, 0, len(es.list))
+	for _, ev := range es.list {
+		if _, ok := es.committed[string(ev.Hash())]; !ok {
+			list = append(list, ev)
+		}
+	}
+	return list
+}
+
+// MarkCommitted marks the evidence with the given hash as committed. The
+// evidence doesn't need to be in the set. Marking it again is a no-op.
+func (es *EvidenceSet) MarkCommitted(hash []byte) {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	es.committed[string(hash)] = struct{}{}
+}
+
+// IsCommitted returns true if the evidence with the given hash was marked as
+// committed.
+func (es *EvidenceSet) IsCommitted(hash []byte) bool {
+	es.mtx.RLock()
+	defer es.mtx.RUnlock()
+
+	_, ok := es.committed[string(hash)]
+	return ok
+}
+
+// ListByAddress returns the evidence against the validator with the given
+// address, sorted by height. Evidence at the same height is returned in the
+// order it was added.
+func (es *EvidenceSet) ListByAddress(addr []byte) []Evidence {
+	es.mtx.RLock()
+	defer es.mtx.RUnlock()
+
+	list := make([]Evidence, len(es.byAddress[string(addr)]))
+	copy(list, es.byAddress[string(addr)])
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Height() < list[j].Height()
+	})
+	return list
+}
+
+// Len returns the number of pieces of evidence in the set.
+func (es *EvidenceSet) Len() int {
+	es.mtx.RLock()
+	defer es.mtx.RUnlock()
+
+	return len(es.list)
+}
+
+// evidenceSetSnapshot is the JSON representation of an EvidenceSet exported
+// by ExportSnapshot.
+type evidenceSetSnapshot struct {
+	// pending evidence in insertion order, encoded with
+	// MarshalEvidenceVersioned
+	Evidence [][]byte `json:"evidence"`
+	// hashes of committed evidence, sorted
+	Committed [][]byte `json:"committed"`
+}
+
+// ExportSnapshot serializes the pending evidence in the set (see
+// ListPending), encoded with MarshalEvidenceVersioned, along with the hashes
+// of committed evidence, so that the set can be restored with ImportSnapshot
+// (e.g. after a restart). Committed evidence itself isn't exported.
+func (es *EvidenceSet) ExportSnapshot() ([]byte, error) {
+	es.mtx.RLock()
+	defer es.mtx.RUnlock()
+
+	snapshot := evidenceSetSnapshot{
+		Evidence:  make([][]byte, 0, len(es.list)),
+		Committed: make([][]byte, 0, len(es.committed)),
+	}
+	for i, ev := range es.list {
+		if _, ok := es.committed[string(ev.Hash())]; ok {
+			continue
+		}
+		bz, err := MarshalEvidenceVersioned(ev)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode evidence #%d: %w", i, err)
+		}
+		snapshot.Evidence = append(snapshot.Evidence, bz)
+	}
+	for hash := range es.committed {
+		snapshot.Committed = append(snapshot.Committed, []byte(hash))
+	}
+	sort.Slice(snapshot.Committed, func(i, j int) bool {
+		return bytes.Compare(snapshot.Committed[i], snapshot.Committed[j]) < 0
+	})
+	return json.Marshal(snapshot)
+}
+
+// ImportSnapshot adds the evidence and committed flags of a snapshot exported
+// by ExportSnapshot to the set. Evidence already in the set is skipped. An
+// error is returned, and the set left unchanged, if the snapshot can't be
+// decoded.
+func (es *EvidenceSet) ImportSnapshot(bz []byte) error {
+	var snapshot evidenceSetSnapshot
+	if err := json.Unmarshal(bz, &snapshot); err != nil {
+		return fmt.Errorf("failed to decode evidence set snapshot: %w", err)
+	}
+	evList := make([]Evidence, len(snapshot.Evidence))
+	for i, evBz := range snapshot.Evidence {
+		ev, err := UnmarshalEvidenceVersioned(evBz)
+		if err != nil {
+			return fmt.Errorf("failed to decode evidence #%d: %w", i, err)
+		}
+		evList[i] = ev
+	}
+
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	for _, ev := range evList {
+		es.add(ev)
+	}
+	for _, hash := range snapshot.Committed {
+		es.committed[string(hash)] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvidenceSet(t *testing.T) {
+	var (
+		es  = NewEvidenceSet()
+		ev  = randomDuplicatedVoteEvidence(t)
+		ev2 = randomDuplicatedVoteEvidence(t)
+	)
+
+	assert.Equal(t, 0, es.Len())
+	assert.False(t, es.Has(ev))
+
+	assert.True(t, es.Add(ev))
+	assert.False(t, es.Add(ev), "the same evidence should not be added twice")
+	assert.True(t, es.Has(ev))
+	assert.False(t, es.Has(ev2))
+
+	assert.True(t, es.Add(ev2))
+	assert.True(t, es.Has(ev2))
+
+	assert.Equal(t, 2, es.Len())
+	assert.Equal(t, []Evidence{ev, ev2}, es.List())
+}
+
+func TestEvidenceSetMarkCommitted(t *testing.T) {
+	var (
+		es  = NewEvidenceSet()
+		ev  = randomDuplicatedVoteEvidence(t)
+		ev2 = randomDuplicatedVoteEvidence(t)
+		ev3 = randomDuplicatedVoteEvidence(t)
+	)
+
+	assert.True(t, es.Add(ev))
+	assert.True(t, es.Add(ev2))
+	assert.False(t, es.IsCommitted(ev.Hash()))
+
+	es.MarkCommitted(ev.Hash())
+	es.MarkCommitted(ev.Hash())
+	assert.True(t, es.IsCommitted(ev.Hash()))
+	assert.False(t, es.IsCommitted(ev2.Hash()))
+	assert.False(t, es.Add(ev))
+
+	assert.Equal(t, []Evidence{ev, ev2}, es.List())
+	assert.Equal(t, []Evidence{ev2}, es.ListPending())
+
+	// evidence committed before being added is refused
+	es.MarkCommitted(ev3.Hash())
+	assert.False(t, es.Add(ev3))
+	assert.False(t, es.Has(ev3))
+	assert.Equal(t, 2, es.Len())
+}
+
+func TestEvidenceSetListByAddress(t *testing.T) {
+	var (
+		es     = NewEvidenceSet()
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		val    = NewMockPV()
+		val2   = NewMockPV()
+		ev5    = NewMockDuplicateVoteEvidenceWithValidator(5, evTime, val, "mychain")
+		ev2    = NewMockDuplicateVoteEvidenceWithValidator(2, evTime, val, "mychain")
+		ev9    = NewMockDuplicateVoteEvidenceWithValidator(9, evTime, val, "mychain")
+		other  = NewMockDuplicateVoteEvidenceWithValidator(3, evTime, val2, "mychain")
+	)
+
+	for _, ev := range []Evidence{ev5, other, ev2, ev9} {
+		assert.True(t, es.Add(ev))
+	}
+
+	assert.Equal(t, []Evidence{ev2, ev5, ev9}, es.ListByAddress(ev5.Address()))
+	assert.Equal(t, []Evidence{other}, es.ListByAddress(other.Address()))
+	assert.Empty(t, es.ListByAddress([]byte("unknown")))
+}
+
+func TestEvidenceSetSnapshot(t *testing.T) {
+	var (
+		es  = NewEvidenceSet()
+		ev  = randomDuplicatedVoteEvidence(t)
+		ev2 = randomDuplicatedVoteEvidence(t)
+		ev3 = randomDuplicatedVoteEvidence(t)
+	)
+	require.True(t, es.Add(ev))
+	require.True(t, es.Add(ev2))
+	es.MarkCommitted(ev.Hash())
+	es.MarkCommitted(ev3.Hash())
+
+	bz, err := es.ExportSnapshot()
+	require.NoError(t, err)
+
+	// only pending evidence is exported
+	restored := NewEvidenceSet()
+	require.NoError(t, restored.ImportSnapshot(bz))
+	assert.Equal(t, []Evidence{ev2}, restored.List())
+	assert.Equal(t, es.ListPending(), restored.ListPending())
+	for _, ev := range []Evidence{ev, ev2, ev3} {
+		assert.Equal(t, es.IsCommitted(ev.Hash()), restored.IsCommitted(ev.Hash()))
+	}
+	assert.Equal(t, es.ListByAddress(ev2.Address()), restored.ListByAddress(ev2.Address()))
+	assert.False(t, restored.Add(ev))
+	assert.False(t, restored.Add(ev3))
+
+	// importing twice doesn't duplicate evidence
+	require.NoError(t, restored.ImportSnapshot(bz))
+	assert.Equal(t, 1, restored.Len())
+
+	// evidence of every type, some bigger than MaxEvidenceBytes
+	es = NewEvidenceSet()
+	evList := makeEvidenceOfEveryType(t)
+	for _, ev := range evList {
+		require.True(t, es.Add(ev), "%T", ev)
+	}
+	bz, err = es.ExportSnapshot()
+	require.NoError(t, err)
+	restored = NewEvidenceSet()
+	require.NoError(t, restored.ImportSnapshot(bz))
+	require.Equal(t, len(evList), restored.Len())
+	for i, ev := range restored.List() {
+		assert.Equal(t, evList[i].Hash(), ev.Hash(), "%T", ev)
+	}
+
+	// invalid snapshots leave the set unchanged
+	empty := NewEvidenceSet()
+	assert.Error(t, empty.ImportSnapshot([]byte("foo")))
+	assert.Error(t, empty.ImportSnapshot([]byte(`{"evidence":["AQID"]}`)))
+	assert.Equal(t, 0, empty.Len())
+}
@@ -1,7 +1,12 @@
 package types
 
 import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,8 +16,10 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 type voteData struct {
@@ -80,7 +87,223 @@ func TestDuplicateVoteEvidence(t *testing.T) {
 
 	assert.True(t, ev.Equal(ev))
 	assert.False(t, ev.Equal(&DuplicateVoteEvidence{}))
+	assertEvidenceEncodingParity(t, ev)
+}
+
+func TestDuplicateVoteEvidencePartSetConflict(t *testing.T) {
+	const chainID = "mychain"
+	val := NewMockPV()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+
+	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+	blockID4 := makeBlockID([]byte("blockhash"), 10000, []byte("partshash2"))
+
+	vote1 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultVoteTime)
+	vote4 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID4, defaultVoteTime)
+	assert.True(t, IsPartSetConflict(vote1, vote4))
+	assert.True(t, IsPartSetConflict(vote4, vote1))
+	assert.False(t, IsPartSetConflict(vote1, vote1))
+	assert.False(t, IsPartSetConflict(vote1, nil))
+
+	// same block hash, different part set headers: punishable
+	ev := NewDuplicateVoteEvidence(vote1, vote4, defaultVoteTime)
+	assert.NoError(t, ev.ValidateBasic())
+	assert.NoError(t, ev.Verify(chainID, pubKey))
+	assert.True(t, ev.IsPartSetConflict())
+
+	// different blocks
+	vote2 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultVoteTime)
+	ev = NewDuplicateVoteEvidence(vote1, vote2, defaultVoteTime)
+	assert.NoError(t, ev.Verify(chainID, pubKey))
+	assert.False(t, ev.IsPartSetConflict())
+}
+
+func TestNewDuplicateVoteEvidenceFromCommits(t *testing.T) {
+	const (
+		chainID = "mychain"
+		height  = int64(3)
+	)
+	var (
+		_, valSet, vals = randVoteSet(height, 0, tmproto.PrecommitType, 4, 10)
+		blockID1        = makeBlockID(tmhash.Sum([]byte("block1")), 1, tmhash.Sum([]byte("parts1")))
+		blockID2        = makeBlockID(tmhash.Sum([]byte("block2")), 1, tmhash.Sum([]byte("parts2")))
+		now             = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	// makeCommit returns a commit for blockID, where the i-th validator voted
+	// for votes[i] (nil for an absent vote).
+	makeCommit := func(blockID BlockID, votes ...*BlockID) *Commit {
+		sigs := make([]CommitSig, len(votes))
+		for i, vote := range votes {
+			if vote == nil {
+				sigs[i] = NewCommitSigAbsent()
+				continue
+			}
+			sigs[i] = makeVote(t, vals[i], chainID, int32(i), height, 0, 2, *vote, now.Add(time.Duration(i)*time.Second)).
+				CommitSig()
+		}
+		return NewCommit(height, 0, blockID, sigs)
+	}
+
+	// validators 0 and 2 equivocated, 1 voted nil twice and 3 voted only once
+	c1 := makeCommit(blockID1, &blockID1, &BlockID{}, &blockID1, &blockID1)
+	c2 := makeCommit(blockID2, &blockID2, &BlockID{}, &blockID2, nil)
+	evList, err := NewDuplicateVoteEvidenceFromCommits(chainID, c1, c2, valSet)
+	require.NoError(t, err)
+	require.Len(t, evList, 2)
+	for i, valIdx := range []int{0, 2} {
+		assert.EqualValues(t, valSet.Validators[valIdx].Address, evList[i].Address())
+		assert.EqualValues(t, height, evList[i].Height())
+		assert.NoError(t, evList[i].ValidateBasic())
+	}
+
+	evList, err = NewDuplicateVoteEvidenceFromCommits(chainID, c1, c1, valSet)
+	require.NoError(t, err)
+	assert.Empty(t, evList)
+
+	// invalid signature
+	c2.Signatures[2].Signature = c2.Signatures[0].Signature
+	_, err = NewDuplicateVoteEvidenceFromCommits(chainID, c1, c2, valSet)
+	assert.Error(t, err)
+
+	// commits from different heights
+	_, err = NewDuplicateVoteEvidenceFromCommits(chainID, c1, NewCommit(height+1, 0, blockID2, c2.Signatures), valSet)
+	assert.Error(t, err)
+}
+
+func TestNewPrecommitDuplicateVoteEvidence(t *testing.T) {
+	const chainID = "mychain"
+	var (
+		val      = NewMockPV()
+		blockID1 = makeBlockID(tmhash.Sum([]byte("block1")), 1, tmhash.Sum([]byte("parts1")))
+		blockID2 = makeBlockID(tmhash.Sum([]byte("block2")), 1, tmhash.Sum([]byte("parts2")))
+	)
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	vote := func(step int, blockID BlockID, ts time.Time) *Vote {
+		return makeVote(t, val, chainID, 0, 10, 2, step, blockID, ts)
+	}
+
+	// two precommits
+	pc1 := vote(2, blockID1, defaultVoteTime)
+	pc2 := vote(2, blockID2, defaultVoteTime.Add(time.Second))
+	ev, err := NewPrecommitDuplicateVoteEvidence(pubKey, pc1, pc2)
+	require.NoError(t, err)
+	assert.NoError(t, ev.ValidateBasic())
+	assert.NoError(t, ev.Verify(chainID, pubKey))
+	assert.Equal(t, defaultVoteTime.Add(time.Second), ev.Time())
+
+	// two prevotes
+	pv1 := vote(1, blockID1, defaultVoteTime)
+	pv2 := vote(1, blockID2, defaultVoteTime)
+	_, err = NewPrecommitDuplicateVoteEvidence(pubKey, pv1, pv2)
+	assert.True(t, errors.Is(err, ErrEvidenceNotPrecommit), err)
+	require.NotNil(t, NewDuplicateVoteEvidence(pv1, pv2, defaultVoteTime))
+
+	// mixed pair
+	_, err = NewPrecommitDuplicateVoteEvidence(pubKey, pc1, pv2)
+	assert.True(t, errors.Is(err, ErrEvidenceNotPrecommit), err)
+
+	// not conflicting, or not from the validator
+	_, err = NewPrecommitDuplicateVoteEvidence(pubKey, pc1, pc1)
+	assert.True(t, errors.Is(err, ErrEvidenceSameBlockID), err)
+	_, err = NewPrecommitDuplicateVoteEvidence(ed25519.GenPrivKey().PubKey(), pc1, pc2)
+	assert.Equal(t, ErrEvidencePubKeyMismatch, err)
+}
 
+func TestDuplicateVoteEvidenceFixtureHash(t *testing.T) {
+	ev := DuplicateVoteEvidenceFromFixture()
+	require.NoError(t, ev.ValidateBasic())
+
+	// If this changes, the evidence encoding (and thus the evidence hash included
+	// in blocks) has changed, which is a breaking change.
+	const expected = "8FA452A59C13BE9CC871B0F91BA356FEDC1F9D8FE31051A3299936F4657ED501"
+	assert.Equal(t, expected, strings.ToUpper(hex.EncodeToString(ev.Hash())))
+	assert.Equal(t, ev.Hash(), DuplicateVoteEvidenceFromFixture().Hash())
+}
+
+func TestDuplicateVoteEvidenceTime(t *testing.T) {
+	ev := randomDuplicatedVoteEvidence(t)
+	require.True(t, ev.Timestamp.IsZero())
+	// the latest vote
+	assert.Equal(t, ev.VoteB.Timestamp, ev.Time())
+	ev.VoteA, ev.VoteB = ev.VoteB, ev.VoteA
+	assert.Equal(t, ev.VoteA.Timestamp, ev.Time())
+
+	unset := ev.Hash()
+	blockTime := defaultVoteTime.Add(time.Hour)
+	ev.Timestamp = blockTime
+	assert.Equal(t, blockTime, ev.Time())
+	assert.NotEqual(t, unset, ev.Hash())
+	assert.Equal(t, ev.Hash(), (&DuplicateVoteEvidence{VoteA: ev.VoteA, VoteB: ev.VoteB, Timestamp: blockTime}).Hash())
+
+	assert.True(t, (&DuplicateVoteEvidence{}).Time().IsZero())
+}
+
+func TestEvidenceVersioned(t *testing.T) {
+	ev := randomDuplicatedVoteEvidence(t)
+
+	bz, err := MarshalEvidenceVersioned(ev)
+	require.NoError(t, err)
+	assert.Equal(t, EvidenceFormatV1, bz[0])
+
+	ev2, err := UnmarshalEvidenceVersioned(bz)
+	require.NoError(t, err)
+	assert.Equal(t, ev, ev2)
+	assert.Equal(t, ev.Hash(), ev2.Hash())
+
+	for _, ev := range makeEvidenceOfEveryType(t) {
+		bz, err := MarshalEvidenceVersioned(ev)
+		require.NoError(t, err)
+		ev2, err := UnmarshalEvidenceVersioned(bz)
+		if assert.NoError(t, err, "%T", ev) {
+			assert.Equal(t, ev.Hash(), ev2.Hash(), "%T", ev)
+			assert.True(t, ev.Equal(ev2), "%T", ev)
+		}
+	}
+
+	bz[0] = 2
+	_, err = UnmarshalEvidenceVersioned(bz)
+	assert.True(t, errors.Is(err, ErrUnknownEvidenceFormat), err)
+	_, err = UnmarshalEvidenceVersioned(nil)
+	assert.Error(t, err)
+	_, err = MarshalEvidenceVersioned(nil)
+	assert.Error(t, err)
+}
+
+func TestCanonicalEvidenceBytes(t *testing.T) {
+	ev := randomDuplicatedVoteEvidence(t)
+	// a non-UTC time doesn't change the encoding
+	ev.Timestamp = ev.Timestamp.In(time.FixedZone("UTC+1", 3600))
+
+	bz, err := CanonicalEvidenceBytes(ev)
+	require.NoError(t, err)
+	decoded, err := UnmarshalEvidenceVersioned(bz)
+	require.NoError(t, err)
+	assert.True(t, decoded.Equal(ev))
+	assert.Equal(t, ev.Hash(), decoded.Hash())
+
+	// idempotent
+	bz2, err := CanonicalEvidenceBytes(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, bz, bz2)
+
+	for _, ev := range makeEvidenceOfEveryType(t) {
+		bz, err := CanonicalEvidenceBytes(ev)
+		if !assert.NoError(t, err, "%T", ev) {
+			continue
+		}
+		decoded, err := UnmarshalEvidenceVersioned(bz)
+		require.NoError(t, err)
+		assert.Equal(t, ev.Hash(), decoded.Hash(), "%T", ev)
+		bz2, err := CanonicalEvidenceBytes(decoded)
+		require.NoError(t, err)
+		assert.Equal(t, bz, bz2, "%T", ev)
+	}
+
+	_, err = CanonicalEvidenceBytes(nil)
+	assert.Error(t, err)
 }
 
 func TestEvidenceList(t *testing.T) {
@@ -92,6 +315,226 @@ func TestEvidenceList(t *testing.T) {
 	assert.False(t, evl.Has(&DuplicateVoteEvidence{}))
 }
 
+func TestEvidenceListProof(t *testing.T) {
+	evl := EvidenceList([]Evidence{
+		randomDuplicatedVoteEvidence(t),
+		randomDuplicatedVoteEvidence(t),
+		randomDuplicatedVoteEvidence(t),
+	})
+	root := evl.Hash()
+
+	ev, proof := evl.Proof(1)
+	assert.Equal(t, evl[1], ev)
+	assert.EqualValues(t, 1, proof.Index)
+	assert.EqualValues(t, 3, proof.Total)
+	assert.NoError(t, proof.Verify(root, ev.Bytes()))
+
+	// a tampered evidence doesn't match the proof
+	bz := ev.Bytes()
+	bz[len(bz)-1] ^= 0xff
+	assert.Error(t, proof.Verify(root, bz))
+
+	// neither does another evidence in the list
+	assert.Error(t, proof.Verify(root, evl[0].Bytes()))
+
+	assert.Panics(t, func() { evl.Proof(3) })
+}
+
+func TestEvidenceListByteSize(t *testing.T) {
+	assert.EqualValues(t, 0, EvidenceList{}.ByteSize())
+
+	ev := randomDuplicatedVoteEvidence(t)
+	size := int64(len(ev.Bytes()))
+	require.True(t, size >= 1<<7 && size < 1<<14, "length prefix should be two bytes")
+	assert.Equal(t, size+2, EvidenceList{ev}.ByteSize())
+	assert.Equal(t, 2*(size+2), EvidenceList{ev, ev}.ByteSize())
+}
+
+func TestEvidenceListSplitByByteBudget(t *testing.T) {
+	val := NewMockPV()
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	const chainID = "mychain"
+	largeEvidence := func() Evidence {
+		return NewDuplicateVoteEvidence(
+			makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, math.MaxInt32, 0x02, blockID, defaultVoteTime),
+			makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, math.MaxInt32, 0x02, blockID2, defaultVoteTime),
+			defaultVoteTime,
+		)
+	}
+
+	evl := EvidenceList{
+		randomDuplicatedVoteEvidence(t),
+		largeEvidence(),
+		randomDuplicatedVoteEvidence(t),
+		randomDuplicatedVoteEvidence(t),
+		largeEvidence(),
+		largeEvidence(),
+		randomDuplicatedVoteEvidence(t),
+	}
+	small := EvidenceList{evl[0]}.ByteSize()
+	large := EvidenceList{evl[1]}.ByteSize()
+	require.Greater(t, large, small)
+
+	for _, maxBytes := range []int64{large, large + small, 2 * large, evl.ByteSize()} {
+		pages := evl.SplitByByteBudget(maxBytes)
+
+		var joined EvidenceList
+		for _, page := range pages {
+			assert.NotEmpty(t, page)
+			assert.LessOrEqual(t, page.ByteSize(), maxBytes, "budget %d", maxBytes)
+			joined = append(joined, page...)
+		}
+		assert.Equal(t, evl, joined, "budget %d: evidence was dropped or reordered", maxBytes)
+	}
+	assert.Len(t, evl.SplitByByteBudget(evl.ByteSize()), 1)
+
+	// evidence bigger than the budget gets its own page
+	pages := evl.SplitByByteBudget(small)
+	assert.Len(t, pages, len(evl))
+	assert.Equal(t, EvidenceList{evl[1]}, pages[1])
+
+	assert.Empty(t, EvidenceList{}.SplitByByteBudget(small))
+}
+
+// Two nodes observing the same equivocation, possibly with the votes in a
+// different order, must agree on the evidence and its hash.
+func TestDuplicateVoteEvidenceEqualIsDefinedByVotes(t *testing.T) {
+	const chainID = "mychain"
+	val := NewMockPV()
+	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+	vote1 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultVoteTime)
+	vote2 := makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultVoteTime)
+
+	ev1 := NewDuplicateVoteEvidence(vote1, vote2, defaultVoteTime)
+	ev2 := NewDuplicateVoteEvidence(vote2.Copy(), vote1.Copy(), defaultVoteTime)
+	assert.True(t, ev1.Equal(ev2))
+	assert.True(t, ev2.Equal(ev1))
+	assert.Equal(t, ev1.Hash(), ev2.Hash())
+
+	vote3 := makeVote(t, val, chainID, 0, 10, 2, 1, makeBlockIDRandom(), defaultVoteTime)
+	ev3 := NewDuplicateVoteEvidence(vote1, vote3, defaultVoteTime)
+	assert.False(t, ev1.Equal(ev3))
+	assert.NotEqual(t, ev1.Hash(), ev3.Hash())
+}
+
+func TestDuplicateVoteEvidenceCustomAddressHasher(t *testing.T) {
+	prefix := crypto.AddressHasherFunc(func(pubKey crypto.PubKey) crypto.Address {
+		return crypto.Address(pubKey.Bytes()[:crypto.AddressSize])
+	})
+
+	const chainID = "mychain"
+	pv := NewMockPV()
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+	val := NewValidatorWithAddressHasher(pubKey, 10, prefix)
+	require.NoError(t, val.ValidateBasic())
+	addr := crypto.Address(pubKey.Bytes()[:crypto.AddressSize])
+	require.Equal(t, addr, val.Address)
+	require.NotEqual(t, pubKey.Address(), val.Address)
+
+	vote := func(blockID BlockID) *Vote {
+		v := &Vote{
+			ValidatorAddress: val.Address,
+			Height:           10,
+			Round:            2,
+			Type:             tmproto.PrevoteType,
+			BlockID:          blockID,
+			Timestamp:        defaultVoteTime,
+		}
+		vpb := v.ToProto()
+		require.NoError(t, pv.SignVote(chainID, vpb))
+		v.Signature = vpb.Signature
+		return v
+	}
+	ev := NewDuplicateVoteEvidence(vote(makeBlockIDRandom()), vote(makeBlockIDRandom()), defaultVoteTime)
+	require.NoError(t, ev.ValidateBasic())
+	assert.Equal(t, []byte(addr), ev.Address())
+	assert.Equal(t, addr, ev.VoteA.ValidatorAddress)
+
+	valSet := NewValidatorSet([]*Validator{val})
+	idx, v := valSet.GetByAddress(ev.Address())
+	assert.EqualValues(t, 0, idx)
+	assert.Equal(t, pubKey, v.PubKey)
+}
+
+func TestEvidenceListSortByPriority(t *testing.T) {
+	var (
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		powers = []int64{10, 100, 50}
+		vals   = make([]*Validator, len(powers))
+		evl    = make(EvidenceList, len(powers))
+	)
+	for i, power := range powers {
+		pv := NewMockPV()
+		vals[i] = pv.ExtractIntoValidator(power)
+		evl[i] = NewMockDuplicateVoteEvidenceWithValidator(1, evTime, pv, "mychain")
+	}
+	valSet := NewValidatorSet(vals)
+	powerOf := func(ev Evidence) int64 { return EvidenceImpactScore(ev, valSet) }
+
+	sorted := append(EvidenceList(nil), evl...)
+	sorted.SortByPriority(powerOf)
+	assert.Equal(t, EvidenceList{evl[1], evl[2], evl[0]}, sorted)
+
+	// the sort is stable
+	same := EvidenceList{evl[0], evl[1], evl[2]}
+	same.SortByPriority(func(Evidence) int64 { return 1 })
+	assert.Equal(t, EvidenceList{evl[0], evl[1], evl[2]}, same)
+}
+
+func TestEvidenceListDedup(t *testing.T) {
+	ev := randomDuplicatedVoteEvidence(t)
+	swapped := &DuplicateVoteEvidence{VoteA: ev.VoteB, VoteB: ev.VoteA, Timestamp: ev.Timestamp}
+	require.NotEqual(t, ev.Hash(), swapped.Hash())
+	other := randomDuplicatedVoteEvidence(t)
+
+	evl := EvidenceList{ev, swapped, other, ev}
+	assert.Equal(t, EvidenceList{ev, other}, evl.Dedup())
+	assert.Len(t, evl, 4)
+	assert.Empty(t, EvidenceList(nil).Dedup())
+}
+
+func TestEvidenceListValidateHeights(t *testing.T) {
+	var (
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		pv     = NewMockPV()
+		ev     = func(h int64) Evidence {
+			return NewMockDuplicateVoteEvidenceWithValidator(h, evTime, pv, "mychain")
+		}
+	)
+
+	assert.NoError(t, EvidenceList{ev(1), ev(5), ev(9)}.ValidateHeights(10))
+	assert.NoError(t, EvidenceList{ev(3), ev(10)}.ValidateHeights(10))
+	assert.Error(t, EvidenceList{ev(3), ev(11)}.ValidateHeights(10))
+	assert.Error(t, EvidenceList{ev(0)}.ValidateHeights(10))
+	assert.NoError(t, EvidenceList(nil).ValidateHeights(10))
+}
+
+func TestEvidenceImpactScore(t *testing.T) {
+	var (
+		highPowerVal = NewMockPV()
+		lowPowerVal  = NewMockPV()
+		outsiderVal  = NewMockPV()
+		valSet       = NewValidatorSet([]*Validator{
+			highPowerVal.ExtractIntoValidator(100),
+			lowPowerVal.ExtractIntoValidator(10),
+		})
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	highEv := NewMockDuplicateVoteEvidenceWithValidator(1, evTime, highPowerVal, "mychain")
+	lowEv := NewMockDuplicateVoteEvidenceWithValidator(1, evTime, lowPowerVal, "mychain")
+	outsiderEv := NewMockDuplicateVoteEvidenceWithValidator(1, evTime, outsiderVal, "mychain")
+
+	assert.EqualValues(t, 100, EvidenceImpactScore(highEv, valSet))
+	assert.EqualValues(t, 10, EvidenceImpactScore(lowEv, valSet))
+	assert.Greater(t, EvidenceImpactScore(highEv, valSet), EvidenceImpactScore(lowEv, valSet))
+	assert.Zero(t, EvidenceImpactScore(outsiderEv, valSet))
+	assert.Zero(t, EvidenceImpactScore(&ConflictingHeadersEvidence{}, valSet))
+}
+
 func TestMaxEvidenceBytes(t *testing.T) {
 	val := NewMockPV()
 	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
@@ -101,6 +544,8 @@ func TestMaxEvidenceBytes(t *testing.T) {
 	ev := &DuplicateVoteEvidence{
 		VoteA: makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, math.MaxInt32, math.MaxInt64, blockID, maxTime),
 		VoteB: makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, math.MaxInt32, math.MaxInt64, blockID2, maxTime),
+
+		Timestamp: maxTime,
 	}
 
 	//TODO: Add other types of evidence to test and set MaxEvidenceBytes accordingly
@@ -109,7 +554,7 @@ func TestMaxEvidenceBytes(t *testing.T) {
 	// Header: makeHeaderRandom(),
 	// Vote:   makeVote(t, val, chainID, math.MaxInt64, math.MaxInt64, math.MaxInt64, math.MaxInt64, blockID2),
 
-	// 	InvalidHeaderField: "",
+	// 	InvalidHeaderFields: []string{},
 	// }
 
 	// signedHeader := SignedHeader{Header: makeHeaderRandom(), Commit: randCommit(time.Now())}
@@ -138,6 +583,177 @@ func TestMaxEvidenceBytes(t *testing.T) {
 
 }
 
+func TestMaxBytesForEvidence(t *testing.T) {
+	val := NewMockPV()
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	maxTime := time.Date(9999, 0, 0, 0, 0, 0, 0, time.UTC)
+	const chainID = "mychain"
+
+	vote := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, math.MaxInt32, math.MaxInt64, blockID, maxTime)
+	proposal := func(blockID BlockID) *Proposal {
+		p := makeProposal(t, val, chainID, math.MaxInt64, math.MaxInt32, blockID)
+		p.POLRound, p.Timestamp = math.MaxInt32, maxTime
+		return p
+	}
+
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	potentialAmnesia := &PotentialAmnesiaEvidence{
+		VoteA:       vote,
+		VoteB:       makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, math.MaxInt32, math.MaxInt64, blockID2, maxTime),
+		HeightStamp: math.MaxInt64,
+		Timestamp:   maxTime,
+	}
+	// commits and proofs of lock change with MaxVotesCount signatures
+	commit := func(blockID BlockID) *Commit {
+		sigs := make([]CommitSig, MaxVotesCount)
+		for i := range sigs {
+			sigs[i] = NewCommitSigForBlock(vote.Signature, vote.ValidatorAddress, maxTime)
+		}
+		return &Commit{Height: math.MaxInt64, Round: math.MaxInt32, BlockID: blockID, Signatures: sigs}
+	}
+	polcVotes := make([]*Vote, MaxVotesCount)
+	for i := range polcVotes {
+		polcVotes[i] = vote
+	}
+
+	// evidence with every field at its maximum size
+	testCases := []struct {
+		testName string
+		evidence Evidence
+		maxBytes int64
+	}{
+		{"DuplicateVote", &DuplicateVoteEvidence{
+			VoteA:     vote,
+			VoteB:     makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, math.MaxInt32, math.MaxInt64, blockID2, maxTime),
+			Timestamp: maxTime,
+		}, MaxEvidenceBytes},
+		{"FutureHeight", &FutureHeightEvidence{
+			Vote:          vote,
+			ClaimedHeight: math.MaxInt64,
+			Timestamp:     maxTime,
+		}, MaxFutureHeightEvidenceBytes},
+		{"DuplicateProposal", &DuplicateProposalEvidence{
+			ProposalA:        proposal(blockID),
+			ProposalB:        proposal(blockID2),
+			ValidatorAddress: vote.ValidatorAddress,
+			Timestamp:        maxTime,
+		}, MaxDuplicateProposalEvidenceBytes},
+		{"LunaticValidator", &LunaticValidatorEvidence{
+			Header:              makeMaxHeader(),
+			Vote:                vote,
+			InvalidHeaderFields: lunaticHeaderFields,
+			Timestamp:           maxTime,
+		}, MaxLunaticValidatorEvidenceBytes},
+		{"PotentialAmnesia", potentialAmnesia, MaxPotentialAmnesiaEvidenceBytes},
+		{"ConflictingHeaders", &ConflictingHeadersEvidence{
+			H1: &SignedHeader{Header: makeMaxHeader(), Commit: commit(blockID)},
+			H2: &SignedHeader{Header: makeMaxHeader(), Commit: commit(blockID2)},
+		}, MaxConflictingHeadersEvidenceBytes},
+		{"Amnesia", &AmnesiaEvidence{
+			PotentialAmnesiaEvidence: potentialAmnesia,
+			Polc:                     &ProofOfLockChange{Votes: polcVotes, PubKey: pubKey},
+		}, MaxAmnesiaEvidenceBytes},
+		{"Unknown", &UnknownEvidence{}, MaxEvidenceBytes},
+		{"Application-defined", &appEvidence{}, 0},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.Equal(t, tc.maxBytes, MaxBytesForEvidence(tc.evidence))
+			if tc.maxBytes > 0 {
+				assert.LessOrEqual(t, int64(len(tc.evidence.Bytes())), tc.maxBytes)
+			}
+		})
+	}
+
+	// valid evidence of every type passes the size check
+	for _, ev := range makeEvidenceOfEveryType(t) {
+		assert.NoError(t, checkEvidenceSize(ev), "%T", ev)
+		assert.NoError(t, EvidenceList{ev}.ValidateBasic(), "%T", ev)
+	}
+	assert.NoError(t, EvidenceList(makeEvidenceOfEveryType(t)).ValidateBasic())
+	assert.NoError(t, checkEvidenceSize(&appEvidence{EvAddr: make([]byte, 10*MaxEvidenceBytes)}))
+
+	// oversized FutureHeightEvidence, under MaxEvidenceBytes
+	oversized := &FutureHeightEvidence{
+		Vote:          vote.Copy(),
+		ClaimedHeight: math.MaxInt64,
+		Timestamp:     maxTime,
+	}
+	oversized.Vote.Signature = make([]byte, 100)
+	size := int64(len(oversized.Bytes()))
+	require.Greater(t, size, MaxFutureHeightEvidenceBytes)
+	require.Less(t, size, MaxEvidenceBytes)
+
+	err = EvidenceList{randomDuplicatedVoteEvidence(t), oversized}.ValidateBasic()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "evidence #1: *types.FutureHeightEvidence is too big")
+	}
+	assert.NoError(t, EvidenceList{randomDuplicatedVoteEvidence(t)}.ValidateBasic())
+	assert.Error(t, EvidenceList{&DuplicateVoteEvidence{}}.ValidateBasic())
+}
+
+func TestDecodeEvidence(t *testing.T) {
+	ev := randomDuplicatedVoteEvidence(t)
+	pb, err := EvidenceToProto(ev)
+	require.NoError(t, err)
+	bz, err := pb.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := DecodeEvidence(bz)
+	require.NoError(t, err)
+	assert.Equal(t, ev.Hash(), decoded.Hash())
+
+	for _, ev := range makeEvidenceOfEveryType(t) {
+		pb, err := EvidenceToProto(ev)
+		require.NoError(t, err)
+		bz, err := pb.Marshal()
+		require.NoError(t, err)
+
+		decoded, err := DecodeEvidence(bz)
+		if assert.NoError(t, err, "%T", ev) {
+			assert.Equal(t, ev.Hash(), decoded.Hash(), "%T", ev)
+		}
+	}
+
+	// the largest evidence fits the bound checked before decoding
+	for _, maxBytes := range []int64{MaxEvidenceBytes, MaxFutureHeightEvidenceBytes,
+		MaxDuplicateProposalEvidenceBytes, MaxLunaticValidatorEvidenceBytes, MaxPotentialAmnesiaEvidenceBytes,
+		MaxAmnesiaEvidenceBytes} {
+		assert.Less(t, maxBytes, MaxConflictingHeadersEvidenceBytes)
+	}
+
+	testCases := []struct {
+		testName string
+		bz       []byte
+	}{
+		{"nil", nil},
+		{"truncated", bz[:len(bz)-5]},
+		{"oversized", append(bz, make([]byte, maxEncodedEvidenceBytes)...)},
+		{"unknown type tag", []byte{0x9a, 0x06, 0x00}}, // field 99, length 0
+		{"empty duplicate vote evidence", []byte{0x0a, 0x00}},
+		{"garbage", []byte{0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				ev, err := DecodeEvidence(tc.bz)
+				assert.Error(t, err)
+				assert.Nil(t, ev)
+			})
+		})
+	}
+
+	// random input must never panic
+	for i := 0; i < 1000; i++ {
+		input := tmrand.Bytes(tmrand.Intn(int(MaxEvidenceBytes)))
+		assert.NotPanics(t, func() { _, _ = DecodeEvidence(input) })
+	}
+}
+
 func randomDuplicatedVoteEvidence(t *testing.T) *DuplicateVoteEvidence {
 	val := NewMockPV()
 	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
@@ -149,6 +765,180 @@ func randomDuplicatedVoteEvidence(t *testing.T) *DuplicateVoteEvidence {
 	}
 }
 
+// makeEvidenceOfEveryType returns a valid instance of every evidence type
+// supported by EvidenceToProto.
+func makeEvidenceOfEveryType(t *testing.T) []Evidence {
+	const (
+		chainID       = "mychain"
+		height  int64 = 37
+	)
+	val := NewMockPV()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+
+	// lunatic validator evidence
+	header := makeHeaderRandom()
+	header.ChainID, header.Height = chainID, height
+	headerBlockID := makeBlockID(header.Hash(), 100, tmhash.Sum([]byte("partshash")))
+	lunatic := NewLunaticValidatorEvidence(header,
+		makeVote(t, val, chainID, 0, height, 0, 2, headerBlockID, defaultVoteTime),
+		[]string{AppHashField, ConsensusHashField}, defaultVoteTime)
+
+	// conflicting headers, signed by the same validators
+	voteSet1, valSet, vals := randVoteSet(height, 1, tmproto.PrecommitType, 10, 1)
+	voteSet2 := NewVoteSet(chainID, height, 1, tmproto.PrecommitType, valSet)
+	signedHeader := func(voteSet *VoteSet) *SignedHeader {
+		h := makeHeaderRandom()
+		h.ChainID, h.Height = chainID, height
+		commit, err := MakeCommit(makeBlockID(h.Hash(), 100, tmhash.Sum([]byte("partshash"))), height, 1,
+			voteSet, vals, defaultVoteTime)
+		require.NoError(t, err)
+		return &SignedHeader{Header: h, Commit: commit}
+	}
+
+	// amnesia evidence, with a proof of lock change for the second vote
+	polcVoteSet, _, polcVals, polcBlockID := buildVoteSet(height, 1, 2, 7, 0, tmproto.PrecommitType)
+	polcPubKey, err := polcVals[7].GetPubKey()
+	require.NoError(t, err)
+	amnesia := NewAmnesiaEvidence(
+		NewPotentialAmnesiaEvidence(
+			makeVote(t, polcVals[7], chainID, 7, height, 0, 2, blockID, defaultVoteTime),
+			makeVote(t, polcVals[7], chainID, 7, height, 1, 2, polcBlockID, time.Now().Add(time.Hour)),
+			defaultVoteTime),
+		newPOLCFromVoteSet(polcVoteSet, polcPubKey, polcBlockID))
+
+	evList := []Evidence{
+		randomDuplicatedVoteEvidence(t),
+		NewDuplicateProposalEvidence(
+			makeProposal(t, val, chainID, height, 2, blockID),
+			makeProposal(t, val, chainID, height, 2, blockID2),
+			pubKey.Address(), defaultVoteTime),
+		NewFutureHeightEvidence(makeVote(t, val, chainID, 0, 100, 0, 2, blockID, defaultVoteTime), 10,
+			defaultVoteTime),
+		NewConflictingHeadersEvidence(signedHeader(voteSet1), signedHeader(voteSet2)),
+		lunatic,
+		NewPotentialAmnesiaEvidence(
+			makeVote(t, val, chainID, 0, height, 0, 2, blockID, defaultVoteTime),
+			makeVote(t, val, chainID, 0, height, 1, 2, blockID2, defaultVoteTime.Add(time.Second)),
+			defaultVoteTime),
+		amnesia,
+	}
+	for _, ev := range evList {
+		require.NoError(t, ev.ValidateBasic(), "%T", ev)
+	}
+	return evList
+}
+
+func TestDuplicateVoteEvidenceVerifyVersion(t *testing.T) {
+	const chainID = "mychain"
+	oldVersion := version.BlockProtocol - 1
+	val := NewMockPV()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+
+	signVersion := func(vote *Vote, blockVersion uint64) {
+		signBytes, err := VoteSignBytesVersion(chainID, vote.ToProto(), blockVersion)
+		require.NoError(t, err)
+		sig, err := val.PrivKey.Sign(signBytes)
+		require.NoError(t, err)
+		vote.Signature = sig
+	}
+
+	voteA := makeVote(t, val, chainID, 0, 10, 2, 1, makeBlockID([]byte("blockhash"), 1000, []byte("partshash")),
+		defaultVoteTime)
+	voteB := makeVote(t, val, chainID, 0, 10, 2, 1, makeBlockID([]byte("blockhash2"), 1000, []byte("partshash")),
+		defaultVoteTime)
+	signVersion(voteA, oldVersion)
+	signVersion(voteB, oldVersion)
+	ev := NewDuplicateVoteEvidence(voteA, voteB, defaultVoteTime)
+
+	// votes signed under an earlier version with the same layout verify
+	// under both
+	assert.NoError(t, ev.VerifyVersion(chainID, pubKey, oldVersion))
+	assert.NoError(t, ev.VerifyVersion(chainID, pubKey, version.BlockProtocol))
+	assert.NoError(t, ev.Verify(chainID, pubKey))
+
+	// the layout of future versions is unknown
+	assert.Error(t, ev.VerifyVersion(chainID, pubKey, version.BlockProtocol+1))
+
+	ev.VoteB.Signature = ev.VoteA.Signature
+	err = ev.VerifyVersion(chainID, pubKey, oldVersion)
+	assert.True(t, errors.Is(err, ErrEvidenceInvalidSignature), err)
+}
+
+func TestDuplicateVoteEvidenceVerifyErrors(t *testing.T) {
+	const chainID = "mychain"
+	var (
+		val      = NewMockPV()
+		val2     = NewMockPV()
+		blockID  = makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+		blockID2 = makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+		vote1    = makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultVoteTime)
+	)
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	pubKey2, err := val2.GetPubKey()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		testName string
+		vote2    *Vote
+		pubKey   crypto.PubKey
+		chainID  string
+		expErr   error
+	}{
+		{"wrong height", makeVote(t, val, chainID, 0, 11, 2, 1, blockID2, defaultVoteTime), pubKey, chainID,
+			ErrEvidenceVoteMismatch},
+		{"wrong validator", makeVote(t, val2, chainID, 0, 10, 2, 1, blockID2, defaultVoteTime), pubKey, chainID,
+			ErrEvidenceAddressMismatch},
+		{"same block id", makeVote(t, val, chainID, 0, 10, 2, 1, blockID, defaultVoteTime), pubKey, chainID,
+			ErrEvidenceSameBlockID},
+		{"wrong pubkey", makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultVoteTime), pubKey2, chainID,
+			ErrEvidencePubKeyMismatch},
+		{"nil pubkey", makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultVoteTime), nil, chainID,
+			ErrEvidenceNilPubKey},
+		{"wrong chain id", makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultVoteTime), pubKey, "mychain2",
+			ErrEvidenceInvalidSignature},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			ev := &DuplicateVoteEvidence{VoteA: vote1, VoteB: tc.vote2, Timestamp: defaultVoteTime}
+			err := ev.Verify(tc.chainID, tc.pubKey)
+			assert.True(t, errors.Is(err, tc.expErr), err)
+		})
+	}
+
+	// still an invalid vote signature
+	ev := &DuplicateVoteEvidence{VoteA: vote1, VoteB: makeVote(t, val, chainID, 0, 10, 2, 1, blockID2,
+		defaultVoteTime), Timestamp: defaultVoteTime}
+	assert.True(t, errors.Is(ev.Verify("mychain2", pubKey), ErrVoteInvalidSignature))
+
+	// votes in the wrong order
+	ev = NewDuplicateVoteEvidence(vote1, makeVote(t, val, chainID, 0, 10, 2, 1, blockID2, defaultVoteTime),
+		defaultVoteTime)
+	ev.VoteA, ev.VoteB = ev.VoteB, ev.VoteA
+	assert.Equal(t, ErrEvidenceVoteOrder, ev.ValidateBasic())
+}
+
+func TestDuplicateVoteEvidenceValidateBasicSameVote(t *testing.T) {
+	const chainID = "mychain"
+	val := NewMockPV()
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), 1, tmhash.Sum([]byte("partshash")))
+	vote := makeVote(t, val, chainID, 0, 10, 2, 2, blockID, defaultVoteTime)
+
+	ev := &DuplicateVoteEvidence{VoteA: vote, VoteB: vote.Copy(), Timestamp: defaultVoteTime}
+	err := ev.ValidateBasic()
+	assert.True(t, errors.Is(err, ErrEvidenceSameBlockID), err)
+
+	// re-signed with another timestamp
+	ev.VoteB = makeVote(t, val, chainID, 0, 10, 2, 2, blockID, defaultVoteTime.Add(time.Second))
+	err = ev.ValidateBasic()
+	assert.True(t, errors.Is(err, ErrEvidenceSameBlockID), err)
+}
+
 func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 	val := NewMockPV()
 	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
@@ -170,6 +960,14 @@ func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 		{"Invalid vote type", func(ev *DuplicateVoteEvidence) {
 			ev.VoteA = makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, math.MaxInt32, 0, blockID2, defaultVoteTime)
 		}, true},
+		{"Proposal vote type", func(ev *DuplicateVoteEvidence) {
+			ev.VoteA = makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, math.MaxInt32,
+				int(tmproto.ProposalType), blockID2, defaultVoteTime)
+		}, true},
+		{"Not an equivocation", func(ev *DuplicateVoteEvidence) {
+			ev.VoteB = makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64-1, math.MaxInt32, 0x02, blockID2,
+				defaultVoteTime)
+		}, true},
 		{"Invalid vote order", func(ev *DuplicateVoteEvidence) {
 			swap := ev.VoteA.Copy()
 			ev.VoteA = ev.VoteB.Copy()
@@ -193,6 +991,147 @@ func TestMockEvidenceValidateBasic(t *testing.T) {
 	assert.Nil(t, goodEvidence.ValidateBasic())
 }
 
+func makeProposal(t *testing.T, val PrivValidator, chainID string, height int64, round int32,
+	blockID BlockID) *Proposal {
+	p := NewProposal(height, round, -1, blockID)
+	p.Timestamp = defaultVoteTime
+	pp := p.ToProto()
+	require.NoError(t, val.SignProposal(chainID, pp))
+	p.Signature = pp.Signature
+	return p
+}
+
+func TestDuplicateProposalEvidence(t *testing.T) {
+	const chainID = "mychain"
+	val := NewMockPV()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+
+	ev := NewDuplicateProposalEvidence(
+		makeProposal(t, val, chainID, 10, 2, blockID2),
+		makeProposal(t, val, chainID, 10, 2, blockID),
+		pubKey.Address(),
+		defaultVoteTime,
+	)
+	require.NoError(t, ev.ValidateBasic())
+	assert.EqualValues(t, 10, ev.Height())
+	assert.Equal(t, []byte(pubKey.Address()), ev.Address())
+	assert.NoError(t, ev.Verify(chainID, pubKey))
+	assert.True(t, ev.Equal(ev))
+	assert.NotEmpty(t, ev.Hash())
+
+	// wrong chain ID
+	assert.True(t, errors.Is(ev.Verify("otherchain", pubKey), ErrEvidenceInvalidSignature))
+
+	// wrong key
+	assert.True(t, errors.Is(ev.Verify(chainID, ed25519.GenPrivKey().PubKey()), ErrEvidencePubKeyMismatch))
+	assert.True(t, errors.Is(ev.Verify(chainID, nil), ErrEvidenceNilPubKey))
+
+	// signed by another validator
+	other := NewMockPV()
+	ev2 := NewDuplicateProposalEvidence(
+		makeProposal(t, other, chainID, 10, 2, blockID),
+		makeProposal(t, other, chainID, 10, 2, blockID2),
+		pubKey.Address(),
+		defaultVoteTime,
+	)
+	require.NoError(t, ev2.ValidateBasic())
+	assert.True(t, errors.Is(ev2.Verify(chainID, pubKey), ErrEvidenceInvalidSignature))
+	assert.False(t, ev.Equal(ev2))
+	assert.False(t, ev.Equal(NewMockDuplicateVoteEvidence(10, defaultVoteTime, chainID)))
+
+	assert.Nil(t, NewDuplicateProposalEvidence(nil, ev.ProposalB, pubKey.Address(), defaultVoteTime))
+}
+
+func TestDuplicateProposalEvidenceValidation(t *testing.T) {
+	const chainID = "mychain"
+	val := NewMockPV()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+
+	testCases := []struct {
+		testName         string
+		malleateEvidence func(*DuplicateProposalEvidence)
+		expectErr        bool
+		errIs            error
+	}{
+		{"Good DuplicateProposalEvidence", func(ev *DuplicateProposalEvidence) {}, false, nil},
+		{"Nil proposal A", func(ev *DuplicateProposalEvidence) { ev.ProposalA = nil }, true, nil},
+		{"Nil proposal B", func(ev *DuplicateProposalEvidence) { ev.ProposalB = nil }, true, nil},
+		{"Unsigned proposal", func(ev *DuplicateProposalEvidence) { ev.ProposalA.Signature = nil }, true, nil},
+		{"Invalid address", func(ev *DuplicateProposalEvidence) { ev.ValidatorAddress = []byte("addr") }, true, nil},
+		{"Same block ID", func(ev *DuplicateProposalEvidence) {
+			ev.ProposalB = makeProposal(t, val, chainID, 10, 2, ev.ProposalA.BlockID)
+		}, true, ErrEvidenceSameBlockID},
+		{"Different heights", func(ev *DuplicateProposalEvidence) {
+			ev.ProposalB = makeProposal(t, val, chainID, 11, 2, ev.ProposalB.BlockID)
+		}, true, ErrEvidenceProposalMismatch},
+		{"Different rounds", func(ev *DuplicateProposalEvidence) {
+			ev.ProposalB = makeProposal(t, val, chainID, 10, 3, ev.ProposalB.BlockID)
+		}, true, ErrEvidenceProposalMismatch},
+		{"Invalid proposal order", func(ev *DuplicateProposalEvidence) {
+			ev.ProposalA, ev.ProposalB = ev.ProposalB, ev.ProposalA
+		}, true, ErrEvidenceProposalOrder},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			ev := NewDuplicateProposalEvidence(
+				makeProposal(t, val, chainID, 10, 2, blockID),
+				makeProposal(t, val, chainID, 10, 2, blockID2),
+				pubKey.Address(),
+				defaultVoteTime,
+			)
+			tc.malleateEvidence(ev)
+			err := ev.ValidateBasic()
+			assert.Equal(t, tc.expectErr, err != nil, "Validate Basic had an unexpected result: %v", err)
+			if tc.errIs != nil {
+				assert.True(t, errors.Is(err, tc.errIs), "unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFutureHeightEvidence(t *testing.T) {
+	const chainID = "mychain"
+	val := NewMockPV()
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+
+	ev := NewFutureHeightEvidence(makeVote(t, val, chainID, 0, 100, 0, 2, blockID, defaultVoteTime), 10,
+		defaultVoteTime)
+	require.NoError(t, ev.ValidateBasic())
+	assert.EqualValues(t, 100, ev.Height())
+	assert.Equal(t, []byte(pubKey.Address()), ev.Address())
+	assert.NoError(t, ev.Verify(chainID, pubKey))
+	assert.True(t, ev.Equal(ev))
+	assert.NotEmpty(t, ev.Hash())
+
+	// the vote is not in the future
+	for _, height := range []int64{5, 10} {
+		past := NewFutureHeightEvidence(makeVote(t, val, chainID, 0, height, 0, 2, blockID, defaultVoteTime), 10,
+			defaultVoteTime)
+		assert.True(t, errors.Is(past.ValidateBasic(), ErrEvidenceNotFutureHeight))
+		assert.True(t, errors.Is(past.Verify(chainID, pubKey), ErrEvidenceNotFutureHeight))
+		assert.False(t, ev.Equal(past))
+	}
+
+	// wrong signature
+	assert.True(t, errors.Is(ev.Verify("otherchain", pubKey), ErrEvidenceInvalidSignature))
+	assert.True(t, errors.Is(ev.Verify(chainID, ed25519.GenPrivKey().PubKey()), ErrEvidencePubKeyMismatch))
+	assert.True(t, errors.Is(ev.Verify(chainID, nil), ErrEvidenceNilPubKey))
+
+	// invalid
+	assert.Error(t, NewFutureHeightEvidence(ev.Vote, -1, defaultVoteTime).ValidateBasic())
+	assert.Error(t, (&FutureHeightEvidence{ClaimedHeight: 10}).ValidateBasic())
+	assert.Nil(t, NewFutureHeightEvidence(nil, 10, defaultVoteTime))
+}
+
 func TestLunaticValidatorEvidence(t *testing.T) {
 	var (
 		invalidBlockID = makeBlockIDRandom()
@@ -214,7 +1153,7 @@ func TestLunaticValidatorEvidence(t *testing.T) {
 
 	vote := makeVote(t, val, header.ChainID, 0, header.Height, 0, 2, blockID, defaultVoteTime)
 
-	ev := NewLunaticValidatorEvidence(header, vote, "AppHash", bTime)
+	ev := NewLunaticValidatorEvidence(header, vote, []string{"AppHash"}, bTime)
 
 	//happy path
 	assert.Equal(t, header.Height, ev.Height())
@@ -229,12 +1168,15 @@ func TestLunaticValidatorEvidence(t *testing.T) {
 	assert.NoError(t, ev.ValidateBasic())
 	assert.NotEmpty(t, ev.String())
 	assert.NoError(t, ev.VerifyHeader(altHeader))
+	assertEvidenceEncodingParity(t, ev)
 
 	// invalid evidence
-	assert.Error(t, ev.Verify("other", pubKey))
+	err = ev.Verify("other", pubKey)
+	assert.True(t, errors.Is(err, ErrEvidenceWrongChainID), err)
 	privKey2 := ed25519.GenPrivKey()
 	pubKey2 := privKey2.PubKey()
-	assert.Error(t, ev.Verify(header.ChainID, pubKey2))
+	err = ev.Verify(header.ChainID, pubKey2)
+	assert.True(t, errors.Is(err, ErrEvidenceInvalidSignature), err)
 	assert.Error(t, ev.VerifyHeader(header))
 
 	invalidVote := makeVote(t, val, header.ChainID, 0, header.Height, 0, 2, invalidBlockID, defaultVoteTime)
@@ -242,12 +1184,12 @@ func TestLunaticValidatorEvidence(t *testing.T) {
 	emptyBlockVote := makeVote(t, val, header.ChainID, 0, header.Height, 0, 2, BlockID{}, defaultVoteTime)
 
 	invalidLunaticEvidence := []*LunaticValidatorEvidence{
-		NewLunaticValidatorEvidence(header, invalidVote, "AppHash", header.Time),
-		NewLunaticValidatorEvidence(header, invalidHeightVote, "AppHash", header.Time),
-		NewLunaticValidatorEvidence(nil, vote, "AppHash", vote.Timestamp),
-		NewLunaticValidatorEvidence(header, nil, "AppHash", header.Time),
-		NewLunaticValidatorEvidence(header, vote, "other", header.Time),
-		NewLunaticValidatorEvidence(header, emptyBlockVote, "AppHash", header.Time),
+		NewLunaticValidatorEvidence(header, invalidVote, []string{"AppHash"}, header.Time),
+		NewLunaticValidatorEvidence(header, invalidHeightVote, []string{"AppHash"}, header.Time),
+		NewLunaticValidatorEvidence(nil, vote, []string{"AppHash"}, vote.Timestamp),
+		NewLunaticValidatorEvidence(header, nil, []string{"AppHash"}, header.Time),
+		NewLunaticValidatorEvidence(header, vote, []string{"other"}, header.Time),
+		NewLunaticValidatorEvidence(header, emptyBlockVote, []string{"AppHash"}, header.Time),
 	}
 
 	for idx, ev := range invalidLunaticEvidence {
@@ -256,6 +1198,85 @@ func TestLunaticValidatorEvidence(t *testing.T) {
 
 }
 
+func TestLunaticValidatorEvidenceMultipleFields(t *testing.T) {
+	var (
+		header   = makeHeaderRandom()
+		bTime, _ = time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+		val      = NewMockPV()
+		fields   = []string{AppHashField, ConsensusHashField}
+	)
+	header.Time = bTime
+
+	blockID := BlockID{
+		Hash: header.Hash(),
+		PartSetHeader: PartSetHeader{
+			Total: 100,
+			Hash:  crypto.CRandBytes(tmhash.Size),
+		},
+	}
+	vote := makeVote(t, val, header.ChainID, 0, header.Height, 0, 2, blockID, defaultVoteTime)
+	ev := NewLunaticValidatorEvidence(header, vote, fields, bTime)
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+
+	assert.NoError(t, ev.ValidateBasic())
+	assert.NoError(t, ev.Verify(header.ChainID, pubKey))
+	assert.Contains(t, ev.String(), "AppHash, ConsensusHash")
+
+	// both fields differ from the committed header
+	committedHeader := *header
+	committedHeader.AppHash = crypto.CRandBytes(tmhash.Size)
+	committedHeader.ConsensusHash = crypto.CRandBytes(tmhash.Size)
+	assert.NoError(t, ev.VerifyHeader(&committedHeader))
+
+	// listing a field which matches the committed header fails
+	committedHeader.ConsensusHash = header.ConsensusHash
+	if err := ev.VerifyHeader(&committedHeader); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "ConsensusHash matches committed hash")
+	}
+
+	// the hash doesn't depend on the listed fields
+	assert.Equal(t, ev.Hash(), NewLunaticValidatorEvidence(header, vote, fields[:1], bTime).Hash())
+	assert.Equal(t, ev.Hash(), ev.Hash())
+
+	// proto round trip
+	pb := ev.ToProto()
+	assert.Equal(t, fields, pb.InvalidHeaderFields)
+	assert.Empty(t, pb.InvalidHeaderField)
+	ev2, err := LunaticValidatorEvidenceFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, fields, ev2.InvalidHeaderFields)
+	assert.Equal(t, ev.Hash(), ev2.Hash())
+
+	// the single field of evidence encoded before is still decoded
+	pb.InvalidHeaderFields = nil
+	pb.InvalidHeaderField = AppHashField
+	ev2, err = LunaticValidatorEvidenceFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, []string{AppHashField}, ev2.InvalidHeaderFields)
+
+	// JSON round trip
+	bz, err := tmjson.Marshal(ev)
+	require.NoError(t, err)
+	assert.Contains(t, string(bz), `"invalid_header_fields":["AppHash","ConsensusHash"]`)
+	var ev3 Evidence
+	require.NoError(t, tmjson.Unmarshal(bz, &ev3))
+	assert.Equal(t, ev, ev3)
+
+	// evidence encoded with a single invalid_header_field can still be decoded
+	old := strings.Replace(string(bz), `"invalid_header_fields":["AppHash","ConsensusHash"]`,
+		`"invalid_header_field":"AppHash"`, 1)
+	require.NoError(t, tmjson.Unmarshal([]byte(old), &ev3))
+	if lve, ok := ev3.(*LunaticValidatorEvidence); assert.True(t, ok) {
+		assert.Equal(t, []string{AppHashField}, lve.InvalidHeaderFields)
+		assert.Equal(t, ev.Hash(), lve.Hash())
+	}
+
+	for _, badFields := range [][]string{nil, {}, {AppHashField, "DataHash"}, {AppHashField, AppHashField}} {
+		assert.Error(t, NewLunaticValidatorEvidence(header, vote, badFields, bTime).ValidateBasic(), "%v", badFields)
+	}
+}
+
 func TestConflictingHeadersEvidence(t *testing.T) {
 	const (
 		chainID       = "TestConflictingHeadersEvidence"
@@ -535,6 +1556,12 @@ func TestAmnesiaEvidence(t *testing.T) {
 	assert.NotEmpty(t, completeAmnesiaEvidence.Hash())
 	assert.NotEmpty(t, completeAmnesiaEvidence.Bytes())
 
+	// a polc that doesn't have more than 2/3 of the voting power is well formed but doesn't prove the lock change
+	insufficientAmnesiaEvidence := NewAmnesiaEvidence(pe, NewPOLC(polc.Votes[:5], pubKey))
+	assert.NoError(t, insufficientAmnesiaEvidence.ValidateBasic())
+	err := insufficientAmnesiaEvidence.Polc.ValidateVotes(valSet, chainID)
+	assert.True(t, IsErrNotEnoughVotingPowerSigned(err), err)
+
 	pe2 := &PotentialAmnesiaEvidence{
 		VoteA: vote3,
 		VoteB: vote2,
@@ -576,6 +1603,90 @@ func TestAmnesiaEvidence(t *testing.T) {
 
 }
 
+// assertEvidenceEncodingParity round-trips the evidence through both its
+// binary (protobuf) and JSON encodings and asserts that the decoded evidence is
+// the same, whichever encoding is used.
+func assertEvidenceEncodingParity(t *testing.T, ev Evidence) {
+	t.Helper()
+
+	pb, err := EvidenceToProto(ev)
+	require.NoError(t, err)
+	bz, err := pb.Marshal()
+	require.NoError(t, err)
+	var pb2 tmproto.Evidence
+	require.NoError(t, pb2.Unmarshal(bz))
+	binEv, err := EvidenceFromProto(&pb2)
+	require.NoError(t, err)
+
+	jsonBz, err := tmjson.Marshal(ev)
+	require.NoError(t, err)
+	var jsonEv Evidence
+	require.NoError(t, tmjson.Unmarshal(jsonBz, &jsonEv))
+
+	for name, decoded := range map[string]Evidence{"binary": binEv, "JSON": jsonEv} {
+		assert.True(t, ev.Equal(decoded), "%s round trip: evidence is not equal", name)
+		assert.True(t, decoded.Equal(ev), "%s round trip: evidence is not equal", name)
+		assert.Equal(t, ev.Hash(), decoded.Hash(), "%s round trip: hash changed", name)
+	}
+
+	// both encodings must carry the same information
+	binJSONBz, err := tmjson.Marshal(binEv)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(jsonBz), string(binJSONBz))
+}
+
+// appEvidence is an application-defined evidence type.
+type appEvidence struct {
+	EvHeight int64     `json:"height"`
+	EvTime   time.Time `json:"time"`
+	EvAddr   []byte    `json:"address"`
+}
+
+var _ Evidence = &appEvidence{}
+
+func (e *appEvidence) Height() int64                      { return e.EvHeight }
+func (e *appEvidence) Time() time.Time                    { return e.EvTime }
+func (e *appEvidence) Address() []byte                    { return e.EvAddr }
+func (e *appEvidence) Hash() []byte                       { return tmhash.Sum(e.Bytes()) }
+func (e *appEvidence) Verify(string, crypto.PubKey) error { return nil }
+func (e *appEvidence) Equal(ev Evidence) bool             { return bytes.Equal(e.Hash(), ev.Hash()) }
+func (e *appEvidence) ValidateBasic() error               { return nil }
+func (e *appEvidence) String() string                     { return fmt.Sprintf("appEvidence{%d}", e.EvHeight) }
+
+func (e *appEvidence) Bytes() []byte {
+	bz, err := tmjson.Marshal(e)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// registered once, so that the test can be run multiple times (-count)
+var registerAppEvidence = RegisterEvidence("test/AppEvidence", &appEvidence{})
+
+func TestRegisterEvidence(t *testing.T) {
+	require.NoError(t, registerAppEvidence)
+
+	ev := &appEvidence{
+		EvHeight: 10,
+		EvTime:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		EvAddr:   tmrand.Bytes(crypto.AddressSize),
+	}
+	bz, err := tmjson.Marshal(ev)
+	require.NoError(t, err)
+	assert.Contains(t, string(bz), `"type":"test/AppEvidence"`)
+
+	var decoded Evidence
+	require.NoError(t, tmjson.Unmarshal(bz, &decoded))
+	assert.Equal(t, ev, decoded)
+
+	// names and types can't be registered twice
+	assert.Error(t, RegisterEvidence("test/AppEvidence", &DuplicateVoteEvidence{}))
+	assert.Error(t, RegisterEvidence("tendermint/DuplicateVoteEvidence", &appEvidence{}))
+	assert.Error(t, RegisterEvidence("test/AppEvidence2", &appEvidence{}))
+	assert.Error(t, RegisterEvidence("test/NilEvidence", nil))
+}
+
 func makeVote(
 	t *testing.T, val PrivValidator, chainID string, valIndex int32, height int64, round int32, step int, blockID BlockID,
 	time time.Time) *Vote {
@@ -627,6 +1738,12 @@ func TestEvidenceProto(t *testing.T) {
 	v := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, 1, 0x01, blockID, defaultVoteTime)
 	v2 := makeVote(t, val, chainID, math.MaxInt32, math.MaxInt64, 2, 0x01, blockID2, defaultVoteTime)
 
+	// -------- Proposals --------
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	p := makeProposal(t, val, chainID, math.MaxInt64, math.MaxInt32, blockID)
+	p2 := makeProposal(t, val, chainID, math.MaxInt64, math.MaxInt32, blockID2)
+
 	// -------- SignedHeaders --------
 	const height int64 = 37
 
@@ -688,7 +1805,7 @@ func TestEvidenceProto(t *testing.T) {
 		{"ConflictingHeadersEvidence nil H1", &ConflictingHeadersEvidence{H1: nil, H2: h2}, false, true},
 		{"ConflictingHeadersEvidence success", &ConflictingHeadersEvidence{H1: h1, H2: h2}, false, false},
 		{"LunaticValidatorEvidence success", &LunaticValidatorEvidence{Header: header1,
-			Vote: v, InvalidHeaderField: "ValidatorsHash"}, false, true},
+			Vote: v, InvalidHeaderFields: []string{"ValidatorsHash"}}, false, true},
 		{"&LunaticValidatorEvidence empty fail", &LunaticValidatorEvidence{}, false, true},
 		{"LunaticValidatorEvidence only header fail", &LunaticValidatorEvidence{Header: header1}, false, true},
 		{"LunaticValidatorEvidence only vote fail", &LunaticValidatorEvidence{Vote: v}, false, true},
@@ -705,6 +1822,15 @@ func TestEvidenceProto(t *testing.T) {
 				Polc: &ProofOfLockChange{}}, false, false},
 		{"AmnesiaEvidence success", &AmnesiaEvidence{PotentialAmnesiaEvidence: &PotentialAmnesiaEvidence{VoteA: v2, VoteB: v},
 			Polc: NewEmptyPOLC()}, false, false},
+		{"DuplicateProposalEvidence empty fail", &DuplicateProposalEvidence{}, false, true},
+		{"DuplicateProposalEvidence nil ProposalB", &DuplicateProposalEvidence{ProposalA: p,
+			ValidatorAddress: pubKey.Address()}, false, true},
+		{"DuplicateProposalEvidence success",
+			NewDuplicateProposalEvidence(p, p2, pubKey.Address(), defaultVoteTime), false, false},
+		{"FutureHeightEvidence empty fail", &FutureHeightEvidence{}, false, true},
+		{"FutureHeightEvidence not future fail", &FutureHeightEvidence{Vote: v, ClaimedHeight: math.MaxInt64,
+			Timestamp: defaultVoteTime}, false, true},
+		{"FutureHeightEvidence success", NewFutureHeightEvidence(v, 10, defaultVoteTime), false, false},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -0,0 +1,219 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// ErrUnknownEvidenceType is returned when verifying UnknownEvidence.
+var ErrUnknownEvidenceType = errors.New("unknown evidence type")
+
+// Field numbers of the Block and EvidenceData messages.
+const (
+	blockEvidenceFieldNumber        = 3
+	evidenceDataEvidenceFieldNumber = 1
+)
+
+// UnknownEvidence is a placeholder for evidence of a type this node can't
+// decode, e.g. one introduced by a newer version, found in a block decoded by
+// DecodeBlockLenient. It retains the encoded evidence, so that the evidence
+// hash of the block still matches, but nothing else is known about it: it
+// can't be verified and has no height, time or address.
+//
+// NOTE: it can't be converted back to protobuf (see EvidenceToProto), so a
+// block containing it can't be encoded again.
+type UnknownEvidence struct {
+	// FieldNumber is the field number of the evidence in the Sum oneof of
+	// tmproto.Evidence.
+	FieldNumber int32
+	// Raw is the encoded evidence, i.e. the value of that field.
+	Raw []byte
+}
+
+var _ Evidence = &UnknownEvidence{}
+
+// Height returns 0, as the height of the evidence is unknown.
+func (ue *UnknownEvidence) Height() int64 {
+	return 0
+}
+
+// Time returns the zero time, as the time of the evidence is unknown.
+func (ue *UnknownEvidence) Time() time.Time {
+	return time.Time{}
+}
+
+// Address returns nil, as the accused validator is unknown.
+func (ue *UnknownEvidence) Address() []byte {
+	return nil
+}
+
+// Bytes returns the encoded evidence.
+func (ue *UnknownEvidence) Bytes() []byte {
+	return ue.Raw
+}
+
+// Hash returns the hash of the encoded evidence.
+func (ue *UnknownEvidence) Hash() []byte {
+	return tmhash.Sum(ue.Raw)
+}
+
+// Verify always returns ErrUnknownEvidenceType.
+func (ue *UnknownEvidence) Verify(chainID string, pubKey crypto.PubKey) error {
+	return fmt.Errorf("%w (field %d)", ErrUnknownEvidenceType, ue.FieldNumber)
+}
+
+// Equal returns true if the other evidence is UnknownEvidence with the same
+// field number and bytes.
+func (ue *UnknownEvidence) Equal(ev Evidence) bool {
+	other, ok := ev.(*UnknownEvidence)
+	return ok && ue.FieldNumber == other.FieldNumber && bytes.Equal(ue.Raw, other.Raw)
+}
+
+// ValidateBasic checks the size of the encoded evidence.
+func (ue *UnknownEvidence) ValidateBasic() error {
+	if ue.FieldNumber <= 0 {
+		return fmt.Errorf("invalid field number %d", ue.FieldNumber)
+	}
+	if int64(len(ue.Raw)) > MaxEvidenceBytes {
+		return fmt.Errorf("evidence is too big: %d bytes, max: %d", len(ue.Raw), MaxEvidenceBytes)
+	}
+	return nil
+}
+
+// String returns a string representation of the evidence.
+func (ue *UnknownEvidence) String() string {
+	return fmt.Sprintf("UnknownEvidence{FieldNumber: %d, Hash: %X}", ue.FieldNumber, ue.Hash())
+}
+
+// DecodeBlockLenient decodes a protobuf-encoded block like BlockFromProto,
+// except that evidence of an unknown type is kept as UnknownEvidence instead
+// of failing, so that blocks produced by newer versions can still be
+// processed. A warning is returned for each piece of UnknownEvidence, whose
+// verification must be skipped (see VerifyBlockEvidence).
+//
+// The evidence hash is recomputed from the decoded evidence, so the block is
+// only valid if UnknownEvidence hashes like it would on newer versions.
+func DecodeBlockLenient(bz []byte) (block *Block, warnings []error, err error) {
+	var pb tmproto.Block
+	if err := proto.Unmarshal(bz, &pb); err != nil {
+		return nil, nil, err
+	}
+
+	b := new(Block)
+	if b.Header, err = HeaderFromProto(&pb.Header); err != nil {
+		return nil, nil, err
+	}
+	if b.Data, err = DataFromProto(&pb.Data); err != nil {
+		return nil, nil, err
+	}
+	if pb.LastCommit != nil {
+		if b.LastCommit, err = CommitFromProto(pb.LastCommit); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// unknown oneof fields are skipped by proto.Unmarshal, so find them in
+	// the encoded evidence of the block
+	var rawEvidence [][]byte
+	err = forEachProtoBytesField(bz, func(num int32, evData []byte) error {
+		if num != blockEvidenceFieldNumber {
+			return nil
+		}
+		return forEachProtoBytesField(evData, func(num int32, ev []byte) error {
+			if num == evidenceDataEvidenceFieldNumber {
+				rawEvidence = append(rawEvidence, ev)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rawEvidence) != len(pb.Evidence.Evidence) {
+		return nil, nil, fmt.Errorf("found %d pieces of evidence, decoded %d",
+			len(rawEvidence), len(pb.Evidence.Evidence))
+	}
+
+	for i := range pb.Evidence.Evidence {
+		if pb.Evidence.Evidence[i].Sum != nil {
+			ev, err := EvidenceFromProto(&pb.Evidence.Evidence[i])
+			if err != nil {
+				return nil, nil, fmt.Errorf("evidence #%d: %w", i, err)
+			}
+			b.Evidence.Evidence = append(b.Evidence.Evidence, ev)
+			continue
+		}
+
+		// the last field wins for oneofs
+		ue := &UnknownEvidence{}
+		err := forEachProtoBytesField(rawEvidence[i], func(num int32, value []byte) error {
+			ue.FieldNumber, ue.Raw = num, value
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("evidence #%d: %w", i, err)
+		}
+		if ue.FieldNumber == 0 {
+			return nil, nil, fmt.Errorf("evidence #%d is empty", i)
+		}
+		b.Evidence.Evidence = append(b.Evidence.Evidence, ue)
+		warnings = append(warnings, fmt.Errorf("evidence #%d: %w (field %d), verification skipped",
+			i, ErrUnknownEvidenceType, ue.FieldNumber))
+	}
+
+	if err := b.ValidateBasic(); err != nil {
+		return nil, nil, err
+	}
+	return b, warnings, nil
+}
+
+// forEachProtoBytesField calls fn with the number and value of each
+// length-delimited field of the encoded message, skipping other fields.
+func forEachProtoBytesField(bz []byte, fn func(num int32, value []byte) error) error {
+	for len(bz) > 0 {
+		key, n := proto.DecodeVarint(bz)
+		if n == 0 {
+			return errors.New("invalid field key")
+		}
+		bz = bz[n:]
+		num, wireType := int32(key>>3), key&7
+		if num <= 0 {
+			return fmt.Errorf("invalid field number %d", num)
+		}
+
+		switch wireType {
+		case proto.WireVarint:
+			if _, n = proto.DecodeVarint(bz); n == 0 {
+				return errors.New("invalid varint")
+			}
+		case proto.WireFixed64:
+			n = 8
+		case proto.WireFixed32:
+			n = 4
+		case proto.WireBytes:
+			size, m := proto.DecodeVarint(bz)
+			if m == 0 || size > uint64(len(bz)-m) {
+				return errors.New("invalid length-delimited field")
+			}
+			if err := fn(num, bz[m:m+int(size)]); err != nil {
+				return err
+			}
+			n = m + int(size)
+		default:
+			return fmt.Errorf("unsupported wire type %d", wireType)
+		}
+		if n > len(bz) {
+			return errors.New("unexpected end of input")
+		}
+		bz = bz[n:]
+	}
+	return nil
+}
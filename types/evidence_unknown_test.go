@@ -0,0 +1,86 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func protoBytesField(num int, value []byte) []byte {
+	bz := proto.EncodeVarint(uint64(num<<3 | proto.WireBytes))
+	bz = append(bz, proto.EncodeVarint(uint64(len(value)))...)
+	return append(bz, value...)
+}
+
+func TestDecodeBlockLenient(t *testing.T) {
+	const (
+		chainID = "block-test-chain"
+		h       = int64(3)
+		// not a field of tmproto.Evidence, i.e. a type added by a newer version
+		newFieldNumber = 15
+	)
+
+	voteSet, valSet, vals := randVoteSet(h-1, 1, tmproto.PrecommitType, 10, 1)
+	commit, err := MakeCommit(makeBlockIDRandom(), h-1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	evTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ev1 := NewMockDuplicateVoteEvidenceWithValidator(h, evTime, vals[0], chainID)
+	ev2 := NewMockDuplicateVoteEvidenceWithValidator(h, evTime, vals[1], chainID)
+	block := MakeBlock(h, []Tx{Tx("foo")}, commit, []Evidence{ev1, ev2})
+	block.ProposerAddress = valSet.GetProposer().Address
+
+	pb, err := block.ToProto()
+	require.NoError(t, err)
+	bz, err := proto.Marshal(pb)
+	require.NoError(t, err)
+
+	// known types only
+	decoded, warnings, err := DecodeBlockLenient(bz)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, block.Hash(), decoded.Hash())
+	assert.Equal(t, block.Evidence.Evidence, decoded.Evidence.Evidence)
+
+	// encode ev2 as if it was of the new type
+	pb.Evidence.Evidence = pb.Evidence.Evidence[:1]
+	bz, err = proto.Marshal(pb)
+	require.NoError(t, err)
+	bz = append(bz, protoBytesField(blockEvidenceFieldNumber,
+		protoBytesField(evidenceDataEvidenceFieldNumber,
+			protoBytesField(newFieldNumber, ev2.Bytes())))...)
+
+	var strict tmproto.Block
+	require.NoError(t, proto.Unmarshal(bz, &strict))
+	_, err = BlockFromProto(&strict)
+	assert.Error(t, err)
+
+	decoded, warnings, err = DecodeBlockLenient(bz)
+	require.NoError(t, err)
+	if assert.Len(t, warnings, 1) {
+		assert.True(t, errors.Is(warnings[0], ErrUnknownEvidenceType))
+	}
+	assert.Equal(t, block.Hash(), decoded.Hash())
+	assert.Equal(t, block.EvidenceHash, decoded.Evidence.Hash())
+	require.Len(t, decoded.Evidence.Evidence, 2)
+	assert.Equal(t, ev1, decoded.Evidence.Evidence[0])
+	unknown := &UnknownEvidence{FieldNumber: newFieldNumber, Raw: ev2.Bytes()}
+	assert.Equal(t, unknown, decoded.Evidence.Evidence[1])
+
+	// verification of the unknown evidence is skipped
+	assert.True(t, errors.Is(unknown.Verify(chainID, nil), ErrUnknownEvidenceType))
+	valSetForHeight := func(int64) (*ValidatorSet, error) { return valSet, nil }
+	assert.NoError(t, VerifyBlockEvidence(decoded, valSetForHeight, chainID))
+
+	// the evidence hash must still match
+	bz[len(bz)-1] ^= 0x01
+	_, _, err = DecodeBlockLenient(bz)
+	assert.Error(t, err)
+	_, _, err = DecodeBlockLenient(bz[:len(bz)-1])
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EvidenceErrorCode is a machine-readable reason for rejecting evidence.
+type EvidenceErrorCode uint32
+
+// Evidence error codes. Zero is reserved for success.
+const (
+	// CodeInvalid is used for malformed evidence or evidence that fails
+	// verification for a reason not covered by a more specific code.
+	CodeInvalid EvidenceErrorCode = iota + 1
+	// CodeExpired is used for evidence older than the evidence max age.
+	CodeExpired
+	// CodeInvalidSignature is used for evidence with an invalid signature.
+	CodeInvalidSignature
+	// CodeDuplicate is used for evidence that has already been committed.
+	CodeDuplicate
+	// CodeUnknownValidator is used for evidence against an address that is
+	// not in the validator set.
+	CodeUnknownValidator
+)
+
+var evidenceErrorCodeNames = map[EvidenceErrorCode]string{
+	CodeInvalid:          "invalid",
+	CodeExpired:          "expired",
+	CodeInvalidSignature: "invalid signature",
+	CodeDuplicate:        "duplicate",
+	CodeUnknownValidator: "unknown validator",
+}
+
+func (c EvidenceErrorCode) String() string {
+	if name, ok := evidenceErrorCodeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("EvidenceErrorCode(%d)", uint32(c))
+}
+
+// EvidenceError is returned by ValidateEvidence. It carries a Code suitable
+// for returning to RPC clients alongside a human-readable message.
+type EvidenceError struct {
+	Code    EvidenceErrorCode
+	Message string
+	Err     error // underlying error, if any
+}
+
+func (e *EvidenceError) Error() string {
+	return fmt.Sprintf("%v evidence: %s", e.Code, e.Message)
+}
+
+// Unwrap returns the underlying error.
+func (e *EvidenceError) Unwrap() error {
+	return e.Err
+}
+
+func newEvidenceError(code EvidenceErrorCode, err error) *EvidenceError {
+	return &EvidenceError{Code: code, Message: err.Error(), Err: err}
+}
+
+// EvidenceValidationParams are the parameters of ValidateEvidence.
+type EvidenceValidationParams struct {
+	// CurrentHeight is the height evidence is validated at.
+	CurrentHeight int64
+	// MaxAgeNumBlocks and MaxAgeDuration are the maximum age of evidence (see
+	// EvidenceParams). Like in the evidence pool, evidence is only expired if
+	// it's older than both.
+	MaxAgeNumBlocks int64
+	MaxAgeDuration  time.Duration
+	// Committed is the evidence already committed. Can be nil.
+	Committed *EvidenceSet
+}
+
+// ValidateEvidence runs the basic validation, expiry, duplicate and
+// verification checks on the evidence against valSet, the validator set at
+// the evidence height. It returns nil if the evidence is valid.
+func ValidateEvidence(ev Evidence, chainID string, valSet *ValidatorSet, now time.Time,
+	params EvidenceValidationParams) *EvidenceError {
+	if _, ok := ev.(CompositeEvidence); ok {
+		return newEvidenceError(CodeInvalid, errors.New("composite evidence must be split first"))
+	}
+	if err := ev.ValidateBasic(); err != nil {
+		return newEvidenceError(CodeInvalid, err)
+	}
+
+	if isEvidenceExpired(ev, params.CurrentHeight, now, params.MaxAgeNumBlocks, params.MaxAgeDuration) {
+		return newEvidenceError(CodeExpired, fmt.Errorf(
+			"evidence from height %d (created at: %v) is too old; min height is %d and evidence can not be older than %v",
+			ev.Height(), ev.Time(), params.CurrentHeight-params.MaxAgeNumBlocks, now.Add(-params.MaxAgeDuration)))
+	}
+
+	if params.Committed != nil && params.Committed.Has(ev) {
+		return newEvidenceError(CodeDuplicate, errors.New("evidence was already committed"))
+	}
+
+	if _, err := VerifyEvidenceWithValidatorSet(ev, chainID, valSet); err != nil {
+		switch {
+		case errors.Is(err, ErrEvidenceUnknownValidator):
+			return newEvidenceError(CodeUnknownValidator, err)
+		case errors.Is(err, ErrVoteInvalidSignature) || errors.Is(err, ErrProposalInvalidSignature):
+			return newEvidenceError(CodeInvalidSignature, err)
+		default:
+			return newEvidenceError(CodeInvalid, err)
+		}
+	}
+
+	return nil
+}
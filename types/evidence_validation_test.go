@@ -0,0 +1,77 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEvidence(t *testing.T) {
+	const chainID = "mychain"
+	var (
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		val    = NewMockPV()
+		valSet = NewValidatorSet([]*Validator{val.ExtractIntoValidator(10)})
+		params = EvidenceValidationParams{
+			CurrentHeight:   15,
+			MaxAgeNumBlocks: 10,
+			MaxAgeDuration:  time.Hour,
+			Committed:       NewEvidenceSet(),
+		}
+		now = evTime.Add(time.Minute)
+	)
+
+	committed := NewMockDuplicateVoteEvidenceWithValidator(10, evTime, val, chainID)
+	params.Committed.Add(committed)
+
+	testCases := []struct {
+		name     string
+		malleate func() Evidence
+		now      time.Time
+		expCode  EvidenceErrorCode
+	}{
+		{"valid", func() Evidence {
+			return NewMockDuplicateVoteEvidenceWithValidator(10, evTime, val, chainID)
+		}, now, 0},
+		{"invalid", func() Evidence {
+			ev := NewMockDuplicateVoteEvidenceWithValidator(10, evTime, val, chainID)
+			ev.VoteA, ev.VoteB = ev.VoteB, ev.VoteA
+			return ev
+		}, now, CodeInvalid},
+		{"composite", func() Evidence {
+			return &ConflictingHeadersEvidence{}
+		}, now, CodeInvalid},
+		{"expired", func() Evidence {
+			return NewMockDuplicateVoteEvidenceWithValidator(1, evTime, val, chainID)
+		}, evTime.Add(2 * time.Hour), CodeExpired},
+		{"invalid signature", func() Evidence {
+			ev := NewMockDuplicateVoteEvidenceWithValidator(10, evTime, val, chainID)
+			ev.VoteB.Signature = ev.VoteA.Signature
+			return ev
+		}, now, CodeInvalidSignature},
+		{"wrong chain ID", func() Evidence {
+			return NewMockDuplicateVoteEvidenceWithValidator(10, evTime, val, "otherchain")
+		}, now, CodeInvalidSignature},
+		{"duplicate", func() Evidence {
+			return committed
+		}, now, CodeDuplicate},
+		{"unknown validator", func() Evidence {
+			return NewMockDuplicateVoteEvidence(10, evTime, chainID)
+		}, now, CodeUnknownValidator},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			evErr := ValidateEvidence(tc.malleate(), chainID, valSet, tc.now, params)
+			if tc.expCode == 0 {
+				assert.Nil(t, evErr)
+				return
+			}
+			require.NotNil(t, evErr)
+			assert.Equal(t, tc.expCode, evErr.Code)
+			assert.NotEmpty(t, evErr.Message)
+		})
+	}
+}
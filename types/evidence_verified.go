@@ -0,0 +1,64 @@
+package types
+
+import (
+	"bytes"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// VerifiedEvidence wraps evidence, memoizing its successful verification so
+// that it's verified only once across the reactor and block validation.
+//
+// VerifiedEvidence is safe for concurrent use.
+type VerifiedEvidence struct {
+	Evidence
+
+	mtx      tmsync.Mutex
+	verified bool
+	// chain ID, evidence hash and public key of the accused validator the
+	// evidence was verified for
+	chainID string
+	evHash  []byte
+	pubKey  crypto.PubKey
+}
+
+// NewVerifiedEvidence returns ev wrapped in a VerifiedEvidence, which hasn't
+// been verified yet.
+func NewVerifiedEvidence(ev Evidence) *VerifiedEvidence {
+	return &VerifiedEvidence{Evidence: ev}
+}
+
+// EnsureVerified validates and verifies the evidence against the public key
+// of the implicated validator in valSet, unless it was already successfully
+// verified for the same chain ID, evidence (by hash) and public key, which are
+// all that determine the result. Failures aren't cached.
+//
+// Composite evidence must be split first (see CompositeEvidence).
+func (ve *VerifiedEvidence) EnsureVerified(chainID string, valSet *ValidatorSet) error {
+	ve.mtx.Lock()
+	defer ve.mtx.Unlock()
+
+	evHash := ve.Evidence.Hash()
+	_, val := valSet.GetByAddress(ve.Evidence.Address())
+	if ve.verified && val != nil && ve.chainID == chainID && bytes.Equal(ve.evHash, evHash) &&
+		ve.pubKey.Equals(val.PubKey) {
+		return nil
+	}
+
+	ve.verified = false
+	if err := verifyEvidence(ve.Evidence, chainID, valSet); err != nil {
+		return err
+	}
+	ve.verified, ve.chainID, ve.evHash, ve.pubKey = true, chainID, evHash, val.PubKey
+	return nil
+}
+
+// Verified returns true if the evidence was successfully verified by the last
+// call to EnsureVerified.
+func (ve *VerifiedEvidence) Verified() bool {
+	ve.mtx.Lock()
+	defer ve.mtx.Unlock()
+
+	return ve.verified
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// countingEvidence counts the calls to Verify.
+type countingEvidence struct {
+	*DuplicateVoteEvidence
+	calls int
+}
+
+func (ce *countingEvidence) Verify(chainID string, pubKey crypto.PubKey) error {
+	ce.calls++
+	return ce.DuplicateVoteEvidence.Verify(chainID, pubKey)
+}
+
+func TestVerifiedEvidence(t *testing.T) {
+	const chainID = "mychain"
+	val := NewMockPV()
+	valSet := NewValidatorSet([]*Validator{val.ExtractIntoValidator(10)})
+	ev := &countingEvidence{
+		DuplicateVoteEvidence: NewMockDuplicateVoteEvidenceWithValidator(10, defaultVoteTime, val, chainID),
+	}
+
+	ve := NewVerifiedEvidence(ev)
+	assert.False(t, ve.Verified())
+	require.NoError(t, ve.EnsureVerified(chainID, valSet))
+	require.NoError(t, ve.EnsureVerified(chainID, valSet))
+	assert.True(t, ve.Verified())
+	assert.Equal(t, 1, ev.calls)
+
+	// another chain ID: verified again, and failures aren't cached
+	assert.Error(t, ve.EnsureVerified("otherchain", valSet))
+	assert.False(t, ve.Verified())
+	assert.Error(t, ve.EnsureVerified("otherchain", valSet))
+	assert.Equal(t, 3, ev.calls)
+
+	require.NoError(t, ve.EnsureVerified(chainID, valSet))
+	assert.Equal(t, 4, ev.calls)
+
+	// another validator set with the same validator: the memo is keyed on its
+	// public key, not on the hash of the set, which isn't computed
+	otherVal := NewMockPV()
+	otherValSet := NewValidatorSet([]*Validator{val.ExtractIntoValidator(10), otherVal.ExtractIntoValidator(10)})
+	require.NoError(t, ve.EnsureVerified(chainID, otherValSet))
+	assert.Equal(t, 4, ev.calls)
+	assert.Nil(t, otherValSet.hash.Load())
+
+	// a validator set without the validator
+	assert.Error(t, ve.EnsureVerified(chainID, NewValidatorSet([]*Validator{otherVal.ExtractIntoValidator(10)})))
+	assert.False(t, ve.Verified())
+	require.NoError(t, ve.EnsureVerified(chainID, valSet))
+	assert.Equal(t, 5, ev.calls)
+
+	// other evidence
+	ev.DuplicateVoteEvidence = NewMockDuplicateVoteEvidenceWithValidator(11, defaultVoteTime, val, chainID)
+	require.NoError(t, ve.EnsureVerified(chainID, valSet))
+	assert.Equal(t, 6, ev.calls)
+}
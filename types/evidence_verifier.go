@@ -0,0 +1,151 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrEvidenceVerifierFull is returned by EvidenceVerifier.VerifyAsync when
+	// its queue is full.
+	ErrEvidenceVerifierFull = errors.New("evidence verification queue is full")
+	// ErrEvidenceVerifierStopped is returned by EvidenceVerifier.VerifyAsync
+	// once the verifier is stopped.
+	ErrEvidenceVerifierStopped = errors.New("evidence verifier is stopped")
+)
+
+// EvidenceVerifier verifies evidence concurrently on a fixed number of
+// workers, with a bounded queue of pending evidence, so that a flood of
+// evidence doesn't block its receiver nor exhaust the CPU or memory.
+//
+// EvidenceVerifier is safe for concurrent use.
+type EvidenceVerifier struct {
+	queue chan evidenceVerification
+	quit  chan struct{}
+
+	mtx     sync.RWMutex // protects stopped, see VerifyAsync and Stop
+	stopped bool
+}
+
+type evidenceVerification struct {
+	ev      Evidence
+	chainID string
+	valSet  *ValidatorSet
+	res     chan error
+}
+
+// NewEvidenceVerifier returns an EvidenceVerifier running workers
+// verifications at the same time, with up to queueSize evidence waiting for
+// a worker. The workers run until Stop is called.
+// Panics if workers or queueSize is not positive.
+func NewEvidenceVerifier(workers, queueSize int) *EvidenceVerifier {
+	if workers <= 0 {
+		panic("workers must be positive")
+	}
+	if queueSize <= 0 {
+		panic("queueSize must be positive")
+	}
+	evr := &EvidenceVerifier{
+		queue: make(chan evidenceVerification, queueSize),
+		quit:  make(chan struct{}),
+	}
+	for i := 0; i < workers; i++ {
+		go evr.work()
+	}
+	return evr
+}
+
+func (evr *EvidenceVerifier) work() {
+	for {
+		select {
+		case v := <-evr.queue:
+			v.res <- verifyEvidence(v.ev, v.chainID, v.valSet)
+			close(v.res)
+		case <-evr.quit:
+			return
+		}
+	}
+}
+
+// VerifyAsync queues the evidence for verification: a worker validates it,
+// looks up the implicated validator in valSet and checks the evidence against
+// its public key. The returned channel receives the result (nil if the
+// evidence is valid) and is then closed.
+//
+// VerifyAsync doesn't block: if the queue is full, the channel receives
+// ErrEvidenceVerifierFull right away, and ErrEvidenceVerifierStopped once the
+// verifier is stopped.
+//
+// Composite evidence must be split first (see CompositeEvidence).
+func (evr *EvidenceVerifier) VerifyAsync(ev Evidence, chainID string, valSet *ValidatorSet) <-chan error {
+	res := make(chan error, 1)
+
+	evr.mtx.RLock()
+	defer evr.mtx.RUnlock()
+	if evr.stopped {
+		res <- ErrEvidenceVerifierStopped
+		close(res)
+		return res
+	}
+	select {
+	case evr.queue <- evidenceVerification{ev: ev, chainID: chainID, valSet: valSet, res: res}:
+	default:
+		res <- ErrEvidenceVerifierFull
+		close(res)
+	}
+
+	return res
+}
+
+// Stop stops the workers. The evidence still waiting in the queue isn't
+// verified: its channels receive ErrEvidenceVerifierStopped. Stop doesn't wait
+// for the running verifications to finish.
+func (evr *EvidenceVerifier) Stop() {
+	evr.mtx.Lock()
+	defer evr.mtx.Unlock()
+	if evr.stopped {
+		return
+	}
+	evr.stopped = true
+	close(evr.quit)
+	for {
+		select {
+		case v := <-evr.queue:
+			v.res <- ErrEvidenceVerifierStopped
+			close(v.res)
+		default:
+			return
+		}
+	}
+}
+
+func verifyEvidence(ev Evidence, chainID string, valSet *ValidatorSet) error {
+	if _, ok := ev.(CompositeEvidence); ok {
+		return fmt.Errorf("composite evidence %v must be split first", ev)
+	}
+	if err := ev.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid evidence: %w", err)
+	}
+	_, err := VerifyEvidenceWithValidatorSet(ev, chainID, valSet)
+	return err
+}
+
+// VerifyEvidenceWithValidatorSet looks up the validator accused by the
+// evidence in valSet, the validator set at the evidence height, and verifies
+// the evidence against its public key. It returns the validator, e.g. for its
+// voting power.
+//
+// ErrEvidenceUnknownValidator is returned if the validator is not in valSet.
+// Composite evidence must be split first (see CompositeEvidence).
+func VerifyEvidenceWithValidatorSet(ev Evidence, chainID string, valSet *ValidatorSet) (*Validator, error) {
+	addr := ev.Address()
+	_, val := valSet.GetByAddress(addr)
+	if val == nil {
+		return nil, fmt.Errorf("address %X was %w at height %d", addr, ErrEvidenceUnknownValidator, ev.Height())
+	}
+	if err := ev.Verify(chainID, val.PubKey); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
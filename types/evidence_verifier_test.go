@@ -0,0 +1,195 @@
+package types
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// concurrencyPubKey records the maximum number of concurrent signature
+// verifications.
+type concurrencyPubKey struct {
+	crypto.PubKey
+	cur, max *int32
+}
+
+func (pk concurrencyPubKey) VerifySignature(msg, sig []byte) bool {
+	n := atomic.AddInt32(pk.cur, 1)
+	defer atomic.AddInt32(pk.cur, -1)
+	for {
+		max := atomic.LoadInt32(pk.max)
+		if n <= max || atomic.CompareAndSwapInt32(pk.max, max, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	return pk.PubKey.VerifySignature(msg, sig)
+}
+
+func TestEvidenceVerifier(t *testing.T) {
+	const (
+		chainID = "mychain"
+		workers = 3
+		n       = 100
+	)
+	var (
+		evTime   = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		cur, max int32
+		pvs      = []MockPV{NewMockPV(), NewMockPV(), NewMockPV(), NewMockPV()}
+		vals     = make([]*Validator, len(pvs))
+	)
+	for i, pv := range pvs {
+		vals[i] = pv.ExtractIntoValidator(10)
+		vals[i].PubKey = concurrencyPubKey{PubKey: vals[i].PubKey, cur: &cur, max: &max}
+	}
+	valSet := NewValidatorSet(vals)
+
+	evs := make([]Evidence, n)
+	valid := make([]bool, n)
+	for i := range evs {
+		ev := NewMockDuplicateVoteEvidenceWithValidator(int64(i+1), evTime, pvs[i%len(pvs)], chainID)
+		switch i % 3 {
+		case 0:
+			valid[i] = true
+		case 1: // bad signature
+			ev.VoteB.Signature = append([]byte(nil), ev.VoteB.Signature...)
+			ev.VoteB.Signature[0] ^= 0xff
+		case 2: // not a validator
+			ev = NewMockDuplicateVoteEvidence(int64(i+1), evTime, chainID)
+		}
+		evs[i] = ev
+	}
+
+	evr := NewEvidenceVerifier(workers, n)
+	defer evr.Stop()
+	var wg sync.WaitGroup
+	for i := range evs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := <-evr.VerifyAsync(evs[i], chainID, valSet)
+			if valid[i] {
+				assert.NoError(t, err, "evidence #%d", i)
+			} else {
+				assert.Error(t, err, "evidence #%d", i)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&max), int32(workers))
+	assert.Greater(t, atomic.LoadInt32(&max), int32(0))
+
+	assert.Panics(t, func() { NewEvidenceVerifier(0, n) })
+	assert.Panics(t, func() { NewEvidenceVerifier(workers, 0) })
+	assert.Error(t, <-evr.VerifyAsync(&ConflictingHeadersEvidence{}, chainID, valSet))
+}
+
+// blockingPubKey blocks signature verifications until release is closed.
+type blockingPubKey struct {
+	crypto.PubKey
+	release chan struct{}
+}
+
+func (pk blockingPubKey) VerifySignature(msg, sig []byte) bool {
+	<-pk.release
+	return pk.PubKey.VerifySignature(msg, sig)
+}
+
+func TestEvidenceVerifierQueue(t *testing.T) {
+	const (
+		chainID   = "mychain"
+		workers   = 2
+		queueSize = 3
+		n         = 100
+	)
+	evTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	pv := NewMockPV()
+	val := pv.ExtractIntoValidator(10)
+	release := make(chan struct{})
+	val.PubKey = blockingPubKey{PubKey: val.PubKey, release: release}
+	valSet := NewValidatorSet([]*Validator{val})
+
+	// more verifications than workers and queue slots, while none of them can
+	// finish: the number of goroutines stays bounded, and the evidence which
+	// doesn't fit in the queue is rejected without blocking
+	goroutines := runtime.NumGoroutine()
+	evr := NewEvidenceVerifier(workers, queueSize)
+	results := make([]<-chan error, n)
+	for i := range results {
+		ev := NewMockDuplicateVoteEvidenceWithValidator(int64(i+1), evTime, pv, chainID)
+		results[i] = evr.VerifyAsync(ev, chainID, valSet)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines+workers)
+
+	close(release)
+	verified := 0
+	for i, res := range results {
+		err := <-res
+		if err == nil {
+			verified++
+		} else {
+			assert.Equal(t, ErrEvidenceVerifierFull, err, "evidence #%d", i)
+		}
+	}
+	assert.GreaterOrEqual(t, verified, queueSize)
+	assert.LessOrEqual(t, verified, workers+queueSize)
+
+	// once stopped, evidence is rejected
+	evr.Stop()
+	evr.Stop()
+	ev := NewMockDuplicateVoteEvidenceWithValidator(1, evTime, pv, chainID)
+	assert.Equal(t, ErrEvidenceVerifierStopped, <-evr.VerifyAsync(ev, chainID, valSet))
+}
+
+func TestEvidenceVerifierStop(t *testing.T) {
+	const (
+		chainID = "mychain"
+		workers = 1
+	)
+	evTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	pv := NewMockPV()
+	val := pv.ExtractIntoValidator(10)
+	release := make(chan struct{})
+	val.PubKey = blockingPubKey{PubKey: val.PubKey, release: release}
+	valSet := NewValidatorSet([]*Validator{val})
+
+	// the worker blocks on the first evidence, so the second one is queued
+	// when the verifier is stopped
+	evr := NewEvidenceVerifier(workers, 1)
+	running := evr.VerifyAsync(NewMockDuplicateVoteEvidenceWithValidator(1, evTime, pv, chainID), chainID, valSet)
+	require.Eventually(t, func() bool { return len(evr.queue) == 0 }, time.Second, time.Millisecond)
+	queued := evr.VerifyAsync(NewMockDuplicateVoteEvidenceWithValidator(2, evTime, pv, chainID), chainID, valSet)
+
+	evr.Stop()
+	assert.Equal(t, ErrEvidenceVerifierStopped, <-queued)
+	close(release)
+	assert.NoError(t, <-running)
+}
+
+func TestVerifyEvidenceWithValidatorSet(t *testing.T) {
+	const chainID = "mychain"
+	evTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	pv := NewMockPV()
+	valSet := NewValidatorSet([]*Validator{pv.ExtractIntoValidator(10)})
+
+	val, err := VerifyEvidenceWithValidatorSet(
+		NewMockDuplicateVoteEvidenceWithValidator(1, evTime, pv, chainID), chainID, valSet)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, val.VotingPower)
+
+	_, err = VerifyEvidenceWithValidatorSet(NewMockDuplicateVoteEvidence(1, evTime, chainID), chainID, valSet)
+	assert.True(t, errors.Is(err, ErrEvidenceUnknownValidator), err)
+
+	_, err = VerifyEvidenceWithValidatorSet(
+		NewMockDuplicateVoteEvidenceWithValidator(1, evTime, pv, "otherchain"), chainID, valSet)
+	assert.Error(t, err)
+}
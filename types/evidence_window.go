@@ -0,0 +1,96 @@
+package types
+
+import (
+	"time"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// EvidenceWindow decides whether evidence received from a peer should be
+// accepted (and thus possibly re-broadcasted). It rejects expired evidence and
+// suppresses immediate resubmissions of the same evidence by remembering the
+// hashes of the last accepted evidence in a bounded ring buffer.
+//
+// Like the evidence pool, it considers evidence expired only once it's both
+// older than MaxAgeNumBlocks blocks and MaxAgeDuration.
+//
+// EvidenceWindow is safe for concurrent use.
+type EvidenceWindow struct {
+	maxAgeNumBlocks int64
+	maxAgeDuration  time.Duration
+
+	mtx     tmsync.Mutex
+	recent  []string            // ring buffer of hashes
+	next    int                 // next position to overwrite in recent
+	recentM map[string]struct{} // hashes in recent
+}
+
+// NewEvidenceWindow returns an EvidenceWindow rejecting evidence older than
+// maxAgeNumBlocks and maxAgeDuration (see EvidenceParams), which remembers
+// the hashes of the last recentSize accepted evidence.
+// Panics if recentSize is not positive.
+func NewEvidenceWindow(maxAgeNumBlocks int64, maxAgeDuration time.Duration, recentSize int) *EvidenceWindow {
+	if recentSize <= 0 {
+		panic("recentSize must be positive")
+	}
+	return &EvidenceWindow{
+		maxAgeNumBlocks: maxAgeNumBlocks,
+		maxAgeDuration:  maxAgeDuration,
+		recent:          make([]string, 0, recentSize),
+		recentM:         make(map[string]struct{}, recentSize),
+	}
+}
+
+// IsExpired returns true if the evidence is too old to be accepted at
+// currentHeight and now.
+func (ew *EvidenceWindow) IsExpired(ev Evidence, currentHeight int64, now time.Time) bool {
+	return isEvidenceExpired(ev, currentHeight, now, ew.maxAgeNumBlocks, ew.maxAgeDuration)
+}
+
+// IsEvidenceExpiredForBlock returns true if the evidence is too old to be
+// included in the block, i.e. older than both maxAgeNumBlocks blocks and
+// maxAgeDuration (see EvidenceParams) at the block's height. Its age is
+// measured against the block time, which is the BFT median time of the votes
+// committing the previous block, rather than the local clock, so that all
+// nodes agree on it.
+func IsEvidenceExpiredForBlock(ev Evidence, block *Block, maxAgeNumBlocks int64, maxAgeDuration time.Duration) bool {
+	return isEvidenceExpired(ev, block.Height, block.Time, maxAgeNumBlocks, maxAgeDuration)
+}
+
+func isEvidenceExpired(ev Evidence, currentHeight int64, now time.Time,
+	maxAgeNumBlocks int64, maxAgeDuration time.Duration) bool {
+	var (
+		ageNumBlocks = currentHeight - ev.Height()
+		ageDuration  = now.Sub(ev.Time())
+	)
+	return ageNumBlocks > maxAgeNumBlocks && ageDuration > maxAgeDuration
+}
+
+// ShouldAccept returns false if the evidence is expired or if it was accepted
+// recently. Otherwise, it records the evidence as recently accepted and
+// returns true.
+func (ew *EvidenceWindow) ShouldAccept(ev Evidence, currentHeight int64, now time.Time) bool {
+	if ew.IsExpired(ev, currentHeight, now) {
+		return false
+	}
+
+	hash := string(ev.Hash())
+
+	ew.mtx.Lock()
+	defer ew.mtx.Unlock()
+
+	if _, ok := ew.recentM[hash]; ok {
+		return false
+	}
+
+	if len(ew.recent) < cap(ew.recent) {
+		ew.recent = append(ew.recent, hash)
+	} else {
+		delete(ew.recentM, ew.recent[ew.next])
+		ew.recent[ew.next] = hash
+	}
+	ew.next = (ew.next + 1) % cap(ew.recent)
+	ew.recentM[hash] = struct{}{}
+
+	return true
+}
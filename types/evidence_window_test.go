@@ -0,0 +1,74 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvidenceWindow(t *testing.T) {
+	var (
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		ew     = NewEvidenceWindow(10, time.Hour, 2)
+		ev1    = NewMockDuplicateVoteEvidence(100, evTime, "mychain")
+		ev2    = NewMockDuplicateVoteEvidence(100, evTime, "mychain")
+		ev3    = NewMockDuplicateVoteEvidence(100, evTime, "mychain")
+		now    = evTime.Add(time.Minute)
+	)
+
+	// fresh evidence is accepted once
+	assert.True(t, ew.ShouldAccept(ev1, 101, now))
+	assert.False(t, ew.ShouldAccept(ev1, 101, now), "resubmission should be suppressed")
+	assert.True(t, ew.ShouldAccept(ev2, 101, now))
+	assert.False(t, ew.ShouldAccept(ev1, 101, now))
+
+	// ev1 is evicted from the ring buffer by ev3
+	assert.True(t, ew.ShouldAccept(ev3, 101, now))
+	assert.False(t, ew.ShouldAccept(ev2, 101, now))
+	assert.False(t, ew.ShouldAccept(ev3, 101, now))
+	assert.True(t, ew.ShouldAccept(ev1, 101, now))
+
+	// evidence is expired only if it's too old both in blocks and in time
+	ev4 := NewMockDuplicateVoteEvidence(100, evTime, "mychain")
+	later := evTime.Add(2 * time.Hour)
+	assert.False(t, ew.IsExpired(ev4, 110, later))
+	assert.False(t, ew.IsExpired(ev4, 111, now))
+	assert.True(t, ew.IsExpired(ev4, 111, later))
+	assert.False(t, ew.ShouldAccept(ev4, 111, later))
+	assert.True(t, ew.ShouldAccept(ev4, 110, later), "rejected expired evidence should not be recorded")
+
+	assert.Panics(t, func() { NewEvidenceWindow(10, time.Hour, 0) })
+}
+
+func TestIsEvidenceExpiredForBlock(t *testing.T) {
+	const (
+		maxAgeNumBlocks = int64(10)
+		maxAgeDuration  = time.Hour
+	)
+	var (
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		ev     = NewMockDuplicateVoteEvidence(100, evTime, "mychain")
+	)
+
+	testCases := []struct {
+		name        string
+		blockHeight int64
+		blockTime   time.Time
+		expired     bool
+	}{
+		{"inside both", 110, evTime.Add(maxAgeDuration), false},
+		{"outside by height only", 111, evTime.Add(maxAgeDuration), false},
+		{"outside by time only", 110, evTime.Add(maxAgeDuration + time.Nanosecond), false},
+		{"outside both", 111, evTime.Add(maxAgeDuration + time.Nanosecond), true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			block := MakeBlock(tc.blockHeight, []Tx{Tx("foo")}, nil, nil)
+			block.Time = tc.blockTime
+			assert.Equal(t, tc.expired,
+				IsEvidenceExpiredForBlock(ev, block, maxAgeNumBlocks, maxAgeDuration))
+		})
+	}
+}
@@ -65,6 +65,29 @@ func (genDoc *GenesisDoc) ValidatorHash() []byte {
 	return vset.Hash()
 }
 
+// NewValidatorSetFromGenesis returns the validator set made of the given
+// genesis validators. An error is returned if any of them has no pubkey, a
+// non-positive power or an address not matching its pubkey, if two of them
+// have the same address or if their total voting power exceeds
+// MaxTotalVotingPower (see TryNewValidatorSet).
+func NewValidatorSetFromGenesis(entries []GenesisValidator) (*ValidatorSet, error) {
+	vals := make([]*Validator, len(entries))
+	for i, v := range entries {
+		if v.PubKey == nil {
+			return nil, fmt.Errorf("genesis validator #%d (%q) has no pubkey", i, v.Name)
+		}
+		if v.Power <= 0 {
+			return nil, fmt.Errorf("genesis validator #%d (%q) must have a positive power, got %d", i, v.Name, v.Power)
+		}
+		if len(v.Address) > 0 && !bytes.Equal(v.PubKey.Address(), v.Address) {
+			return nil, fmt.Errorf("incorrect address for genesis validator #%d (%q), should be %v",
+				i, v.Name, v.PubKey.Address())
+		}
+		vals[i] = NewValidator(v.PubKey, v.Power)
+	}
+	return TryNewValidatorSet(vals)
+}
+
 // ValidateAndComplete checks that all necessary fields are present
 // and fills in defaults for optional fields left empty
 func (genDoc *GenesisDoc) ValidateAndComplete() error {
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmtime "github.com/tendermint/tendermint/types/time"
@@ -151,6 +152,57 @@ func TestGenesisValidatorHash(t *testing.T) {
 	assert.NotEmpty(t, genDoc.ValidatorHash())
 }
 
+func TestNewValidatorSetFromGenesis(t *testing.T) {
+	var (
+		pk1 = ed25519.GenPrivKey().PubKey()
+		pk2 = ed25519.GenPrivKey().PubKey()
+		pk3 = ed25519.GenPrivKey().PubKey()
+	)
+	entries := []GenesisValidator{
+		{PubKey: pk1, Power: 10, Name: "val1"},
+		{PubKey: pk2, Power: 30, Name: "val2"},
+		{Address: pk3.Address(), PubKey: pk3, Power: 20},
+	}
+
+	valSet, err := NewValidatorSetFromGenesis(entries)
+	require.NoError(t, err)
+	require.Equal(t, 3, valSet.Size())
+	// sorted by voting power
+	for i, pk := range []crypto.PubKey{pk2, pk3, pk1} {
+		assert.Equal(t, pk, valSet.Validators[i].PubKey)
+	}
+	assert.EqualValues(t, 60, valSet.TotalVotingPower())
+
+	// the hash doesn't depend on the order of the entries
+	reordered, err := NewValidatorSetFromGenesis([]GenesisValidator{entries[2], entries[0], entries[1]})
+	require.NoError(t, err)
+	assert.Equal(t, valSet.Hash(), reordered.Hash())
+	genDoc := &GenesisDoc{Validators: entries}
+	assert.Equal(t, genDoc.ValidatorHash(), valSet.Hash())
+
+	testCases := []struct {
+		name    string
+		entries []GenesisValidator
+	}{
+		{"zero power", []GenesisValidator{entries[0], {PubKey: pk2, Power: 0}}},
+		{"negative power", []GenesisValidator{{PubKey: pk2, Power: -1}}},
+		{"duplicate", []GenesisValidator{entries[0], entries[1], {PubKey: pk1, Power: 5}}},
+		{"nil pubkey", []GenesisValidator{{Power: 5}}},
+		{"wrong address", []GenesisValidator{{Address: pk1.Address(), PubKey: pk2, Power: 5}}},
+		{"total over the cap", []GenesisValidator{
+			{PubKey: pk1, Power: MaxTotalVotingPower},
+			{PubKey: pk2, Power: 1},
+		}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewValidatorSetFromGenesis(tc.entries)
+			assert.Error(t, err)
+		})
+	}
+}
+
 func randomGenesisDoc() *GenesisDoc {
 	pubkey := ed25519.GenPrivKey().PubKey()
 	return &GenesisDoc{
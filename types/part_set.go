@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/golang/snappy"
+
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
@@ -18,6 +20,7 @@ import (
 var (
 	ErrPartSetUnexpectedIndex = errors.New("error part set unexpected index")
 	ErrPartSetInvalidProof    = errors.New("error part set invalid proof")
+	ErrPartSetHeaderMismatch  = errors.New("error part set header mismatch")
 )
 
 type Part struct {
@@ -89,6 +92,47 @@ func PartFromProto(pb *tmproto.Part) (*Part, error) {
 	return part, part.ValidateBasic()
 }
 
+// Compressed returns the protobuf encoding of the part with its bytes
+// compressed using snappy, for sending over the wire. The proof still refers
+// to the uncompressed bytes, so neither it nor the PartSetHeader hash change.
+//
+// See PartFromCompressed.
+func (part *Part) Compressed() ([]byte, error) {
+	pb, err := part.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	pb.Bytes = snappy.Encode(nil, part.Bytes)
+	return pb.Marshal()
+}
+
+// PartFromCompressed decodes a part encoded by Part.Compressed. The part is
+// validated (see ValidateBasic), but not verified against its PartSetHeader.
+func PartFromCompressed(bz []byte) (*Part, error) {
+	pb := new(tmproto.Part)
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+
+	// check the size before decompressing, so that a small message can't make
+	// us allocate a lot of memory
+	n, err := snappy.DecodedLen(pb.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing part bytes: %w", err)
+	}
+	if n > int(BlockPartSizeBytes) {
+		return nil, fmt.Errorf("too big: %d bytes, max: %d", n, BlockPartSizeBytes)
+	}
+	if pb.Bytes, err = snappy.Decode(nil, pb.Bytes); err != nil {
+		return nil, fmt.Errorf("decompressing part bytes: %w", err)
+	}
+	if len(pb.Bytes) == 0 {
+		pb.Bytes = nil
+	}
+
+	return PartFromProto(pb)
+}
+
 //-------------------------------------
 
 type PartSetHeader struct {
@@ -118,6 +162,9 @@ func (psh PartSetHeader) ValidateBasic() error {
 	if err := ValidateHash(psh.Hash); err != nil {
 		return fmt.Errorf("wrong Hash: %w", err)
 	}
+	if len(psh.Hash) > 0 && psh.Total == 0 {
+		return errors.New("zero Total with non-empty Hash")
+	}
 	return nil
 }
 
@@ -223,6 +270,37 @@ func (ps *PartSet) BitArray() *bits.BitArray {
 	return ps.partsBitArray.Copy()
 }
 
+// RarestMissingPart returns the index of the part that is missing from this
+// PartSet and is held by the fewest of the given peers (but by at least one).
+// Ties are broken in favour of the lowest index. Requesting the rarest part
+// first speeds up reconstruction when peers come and go.
+//
+// Returns false if none of the peers has a part we are missing.
+func (ps *PartSet) RarestMissingPart(peerHas ...*bits.BitArray) (index int, ok bool) {
+	if ps == nil {
+		return 0, false
+	}
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	rarest := 0
+	for i := 0; i < int(ps.total); i++ {
+		if ps.partsBitArray.GetIndex(i) {
+			continue
+		}
+		holders := 0
+		for _, peer := range peerHas {
+			if peer.GetIndex(i) {
+				holders++
+			}
+		}
+		if holders > 0 && (!ok || holders < rarest) {
+			index, rarest, ok = i, holders, true
+		}
+	}
+	return index, ok
+}
+
 func (ps *PartSet) Hash() []byte {
 	if ps == nil {
 		return merkle.HashFromByteSlices(nil)
@@ -280,6 +358,40 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 	return true, nil
 }
 
+// Merge adds the parts of other which are missing from this PartSet, e.g. to
+// combine the parts received from different peers. Both must have the same
+// header (ErrPartSetHeaderMismatch otherwise), and every part is verified as
+// by AddPart. It returns the number of parts added, up to the first invalid
+// part, if any.
+func (ps *PartSet) Merge(other *PartSet) (added int, err error) {
+	if ps == nil || other == nil {
+		return 0, nil
+	}
+	if !ps.HasHeader(other.Header()) {
+		return 0, fmt.Errorf("%w: %v vs %v", ErrPartSetHeaderMismatch, ps.Header(), other.Header())
+	}
+
+	// copy the parts so that both sets are never locked at once
+	other.mtx.Lock()
+	parts := make([]*Part, len(other.parts))
+	copy(parts, other.parts)
+	other.mtx.Unlock()
+
+	for _, part := range parts {
+		if part == nil {
+			continue
+		}
+		ok, err := ps.AddPart(part)
+		if err != nil {
+			return added, fmt.Errorf("part #%d: %w", part.Index, err)
+		}
+		if ok {
+			added++
+		}
+	}
+	return added, nil
+}
+
 func (ps *PartSet) GetPart(index int) *Part {
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
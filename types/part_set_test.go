@@ -1,13 +1,17 @@
 package types
 
 import (
+	"bytes"
+	"errors"
 	"io/ioutil"
 	"testing"
 
+	"github.com/golang/snappy"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/bits"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 )
 
@@ -85,6 +89,130 @@ func TestWrongProof(t *testing.T) {
 	}
 }
 
+func TestPartCompressed(t *testing.T) {
+	// compressible data
+	data := bytes.Repeat([]byte("tendermint"), testPartSize/2)
+	partSet := NewPartSetFromData(data, testPartSize)
+	partSet2 := NewPartSetFromHeader(partSet.Header())
+
+	for i := 0; i < int(partSet.Total()); i++ {
+		part := partSet.GetPart(i)
+		bz, err := part.Compressed()
+		require.NoError(t, err)
+		assert.Less(t, len(bz), len(part.Bytes))
+
+		part2, err := PartFromCompressed(bz)
+		require.NoError(t, err)
+		assert.Equal(t, part, part2)
+		added, err := partSet2.AddPart(part2)
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+	assert.True(t, partSet2.IsComplete())
+
+	// not snappy
+	pb, err := partSet.GetPart(0).ToProto()
+	require.NoError(t, err)
+	bz, err := pb.Marshal()
+	require.NoError(t, err)
+	_, err = PartFromCompressed(bz)
+	assert.Error(t, err)
+
+	// too big
+	pb.Bytes = snappy.Encode(nil, make([]byte, BlockPartSizeBytes+1))
+	bz, err = pb.Marshal()
+	require.NoError(t, err)
+	_, err = PartFromCompressed(bz)
+	assert.Error(t, err)
+}
+
+func TestPartSetRarestMissingPart(t *testing.T) {
+	const total = 10
+	full := NewPartSetFromData(tmrand.Bytes(testPartSize*total), testPartSize)
+	partSet := NewPartSetFromHeader(full.Header())
+
+	_, ok := partSet.RarestMissingPart()
+	assert.False(t, ok, "no peers")
+
+	// we have every part except 3 and 7
+	for i := 0; i < total; i++ {
+		if i == 3 || i == 7 {
+			continue
+		}
+		added, err := partSet.AddPart(full.GetPart(i))
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	peerWithAll := full.BitArray()
+	peerWith3 := bits.NewBitArray(total)
+	peerWith3.SetIndex(3, true)
+	peerWithNone := bits.NewBitArray(total)
+
+	// part 7 is only held by one peer, part 3 by two
+	index, ok := partSet.RarestMissingPart(peerWithAll, peerWith3, peerWithNone)
+	require.True(t, ok)
+	assert.Equal(t, 7, index)
+
+	// neither peer has a part we are missing
+	_, ok = partSet.RarestMissingPart(peerWithNone, nil)
+	assert.False(t, ok)
+
+	// once we have part 7, part 3 is the only one left
+	added, err := partSet.AddPart(full.GetPart(7))
+	require.NoError(t, err)
+	require.True(t, added)
+	index, ok = partSet.RarestMissingPart(peerWithAll, peerWith3)
+	require.True(t, ok)
+	assert.Equal(t, 3, index)
+}
+
+func TestPartSetMerge(t *testing.T) {
+	data := tmrand.Bytes(testPartSize * 6)
+	partSet := NewPartSetFromData(data, testPartSize)
+	require.EqualValues(t, 6, partSet.Total())
+
+	// two disjoint halves
+	first := NewPartSetFromHeader(partSet.Header())
+	second := NewPartSetFromHeader(partSet.Header())
+	for i := 0; i < 6; i++ {
+		half := first
+		if i%2 == 1 {
+			half = second
+		}
+		_, err := half.AddPart(partSet.GetPart(i))
+		require.NoError(t, err)
+	}
+
+	added, err := first.Merge(second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, added)
+	assert.True(t, first.IsComplete())
+	data2, err := ioutil.ReadAll(first.GetReader())
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+
+	// nothing left to add
+	added, err = first.Merge(partSet)
+	require.NoError(t, err)
+	assert.Zero(t, added)
+	assert.EqualValues(t, 3, second.Count())
+
+	// different header
+	other := NewPartSetFromData(tmrand.Bytes(testPartSize*6), testPartSize)
+	added, err = second.Merge(other)
+	assert.True(t, errors.Is(err, ErrPartSetHeaderMismatch), err)
+	assert.Zero(t, added)
+	assert.EqualValues(t, 3, second.Count())
+
+	// invalid part
+	bad := NewPartSetFromHeader(partSet.Header())
+	bad.parts[0] = &Part{Index: 0, Bytes: []byte("bad"), Proof: partSet.GetPart(0).Proof}
+	added, err = second.Merge(bad)
+	assert.True(t, errors.Is(err, ErrPartSetInvalidProof), err)
+	assert.Zero(t, added)
+}
+
 func TestPartSetHeaderValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName              string
@@ -92,7 +220,9 @@ func TestPartSetHeaderValidateBasic(t *testing.T) {
 		expectErr             bool
 	}{
 		{"Good PartSet", func(psHeader *PartSetHeader) {}, false},
+		{"Empty PartSet", func(psHeader *PartSetHeader) { *psHeader = PartSetHeader{} }, false},
 		{"Invalid Hash", func(psHeader *PartSetHeader) { psHeader.Hash = make([]byte, 1) }, true},
+		{"Zero Total with Hash", func(psHeader *PartSetHeader) { psHeader.Total = 0 }, true},
 	}
 	for _, tc := range testCases {
 		tc := tc
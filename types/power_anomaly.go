@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	tmmath "github.com/tendermint/tendermint/libs/math"
+)
+
+// Anomaly describes a validator whose voting power changed by more than the
+// allowed fraction between two validator sets.
+type Anomaly struct {
+	Address   Address
+	PrevPower int64
+	NextPower int64
+}
+
+func (a Anomaly) String() string {
+	return fmt.Sprintf("Anomaly{%v: %d -> %d}", a.Address, a.PrevPower, a.NextPower)
+}
+
+// DetectPowerAnomaly returns the validators present in both prev and next
+// whose voting power changed by more than maxChangeFraction of their power in
+// prev, in the order of next. Validators which were added or removed are not
+// reported.
+//
+// Panics if maxChangeFraction is negative or its denominator is not positive.
+func DetectPowerAnomaly(prev, next *ValidatorSet, maxChangeFraction tmmath.Fraction) []Anomaly {
+	if maxChangeFraction.Numerator < 0 || maxChangeFraction.Denominator <= 0 {
+		panic(fmt.Sprintf("invalid maxChangeFraction %v", maxChangeFraction))
+	}
+
+	var (
+		anomalies []Anomaly
+		num       = big.NewInt(maxChangeFraction.Numerator)
+		denom     = big.NewInt(maxChangeFraction.Denominator)
+	)
+	for _, val := range next.Validators {
+		_, prevVal := prev.GetByAddress(val.Address)
+		if prevVal == nil {
+			continue
+		}
+
+		// |next - prev| * denom > prev * num, without overflowing int64
+		change := new(big.Int).Sub(big.NewInt(val.VotingPower), big.NewInt(prevVal.VotingPower))
+		change.Abs(change).Mul(change, denom)
+		allowed := new(big.Int).Mul(big.NewInt(prevVal.VotingPower), num)
+		if change.Cmp(allowed) > 0 {
+			anomalies = append(anomalies, Anomaly{
+				Address:   val.Address,
+				PrevPower: prevVal.VotingPower,
+				NextPower: val.VotingPower,
+			})
+		}
+	}
+	return anomalies
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	tmmath "github.com/tendermint/tendermint/libs/math"
+)
+
+func TestDetectPowerAnomaly(t *testing.T) {
+	var (
+		a       = newValidator([]byte("a"), 100)
+		b       = newValidator([]byte("b"), 100)
+		c       = newValidator([]byte("c"), 100)
+		prev    = NewValidatorSet([]*Validator{a, b, c})
+		quarter = tmmath.Fraction{Numerator: 1, Denominator: 4}
+	)
+
+	next := prev.Copy()
+	assert.NoError(t, next.UpdateWithChangeSet([]*Validator{
+		newValidator([]byte("a"), 200), // doubled
+		newValidator([]byte("b"), 80),  // within bounds
+		newValidator([]byte("c"), 0),   // removed
+		newValidator([]byte("d"), 500), // added
+	}))
+
+	assert.Equal(t, []Anomaly{{Address: a.Address, PrevPower: 100, NextPower: 200}},
+		DetectPowerAnomaly(prev, next, quarter))
+
+	// the limit is inclusive
+	assert.Empty(t, DetectPowerAnomaly(prev, next, tmmath.Fraction{Numerator: 1, Denominator: 1}))
+	assert.Len(t, DetectPowerAnomaly(prev, next, tmmath.Fraction{Numerator: 0, Denominator: 1}), 2)
+	assert.Empty(t, DetectPowerAnomaly(prev, prev, tmmath.Fraction{Numerator: 0, Denominator: 1}))
+
+	assert.Panics(t, func() { DetectPowerAnomaly(prev, next, tmmath.Fraction{Numerator: 1, Denominator: 0}) })
+}
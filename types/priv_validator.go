@@ -2,12 +2,19 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 // PrivValidator defines the functionality of a local Tendermint validator
@@ -19,6 +26,13 @@ type PrivValidator interface {
 	SignProposal(chainID string, proposal *tmproto.Proposal) error
 }
 
+// VoteExtensionSigner is implemented by private validators which can sign the
+// extension of a precommit (see Vote.Extension).
+type VoteExtensionSigner interface {
+	// SignVoteExtension sets the ExtensionSignature of the vote.
+	SignVoteExtension(chainID string, vote *Vote) error
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
@@ -53,17 +67,145 @@ type MockPV struct {
 	PrivKey              crypto.PrivKey
 	breakProposalSigning bool
 	breakVoteSigning     bool
+
+	// nil unless recording is enabled; shared between copies of the MockPV.
+	signed *signedMessages
+	// nil unless double sign protection is enabled; shared between copies of
+	// the MockPV.
+	lastSign *mockLastSignState
+}
+
+// signedMessages is a concurrent-safe, ordered record of the votes and
+// proposals signed by a MockPV.
+type signedMessages struct {
+	mtx  tmsync.Mutex
+	msgs []interface{}
+}
+
+func (sm *signedMessages) add(msg proto.Message) {
+	sm.mtx.Lock()
+	defer sm.mtx.Unlock()
+	sm.msgs = append(sm.msgs, proto.Clone(msg))
+}
+
+// mockLastSignState tracks the last height/round/step signed by a MockPV to
+// protect it against double signing.
+type mockLastSignState struct {
+	mtx       tmsync.Mutex
+	height    int64
+	round     int32
+	step      int8
+	signBytes []byte
+	signature []byte
+	timestamp time.Time
+}
+
+const (
+	mockStepPropose   int8 = 1
+	mockStepPrevote   int8 = 2
+	mockStepPrecommit int8 = 3
+)
+
+func mockVoteStep(vote *tmproto.Vote) int8 {
+	if vote.Type == tmproto.PrevoteType {
+		return mockStepPrevote
+	}
+	return mockStepPrecommit
+}
+
+// sign signs the message at height/round/step with the given timestamp, and
+// records it as the last signed message. signBytes returns the sign bytes of
+// the message with the given timestamp.
+//
+// Like FilePV, if a message was signed before at the same height/round/step,
+// it returns the signature and timestamp of that message if the two only
+// differ by their timestamps, and ErrVoteDoubleSign otherwise. It returns an
+// error if height/round/step is lower than the last signed one.
+func (lss *mockLastSignState) sign(privKey crypto.PrivKey, height int64, round int32, step int8,
+	timestamp time.Time, signBytes func(timestamp time.Time) []byte) ([]byte, time.Time, error) {
+	lss.mtx.Lock()
+	defer lss.mtx.Unlock()
+
+	if lss.signBytes != nil {
+		switch {
+		case height < lss.height ||
+			(height == lss.height && round < lss.round) ||
+			(height == lss.height && round == lss.round && step < lss.step):
+			return nil, time.Time{}, fmt.Errorf("height/round/step regression: got %d/%d/%d, last signed %d/%d/%d",
+				height, round, step, lss.height, lss.round, lss.step)
+		case height == lss.height && round == lss.round && step == lss.step:
+			if !bytes.Equal(signBytes(lss.timestamp), lss.signBytes) {
+				return nil, time.Time{}, fmt.Errorf("%w: conflicting data at %d/%d/%d",
+					ErrVoteDoubleSign, height, round, step)
+			}
+			return lss.signature, lss.timestamp, nil
+		}
+	}
+
+	bz := signBytes(timestamp)
+	sig, err := privKey.Sign(bz)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	lss.height, lss.round, lss.step = height, round, step
+	lss.signBytes, lss.signature, lss.timestamp = bz, sig, timestamp
+	return sig, timestamp, nil
 }
 
 func NewMockPV() MockPV {
-	return MockPV{ed25519.GenPrivKey(), false, false}
+	return MockPV{PrivKey: ed25519.GenPrivKey()}
+}
+
+// NewMockPVWithDoubleSignProtection returns a MockPV which, like FilePV,
+// refuses to sign a vote or proposal conflicting with the last one it signed
+// at the same height/round/step (returning ErrVoteDoubleSign), or one for an
+// earlier height/round/step. Signing the same message twice is allowed, and
+// if it only differs by timestamp, it gets the timestamp and signature of the
+// message signed first.
+//
+// The protection is per instance (and its copies): double signs can still be
+// produced using two MockPVs sharing a key.
+func NewMockPVWithDoubleSignProtection() MockPV {
+	return MockPV{PrivKey: ed25519.GenPrivKey(), lastSign: &mockLastSignState{}}
+}
+
+// NewRecordingMockPV returns a MockPV which records every vote and proposal
+// it signs. See SignedMessages.
+func NewRecordingMockPV() MockPV {
+	return MockPV{PrivKey: ed25519.GenPrivKey(), signed: &signedMessages{}}
+}
+
+// NewMockPVFromSeed returns a MockPV whose key is deterministically derived
+// from the seed, so that a whole validator set can be regenerated across runs.
+func NewMockPVFromSeed(seed int64) MockPV {
+	secret := make([]byte, 8)
+	binary.BigEndian.PutUint64(secret, uint64(seed))
+	return MockPV{PrivKey: ed25519.GenPrivKeyFromSecret(secret)}
 }
 
 // NewMockPVWithParams allows one to create a MockPV instance, but with finer
 // grained control over the operation of the mock validator. This is useful for
 // mocking test failures.
 func NewMockPVWithParams(privKey crypto.PrivKey, breakProposalSigning, breakVoteSigning bool) MockPV {
-	return MockPV{privKey, breakProposalSigning, breakVoteSigning}
+	return MockPV{
+		PrivKey:              privKey,
+		breakProposalSigning: breakProposalSigning,
+		breakVoteSigning:     breakVoteSigning,
+	}
+}
+
+// SignedMessages returns copies of the votes (*tmproto.Vote) and proposals
+// (*tmproto.Proposal) signed so far, in signing order. It returns nil if the
+// MockPV was not created with NewRecordingMockPV.
+func (pv MockPV) SignedMessages() []interface{} {
+	if pv.signed == nil {
+		return nil
+	}
+	pv.signed.mtx.Lock()
+	defer pv.signed.mtx.Unlock()
+	msgs := make([]interface{}, len(pv.signed.msgs))
+	copy(msgs, pv.signed.msgs)
+	return msgs
 }
 
 // Implements PrivValidator.
@@ -78,12 +220,42 @@ func (pv MockPV) SignVote(chainID string, vote *tmproto.Vote) error {
 		useChainID = "incorrect-chain-id"
 	}
 
-	signBytes := VoteSignBytes(useChainID, vote)
-	sig, err := pv.PrivKey.Sign(signBytes)
+	var (
+		sig []byte
+		err error
+	)
+	if pv.lastSign != nil {
+		sig, vote.Timestamp, err = pv.lastSign.sign(pv.PrivKey, vote.Height, vote.Round, mockVoteStep(vote),
+			vote.Timestamp, func(timestamp time.Time) []byte {
+				v := *vote
+				v.Timestamp = timestamp
+				return VoteSignBytes(useChainID, &v)
+			})
+	} else {
+		sig, err = pv.PrivKey.Sign(VoteSignBytes(useChainID, vote))
+	}
 	if err != nil {
 		return err
 	}
 	vote.Signature = sig
+	if pv.signed != nil {
+		pv.signed.add(vote)
+	}
+	return nil
+}
+
+// Implements VoteExtensionSigner.
+func (pv MockPV) SignVoteExtension(chainID string, vote *Vote) error {
+	useChainID := chainID
+	if pv.breakVoteSigning {
+		useChainID = "incorrect-chain-id"
+	}
+
+	sig, err := pv.PrivKey.Sign(VoteExtensionSignBytes(useChainID, vote))
+	if err != nil {
+		return err
+	}
+	vote.ExtensionSignature = sig
 	return nil
 }
 
@@ -94,15 +266,72 @@ func (pv MockPV) SignProposal(chainID string, proposal *tmproto.Proposal) error
 		useChainID = "incorrect-chain-id"
 	}
 
-	signBytes := ProposalSignBytes(useChainID, proposal)
-	sig, err := pv.PrivKey.Sign(signBytes)
+	var (
+		sig []byte
+		err error
+	)
+	if pv.lastSign != nil {
+		sig, proposal.Timestamp, err = pv.lastSign.sign(pv.PrivKey, proposal.Height, proposal.Round, mockStepPropose,
+			proposal.Timestamp, func(timestamp time.Time) []byte {
+				p := *proposal
+				p.Timestamp = timestamp
+				return ProposalSignBytes(useChainID, &p)
+			})
+	} else {
+		sig, err = pv.PrivKey.Sign(ProposalSignBytes(useChainID, proposal))
+	}
 	if err != nil {
 		return err
 	}
 	proposal.Signature = sig
+	if pv.signed != nil {
+		pv.signed.add(proposal)
+	}
 	return nil
 }
 
+// SignVoteSequence signs count prevote/precommit pairs for consecutive
+// heights at round 0 and returns the 2*count votes in signing order. Each pair
+// is for a block ID derived from its height. The sequence starts at height 1,
+// or right after the last signed height if the MockPV has double sign
+// protection. Panics if signing fails.
+func (pv MockPV) SignVoteSequence(chainID string, count int) []*Vote {
+	pubKey, _ := pv.GetPubKey()
+	startHeight := int64(1)
+	if pv.lastSign != nil {
+		pv.lastSign.mtx.Lock()
+		startHeight = pv.lastSign.height + 1
+		pv.lastSign.mtx.Unlock()
+	}
+
+	votes := make([]*Vote, 0, 2*count)
+	for height := startHeight; height < startHeight+int64(count); height++ {
+		blockHash := tmhash.Sum([]byte(fmt.Sprintf("block %d", height)))
+		blockID := BlockID{
+			Hash:          blockHash,
+			PartSetHeader: PartSetHeader{Total: 1, Hash: tmhash.Sum(blockHash)},
+		}
+		for _, voteType := range []tmproto.SignedMsgType{tmproto.PrevoteType, tmproto.PrecommitType} {
+			vote := &Vote{
+				Type:             voteType,
+				Height:           height,
+				Round:            0,
+				BlockID:          blockID,
+				Timestamp:        tmtime.Now(),
+				ValidatorAddress: pubKey.Address(),
+				ValidatorIndex:   0,
+			}
+			v := vote.ToProto()
+			if err := pv.SignVote(chainID, v); err != nil {
+				panic(fmt.Sprintf("failed to sign vote %v: %v", vote, err))
+			}
+			vote.Signature = v.Signature
+			votes = append(votes, vote)
+		}
+	}
+	return votes
+}
+
 func (pv MockPV) ExtractIntoValidator(votingPower int64) *Validator {
 	pubKey, _ := pv.GetPubKey()
 	return &Validator{
@@ -143,5 +372,5 @@ func (pv *ErroringMockPV) SignProposal(chainID string, proposal *tmproto.Proposa
 // NewErroringMockPV returns a MockPV that fails on each signing request. Again, for testing only.
 
 func NewErroringMockPV() *ErroringMockPV {
-	return &ErroringMockPV{MockPV{ed25519.GenPrivKey(), false, false}}
+	return &ErroringMockPV{MockPV{PrivKey: ed25519.GenPrivKey()}}
 }
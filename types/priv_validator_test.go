@@ -0,0 +1,173 @@
+package types
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestNewMockPVFromSeed(t *testing.T) {
+	pv := NewMockPVFromSeed(42)
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	pubKey2, err := NewMockPVFromSeed(42).GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, pubKey, pubKey2)
+	assert.Equal(t, pubKey.Address(), pubKey2.Address())
+
+	pubKey3, err := NewMockPVFromSeed(43).GetPubKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, pubKey.Address(), pubKey3.Address())
+}
+
+func TestMockPVSignedMessages(t *testing.T) {
+	const chainID = "test_chain_id"
+
+	assert.Nil(t, NewMockPV().SignedMessages(), "recording is disabled by default")
+
+	pv := NewRecordingMockPV()
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	vote1 := examplePrecommit().ToProto()
+	vote1.ValidatorAddress = pubKey.Address()
+	vote2 := *vote1
+	vote2.BlockID.Hash = tmhash.Sum([]byte("another_blockID_hash"))
+	proposal := testProposal.ToProto()
+
+	require.NoError(t, pv.SignVote(chainID, vote1))
+	require.NoError(t, pv.SignVote(chainID, &vote2))
+	require.NoError(t, pv.SignProposal(chainID, proposal))
+
+	msgs := pv.SignedMessages()
+	require.Len(t, msgs, 3)
+	assert.Equal(t, vote1, msgs[0])
+	assert.Equal(t, &vote2, msgs[1])
+	assert.Equal(t, proposal, msgs[2])
+
+	// the recorded votes are a double sign
+	v1, err := VoteFromProto(msgs[0].(*tmproto.Vote))
+	require.NoError(t, err)
+	v2, err := VoteFromProto(msgs[1].(*tmproto.Vote))
+	require.NoError(t, err)
+	ev := NewDuplicateVoteEvidence(v1, v2, defaultVoteTime)
+	assert.NoError(t, ev.Verify(chainID, pubKey))
+
+	// recorded messages are copies
+	vote1.Signature = nil
+	assert.NotEmpty(t, pv.SignedMessages()[0].(*tmproto.Vote).Signature)
+}
+
+func TestMockPVSignedMessagesConcurrent(t *testing.T) {
+	const n = 10
+	pv := NewRecordingMockPV()
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, pv.SignVote("test_chain_id", examplePrecommit().ToProto()))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, pv.SignedMessages(), n)
+}
+
+func TestMockPVDoubleSignProtection(t *testing.T) {
+	const chainID = "test_chain_id"
+	pv := NewMockPVWithDoubleSignProtection()
+
+	vote := examplePrecommit().ToProto()
+	require.NoError(t, pv.SignVote(chainID, vote))
+	sig := vote.Signature
+
+	// signing the identical vote again is fine
+	again := examplePrecommit().ToProto()
+	require.NoError(t, pv.SignVote(chainID, again))
+	assert.Equal(t, sig, again.Signature)
+
+	// like FilePV, a vote only differing by its timestamp gets the timestamp
+	// and signature of the first one
+	later := examplePrecommit().ToProto()
+	later.Timestamp = vote.Timestamp.Add(time.Second)
+	require.NoError(t, pv.SignVote(chainID, later))
+	assert.Equal(t, vote.Timestamp, later.Timestamp)
+	assert.Equal(t, sig, later.Signature)
+
+	// a different vote at the same height/round/step is refused
+	conflicting := examplePrecommit().ToProto()
+	conflicting.BlockID.Hash = tmhash.Sum([]byte("another_blockID_hash"))
+	err := pv.SignVote(chainID, conflicting)
+	assert.True(t, errors.Is(err, ErrVoteDoubleSign), err)
+	assert.Empty(t, conflicting.Signature)
+
+	// copies share the protection
+	pvCopy := pv
+	err = pvCopy.SignVote(chainID, conflicting)
+	assert.True(t, errors.Is(err, ErrVoteDoubleSign), err)
+
+	// the next round is fine, going back isn't
+	nextRound := examplePrecommit().ToProto()
+	nextRound.Round++
+	nextRound.BlockID.Hash = conflicting.BlockID.Hash
+	require.NoError(t, pv.SignVote(chainID, nextRound))
+	assert.Error(t, pv.SignVote(chainID, examplePrecommit().ToProto()))
+
+	// proposals are protected too
+	proposal := testProposal.ToProto()
+	require.NoError(t, pv.SignProposal(chainID, proposal))
+	conflictingProposal := testProposal.ToProto()
+	conflictingProposal.BlockID.Hash = tmhash.Sum([]byte("another_blockID_hash"))
+	err = pv.SignProposal(chainID, conflictingProposal)
+	assert.True(t, errors.Is(err, ErrVoteDoubleSign), err)
+	laterProposal := testProposal.ToProto()
+	laterProposal.Timestamp = proposal.Timestamp.Add(time.Second)
+	require.NoError(t, pv.SignProposal(chainID, laterProposal))
+	assert.Equal(t, proposal.Timestamp, laterProposal.Timestamp)
+	assert.Equal(t, proposal.Signature, laterProposal.Signature)
+
+	// the protection is per instance
+	pv2 := NewMockPVWithParams(pv.PrivKey, false, false)
+	assert.NoError(t, pv2.SignVote(chainID, conflicting))
+}
+
+func TestMockPVSignVoteSequence(t *testing.T) {
+	const chainID = "test_chain_id"
+	pv := NewMockPVWithDoubleSignProtection()
+	pubKey, err := pv.GetPubKey()
+	require.NoError(t, err)
+
+	votes := pv.SignVoteSequence(chainID, 3)
+	require.Len(t, votes, 6)
+	for i, vote := range votes {
+		assert.EqualValues(t, i/2+1, vote.Height)
+		if i%2 == 0 {
+			assert.Equal(t, tmproto.PrevoteType, vote.Type)
+		} else {
+			assert.Equal(t, tmproto.PrecommitType, vote.Type)
+			assert.Equal(t, votes[i-1].BlockID, vote.BlockID)
+		}
+		assert.NoError(t, vote.ValidateBasic())
+		assert.NoError(t, vote.Verify(chainID, pubKey))
+	}
+
+	// the next sequence continues after the last signed height
+	votes = pv.SignVoteSequence(chainID, 1)
+	require.Len(t, votes, 2)
+	assert.EqualValues(t, 4, votes[0].Height)
+
+	// going back is refused
+	old := examplePrecommit().ToProto()
+	old.Height = 1
+	assert.Error(t, pv.SignVote(chainID, old))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/tendermint/tendermint/crypto"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/protoio"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -14,6 +15,7 @@ import (
 var (
 	ErrInvalidBlockPartSignature = errors.New("error invalid block part signature")
 	ErrInvalidBlockPartHash      = errors.New("error invalid block part hash")
+	ErrProposalInvalidSignature  = errors.New("invalid proposal signature")
 )
 
 // Proposal defines a block proposal for the consensus.
@@ -79,6 +81,18 @@ func (p *Proposal) ValidateBasic() error {
 	return nil
 }
 
+// Verify returns ErrProposalInvalidSignature if the proposal is not signed by
+// pubKey for the given chain.
+//
+// NOTE: unlike votes, proposals don't carry the proposer's address: it's up
+// to the caller to pass the pubkey of the proposer for the proposal's H/R.
+func (p *Proposal) Verify(chainID string, pubKey crypto.PubKey) error {
+	if !pubKey.VerifySignature(ProposalSignBytes(chainID, p.ToProto()), p.Signature) {
+		return ErrProposalInvalidSignature
+	}
+	return nil
+}
+
 // String returns a string representation of the Proposal.
 //
 // 1. height
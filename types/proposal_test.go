@@ -1,7 +1,9 @@
 package types
 
 import (
+	"encoding/hex"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -46,6 +48,17 @@ func TestProposalSignable(t *testing.T) {
 	require.Equal(t, expected, signBytes, "Got unexpected sign bytes for Proposal")
 }
 
+// TestProposalSignBytesGolden pins the sign bytes of testProposal, so that
+// signers in other languages can check their canonical encoding against it.
+func TestProposalSignBytesGolden(t *testing.T) {
+	const want = "8601082011393000000000000019A05B00000000000020FFFFFFFFFFFFFFFFFF012A480A202D2D4A756E655F" +
+		"31355F323032305F616D696E6F5F7761735F72656D6F7665641224086F12202D2D4A756E655F31355F32303230" +
+		"5F616D696E6F5F7761735F72656D6F766564320C08A2D8FFD30510C0F2E3EC023A0D746573745F636861696E5F6964"
+
+	got := ProposalSignBytes("test_chain_id", pbp)
+	assert.Equal(t, want, strings.ToUpper(hex.EncodeToString(got)))
+}
+
 func TestProposalString(t *testing.T) {
 	str := testProposal.String()
 	expected := `Proposal{12345/23456 (2D2D4A756E655F31355F323032305F616D696E6F5F7761735F72656D6F766564:111:2D2D4A756E65, -1) 000000000000 @ 2018-02-11T07:09:22.765Z}` //nolint:lll // ignore line length for tests
@@ -94,6 +107,69 @@ func TestProposalVerifySignature(t *testing.T) {
 	require.True(t, valid)
 }
 
+func TestProposalVerify(t *testing.T) {
+	privVal := NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	prop := NewProposal(
+		4, 2, 2,
+		BlockID{tmrand.Bytes(tmhash.Size), PartSetHeader{777, tmrand.Bytes(tmhash.Size)}})
+	p := prop.ToProto()
+	require.NoError(t, privVal.SignProposal("test_chain_id", p))
+	prop.Signature = p.Signature
+
+	assert.NoError(t, prop.Verify("test_chain_id", pubKey))
+	assert.Equal(t, ErrProposalInvalidSignature, prop.Verify("other_chain_id", pubKey))
+
+	otherPubKey, err := NewMockPV().GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, ErrProposalInvalidSignature, prop.Verify("test_chain_id", otherPubKey))
+
+	prop.POLRound = -1
+	assert.Equal(t, ErrProposalInvalidSignature, prop.Verify("test_chain_id", pubKey))
+}
+
+// TestProposalSignatureIsNotAVoteSignature checks that the Type field of the
+// canonical sign bytes separates the proposal and vote domains: a signature
+// of one never verifies as the other for the same chain ID, height and round.
+func TestProposalSignatureIsNotAVoteSignature(t *testing.T) {
+	const chainID = "test_chain_id"
+	privVal := NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	for _, polRound := range []int32{-1, 0, 1} {
+		prop := NewProposal(4, 2, polRound, makeBlockIDRandom())
+		p := prop.ToProto()
+		require.NoError(t, privVal.SignProposal(chainID, p))
+		prop.Signature = p.Signature
+		require.NoError(t, prop.Verify(chainID, pubKey))
+
+		for _, voteType := range []tmproto.SignedMsgType{tmproto.PrevoteType, tmproto.PrecommitType, tmproto.ProposalType} {
+			vote := &Vote{
+				Type:             voteType,
+				Height:           prop.Height,
+				Round:            prop.Round,
+				BlockID:          prop.BlockID,
+				Timestamp:        prop.Timestamp,
+				ValidatorAddress: pubKey.Address(),
+				Signature:        prop.Signature,
+			}
+			assert.Equal(t, ErrVoteInvalidSignature, vote.Verify(chainID, pubKey),
+				"proposal signature verified as vote of type %v (POLRound %d)", voteType, polRound)
+
+			// and vice versa
+			v := vote.ToProto()
+			require.NoError(t, privVal.SignVote(chainID, v))
+			prop.Signature = v.Signature
+			assert.Equal(t, ErrProposalInvalidSignature, prop.Verify(chainID, pubKey),
+				"vote of type %v signature verified as proposal (POLRound %d)", voteType, polRound)
+			prop.Signature = p.Signature
+		}
+	}
+}
+
 func BenchmarkProposalWriteSignBytes(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		ProposalSignBytes("test_chain_id", pbp)
@@ -82,6 +82,31 @@ func TestABCIEvidence(t *testing.T) {
 	assert.Equal(t, ev.Height(), abciEv.GetHeight())
 }
 
+func TestABCIEvidenceValidatorPower(t *testing.T) {
+	const chainID = "mychain"
+	var (
+		evTime = defaultVoteTime
+		pv1    = NewMockPV()
+		pv2    = NewMockPV()
+		valSet = NewValidatorSet([]*Validator{pv1.ExtractIntoValidator(10), pv2.ExtractIntoValidator(30)})
+	)
+
+	for _, tc := range []struct {
+		pv    PrivValidator
+		power int64
+	}{{pv1, 10}, {pv2, 30}} {
+		ev := NewMockDuplicateVoteEvidenceWithValidator(7, evTime, tc.pv, chainID)
+		abciEv := TM2PB.Evidence(ev, valSet)
+
+		assert.Equal(t, ABCIEvidenceTypeDuplicateVote, abciEv.Type)
+		assert.Equal(t, ev.Address(), abciEv.Validator.Address)
+		assert.Equal(t, tc.power, abciEv.Validator.Power)
+		assert.EqualValues(t, 7, abciEv.Height)
+		assert.Equal(t, evTime, abciEv.Time)
+		assert.EqualValues(t, 40, abciEv.TotalVotingPower)
+	}
+}
+
 type pubKeyEddie struct{}
 
 func (pubKeyEddie) Address() Address                            { return []byte{} }
@@ -0,0 +1,97 @@
+package types
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// sigCacheKeySize is the size of the keys of the SignatureVerifier cache.
+const sigCacheKeySize = sha256.Size
+
+// SignatureVerifier verifies signatures and keeps a LRU cache of the ones
+// which were found to be valid, so that the same vote received from many peers
+// is only verified once.
+//
+// Only positive results are cached: an invalid signature is checked again
+// every time.
+//
+// SignatureVerifier is safe for concurrent use.
+type SignatureVerifier struct {
+	mtx      tmsync.Mutex
+	size     int
+	cacheMap map[[sigCacheKeySize]byte]*list.Element
+	list     *list.List
+}
+
+// NewSignatureVerifier returns a SignatureVerifier caching up to size valid
+// signatures.
+func NewSignatureVerifier(size int) *SignatureVerifier {
+	return &SignatureVerifier{
+		size:     size,
+		cacheMap: make(map[[sigCacheKeySize]byte]*list.Element, size),
+		list:     list.New(),
+	}
+}
+
+// Verify returns true if sig is a valid signature of msg by pubKey.
+func (sv *SignatureVerifier) Verify(pubKey crypto.PubKey, msg, sig []byte) bool {
+	key := sigCacheKey(pubKey, msg, sig)
+
+	sv.mtx.Lock()
+	if e, ok := sv.cacheMap[key]; ok {
+		sv.list.MoveToBack(e)
+		sv.mtx.Unlock()
+		return true
+	}
+	sv.mtx.Unlock()
+
+	if !pubKey.VerifySignature(msg, sig) {
+		return false
+	}
+
+	sv.push(key)
+	return true
+}
+
+func (sv *SignatureVerifier) push(key [sigCacheKeySize]byte) {
+	sv.mtx.Lock()
+	defer sv.mtx.Unlock()
+
+	if sv.size <= 0 {
+		return
+	}
+	if _, ok := sv.cacheMap[key]; ok {
+		return
+	}
+
+	if sv.list.Len() >= sv.size {
+		popped := sv.list.Front()
+		if popped != nil {
+			delete(sv.cacheMap, popped.Value.([sigCacheKeySize]byte))
+			sv.list.Remove(popped)
+		}
+	}
+	sv.cacheMap[key] = sv.list.PushBack(key)
+}
+
+// sigCacheKey hashes the (pubkey type, pubkey, msg, sig) tuple. The public key
+// is used rather than its address, which could collide across key types, and
+// every part is length-prefixed so that different tuples can't produce the
+// same key.
+func sigCacheKey(pubKey crypto.PubKey, msg, sig []byte) [sigCacheKeySize]byte {
+	h := sha256.New()
+	for _, bz := range [][]byte{[]byte(pubKey.Type()), pubKey.Bytes(), msg, sig} {
+		var lenBz [8]byte
+		binary.BigEndian.PutUint64(lenBz[:], uint64(len(bz)))
+		h.Write(lenBz[:])
+		h.Write(bz)
+	}
+
+	var key [sigCacheKeySize]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
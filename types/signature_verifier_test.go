@@ -0,0 +1,99 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+// countingPubKey counts the number of signatures it verifies.
+type countingPubKey struct {
+	crypto.PubKey
+	calls int
+}
+
+func (pk *countingPubKey) VerifySignature(msg, sig []byte) bool {
+	pk.calls++
+	return pk.PubKey.VerifySignature(msg, sig)
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	var (
+		sv      = NewSignatureVerifier(2)
+		privKey = ed25519.GenPrivKey()
+		pubKey  = &countingPubKey{PubKey: privKey.PubKey()}
+		msg     = []byte("vote sign bytes")
+	)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+
+	assert.True(t, sv.Verify(pubKey, msg, sig))
+	assert.True(t, sv.Verify(pubKey, msg, sig))
+	assert.Equal(t, 1, pubKey.calls, "second verification should hit the cache")
+
+	// corrupting the sig after a cached hit changes the cache key
+	badSig := copyBytes(sig)
+	badSig[0] ^= 0xff
+	assert.False(t, sv.Verify(pubKey, msg, badSig))
+	assert.False(t, sv.Verify(pubKey, msg, badSig))
+	assert.Equal(t, 3, pubKey.calls, "negative results must not be cached")
+
+	// evict the first entry
+	for _, m := range [][]byte{[]byte("msg1"), []byte("msg2")} {
+		s, err := privKey.Sign(m)
+		require.NoError(t, err)
+		assert.True(t, sv.Verify(pubKey, m, s))
+	}
+	calls := pubKey.calls
+	assert.True(t, sv.Verify(pubKey, msg, sig))
+	assert.Equal(t, calls+1, pubKey.calls, "evicted entry should be verified again")
+}
+
+// typedPubKey overrides the type of a public key.
+type typedPubKey struct {
+	crypto.PubKey
+	keyType string
+}
+
+func (pk typedPubKey) Type() string { return pk.keyType }
+
+func TestSigCacheKey(t *testing.T) {
+	pubKey := ed25519.GenPrivKey().PubKey()
+	key := sigCacheKey(pubKey, []byte("msg"), []byte("sig"))
+	assert.Equal(t, key, sigCacheKey(pubKey, []byte("msg"), []byte("sig")))
+
+	// the same bytes under another key type
+	assert.NotEqual(t, key, sigCacheKey(typedPubKey{pubKey, "other"}, []byte("msg"), []byte("sig")))
+	// another public key
+	assert.NotEqual(t, key, sigCacheKey(ed25519.GenPrivKey().PubKey(), []byte("msg"), []byte("sig")))
+	// the same bytes split differently between the parts
+	assert.NotEqual(t, key, sigCacheKey(pubKey, []byte("msgs"), []byte("ig")))
+	assert.NotEqual(t, key, sigCacheKey(pubKey, []byte("ms"), []byte("gsig")))
+}
+
+func BenchmarkSignatureVerifier(b *testing.B) {
+	privKey := ed25519.GenPrivKey()
+	pubKey := privKey.PubKey()
+	msg := []byte("vote sign bytes")
+	sig, err := privKey.Sign(msg)
+	require.NoError(b, err)
+
+	b.Run("no cache", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pubKey.VerifySignature(msg, sig)
+		}
+	})
+
+	b.Run("cache hit", func(b *testing.B) {
+		sv := NewSignatureVerifier(10)
+		sv.Verify(pubKey, msg, sig)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sv.Verify(pubKey, msg, sig)
+		}
+	})
+}
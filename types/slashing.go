@@ -0,0 +1,94 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	tmmath "github.com/tendermint/tendermint/libs/math"
+)
+
+// SlashingParams define the fraction of a validator's voting power to slash
+// for each type of evidence.
+type SlashingParams struct {
+	DuplicateVote     tmmath.Fraction `json:"duplicate_vote"`
+	DuplicateProposal tmmath.Fraction `json:"duplicate_proposal"`
+	LunaticValidator  tmmath.Fraction `json:"lunatic_validator"`
+	Amnesia           tmmath.Fraction `json:"amnesia"`
+}
+
+// DefaultSlashingParams returns a default SlashingParams.
+func DefaultSlashingParams() SlashingParams {
+	return SlashingParams{
+		DuplicateVote:     tmmath.Fraction{Numerator: 5, Denominator: 100},
+		DuplicateProposal: tmmath.Fraction{Numerator: 5, Denominator: 100},
+		LunaticValidator:  tmmath.Fraction{Numerator: 10, Denominator: 100},
+		Amnesia:           tmmath.Fraction{Numerator: 1, Denominator: 100},
+	}
+}
+
+// ValidateBasic ensures all fractions are within [0, 1].
+func (params SlashingParams) ValidateBasic() error {
+	if err := validateSlashFraction(params.DuplicateVote); err != nil {
+		return fmt.Errorf("invalid DuplicateVote: %w", err)
+	}
+	if err := validateSlashFraction(params.DuplicateProposal); err != nil {
+		return fmt.Errorf("invalid DuplicateProposal: %w", err)
+	}
+	if err := validateSlashFraction(params.LunaticValidator); err != nil {
+		return fmt.Errorf("invalid LunaticValidator: %w", err)
+	}
+	if err := validateSlashFraction(params.Amnesia); err != nil {
+		return fmt.Errorf("invalid Amnesia: %w", err)
+	}
+	return nil
+}
+
+func validateSlashFraction(fr tmmath.Fraction) error {
+	if fr.Denominator <= 0 {
+		return errors.New("denominator must be positive")
+	}
+	if fr.Numerator < 0 || fr.Numerator > fr.Denominator {
+		return fmt.Errorf("fraction must be within [0, 1], got %v", fr)
+	}
+	return nil
+}
+
+// SlashFraction returns the amount of voting power to slash from a validator
+// with the given power, which is implicated by the evidence. It returns 0 for
+// evidence which doesn't prove misbehavior: potential amnesia evidence,
+// amnesia evidence whose proof of lock change justifies the validator's votes
+// and composite evidence, which must be split first (see CompositeEvidence).
+//
+// Panics if the fraction for the evidence type is invalid (see
+// SlashingParams.ValidateBasic).
+func SlashFraction(ev Evidence, power int64, params SlashingParams) int64 {
+	if power <= 0 {
+		return 0
+	}
+
+	var fr tmmath.Fraction
+	switch ev := ev.(type) {
+	case *DuplicateVoteEvidence:
+		fr = params.DuplicateVote
+	case *DuplicateProposalEvidence:
+		fr = params.DuplicateProposal
+	case *LunaticValidatorEvidence:
+		fr = params.LunaticValidator
+	case *AmnesiaEvidence:
+		if violated, _ := ev.ViolatedConsensus(); !violated {
+			return 0
+		}
+		fr = params.Amnesia
+	default:
+		return 0
+	}
+
+	if err := validateSlashFraction(fr); err != nil {
+		panic(err)
+	}
+
+	// power * Numerator may overflow int64
+	amount := new(big.Int).Mul(big.NewInt(power), big.NewInt(fr.Numerator))
+	return amount.Quo(amount, big.NewInt(fr.Denominator)).Int64()
+}
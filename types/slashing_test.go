@@ -0,0 +1,71 @@
+package types
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	tmmath "github.com/tendermint/tendermint/libs/math"
+)
+
+func TestSlashFraction(t *testing.T) {
+	var (
+		params = DefaultSlashingParams()
+		evTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		val    = NewMockPV()
+		dve    = NewMockDuplicateVoteEvidenceWithValidator(10, evTime, val, "mychain")
+		lve    = NewLunaticValidatorEvidence(makeHeaderRandom(), dve.VoteA, []string{ValidatorsHashField}, evTime)
+		pe     = NewPotentialAmnesiaEvidence(
+			makeMockVote(10, 0, 0, dve.Address(), randBlockID(), evTime),
+			makeMockVote(10, 1, 0, dve.Address(), randBlockID(), evTime.Add(time.Second)),
+			evTime,
+		)
+		pubKey, _ = val.GetPubKey()
+		polc      = NewMockPOLC(10, evTime, pubKey)
+	)
+
+	assert.NoError(t, params.ValidateBasic())
+
+	testCases := []struct {
+		name  string
+		ev    Evidence
+		power int64
+		want  int64
+	}{
+		{"duplicate vote", dve, 1000, 50},
+		{"duplicate vote, rounded down", dve, 999, 49},
+		{"duplicate vote, no power", dve, 0, 0},
+		{"lunatic", lve, 1000, 100},
+		{"potential amnesia", pe, 1000, 0},
+		{"amnesia without polc", NewAmnesiaEvidence(pe, NewEmptyPOLC()), 1000, 10},
+		{"amnesia with polc", NewAmnesiaEvidence(pe, &polc), 1000, 0},
+		{"composite", &ConflictingHeadersEvidence{}, 1000, 0},
+		{"duplicate vote, max power", dve, MaxTotalVotingPower, MaxTotalVotingPower / 20},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, SlashFraction(tc.ev, tc.power, params))
+		})
+	}
+
+	params.DuplicateVote = tmmath.Fraction{Numerator: 1, Denominator: 0}
+	assert.Error(t, params.ValidateBasic())
+	assert.Panics(t, func() { SlashFraction(dve, 1000, params) })
+
+	params.DuplicateVote = tmmath.Fraction{Numerator: math.MaxInt64, Denominator: math.MaxInt64}
+	assert.NoError(t, params.ValidateBasic())
+	assert.Equal(t, int64(1000), SlashFraction(dve, 1000, params))
+}
+
+func TestSlashingParamsValidateBasic(t *testing.T) {
+	params := DefaultSlashingParams()
+	params.Amnesia = tmmath.Fraction{Numerator: 3, Denominator: 2}
+	assert.Error(t, params.ValidateBasic())
+	params.Amnesia = tmmath.Fraction{Numerator: -1, Denominator: 2}
+	assert.Error(t, params.ValidateBasic())
+	params.Amnesia = tmmath.Fraction{Numerator: 0, Denominator: 2}
+	assert.NoError(t, params.ValidateBasic())
+}
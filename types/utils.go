@@ -27,3 +27,14 @@ func isEmpty(o interface{}) bool {
 		return false
 	}
 }
+
+// copyBytes returns a copy of bz that doesn't share its underlying array.
+// nil is preserved.
+func copyBytes(bz []byte) []byte {
+	if bz == nil {
+		return nil
+	}
+	cp := make([]byte, len(bz))
+	copy(cp, bz)
+	return cp
+}
@@ -1,10 +1,15 @@
 package types
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
@@ -38,3 +43,86 @@ func ValidateHash(h []byte) error {
 	}
 	return nil
 }
+
+// VerifyLastCommit verifies that block.LastCommit is a commit for
+// block.LastBlockID at the previous height, signed by more than 2/3 of
+// prevValSet, the validator set at that height. It also checks that
+// block.LastCommitHash matches the commit. It must not be used for the
+// initial block, which has no LastCommit.
+func VerifyLastCommit(block *Block, prevValSet *ValidatorSet, chainID string) error {
+	if block == nil {
+		return errors.New("nil block")
+	}
+	if block.LastCommit == nil {
+		return errors.New("nil LastCommit")
+	}
+	if !bytes.Equal(block.LastCommitHash, block.LastCommit.Hash()) {
+		return fmt.Errorf("wrong Header.LastCommitHash. Expected %v, got %v",
+			block.LastCommit.Hash(), block.LastCommitHash)
+	}
+	if err := prevValSet.VerifyCommit(chainID, block.LastBlockID, block.Height-1, block.LastCommit); err != nil {
+		return fmt.Errorf("invalid LastCommit: %w", err)
+	}
+	return nil
+}
+
+// VerifyBlockEvidence verifies each piece of evidence of the block against
+// the validator set at its height, as returned by valSetForHeight: the
+// accused validator must belong to the set and the evidence must verify
+// against its public key. Composite evidence must be split before being
+// included in a block and is rejected. UnknownEvidence (see
+// DecodeBlockLenient) can't be verified and is skipped.
+func VerifyBlockEvidence(block *Block, valSetForHeight func(h int64) (*ValidatorSet, error), chainID string) error {
+	if block == nil {
+		return errors.New("nil block")
+	}
+	for i, ev := range block.Evidence.Evidence {
+		if _, ok := ev.(*UnknownEvidence); ok {
+			continue
+		}
+		if _, ok := ev.(CompositeEvidence); ok {
+			return fmt.Errorf("evidence #%d: composite evidence %T must be split first", i, ev)
+		}
+
+		valSet, err := valSetForHeight(ev.Height())
+		if err != nil {
+			return fmt.Errorf("evidence #%d: can't get validator set at height %d: %w", i, ev.Height(), err)
+		}
+		if _, err := VerifyEvidenceWithValidatorSet(ev, chainID, valSet); err != nil {
+			return fmt.Errorf("evidence #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ErrTooMuchAbsentVotingPower is returned by VerifyCommitWithMaxAbsent when
+// the voting power of the validators absent from the commit exceeds the
+// allowed fraction.
+var ErrTooMuchAbsentVotingPower = errors.New("too much voting power absent from commit")
+
+// VerifyCommitWithMaxAbsent is like ValidatorSet.VerifyCommit, but also fails
+// with ErrTooMuchAbsentVotingPower if the validators absent from the commit
+// (see CommitSig.Absent) have more than maxAbsentFraction of the total voting
+// power of valSet. Validators which voted for nil are not absent.
+func VerifyCommitWithMaxAbsent(chainID string, blockID BlockID, height int64, commit *Commit,
+	valSet *ValidatorSet, maxAbsentFraction tmmath.Fraction) error {
+	if maxAbsentFraction.Denominator <= 0 || maxAbsentFraction.Numerator < 0 {
+		return fmt.Errorf("invalid maxAbsentFraction %v", maxAbsentFraction)
+	}
+	if err := valSet.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
+	}
+	absentPower, err := valSet.verifyCommitSignatures(context.Background(), chainID, commit)
+	if err != nil {
+		return err
+	}
+
+	// absentPower / total > num / denom, without overflowing int64
+	absent := new(big.Int).Mul(big.NewInt(absentPower), big.NewInt(maxAbsentFraction.Denominator))
+	allowed := new(big.Int).Mul(big.NewInt(valSet.TotalVotingPower()), big.NewInt(maxAbsentFraction.Numerator))
+	if absent.Cmp(allowed) > 0 {
+		return fmt.Errorf("%w: %d of %d, max %v", ErrTooMuchAbsentVotingPower,
+			absentPower, valSet.TotalVotingPower(), maxAbsentFraction)
+	}
+	return nil
+}
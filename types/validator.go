@@ -33,6 +33,19 @@ func NewValidator(pubKey crypto.PubKey, votingPower int64) *Validator {
 	}
 }
 
+// NewValidatorWithAddressHasher is like NewValidator, but derives the address
+// of the validator from its public key with the given hasher. ValidateBasic
+// requires addresses of AddressSize bytes.
+func NewValidatorWithAddressHasher(pubKey crypto.PubKey, votingPower int64,
+	hasher crypto.AddressHasher) *Validator {
+	return &Validator{
+		Address:          hasher.Address(pubKey),
+		PubKey:           pubKey,
+		VotingPower:      votingPower,
+		ProposerPriority: 0,
+	}
+}
+
 // ValidateBasic performs basic validation.
 func (v *Validator) ValidateBasic() error {
 	if v == nil {
@@ -60,26 +73,18 @@ func (v *Validator) Copy() *Validator {
 	return &vCopy
 }
 
-// Returns the one with higher ProposerPriority.
-func (v *Validator) CompareProposerPriority(other *Validator) *Validator {
-	if v == nil {
-		return other
-	}
+// CompareProposerPriority returns 1 if v takes precedence over other in
+// proposer selection, -1 if other does and 0 if they have the same address.
+// The validator with the higher ProposerPriority takes precedence; ties are
+// broken in favour of the lexicographically smallest address.
+func (v *Validator) CompareProposerPriority(other *Validator) int {
 	switch {
 	case v.ProposerPriority > other.ProposerPriority:
-		return v
+		return 1
 	case v.ProposerPriority < other.ProposerPriority:
-		return other
+		return -1
 	default:
-		result := bytes.Compare(v.Address, other.Address)
-		switch {
-		case result < 0:
-			return v
-		case result > 0:
-			return other
-		default:
-			panic("Cannot compare identical validators")
-		}
+		return -bytes.Compare(v.Address, other.Address)
 	}
 }
 
@@ -2,15 +2,20 @@ package types
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto/merkle"
 	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmstrings "github.com/tendermint/tendermint/libs/strings"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -28,6 +33,15 @@ const (
 	// total voting power gives the maximum allowed distance between validator
 	// priorities.
 	PriorityWindowSizeFactor = 2
+
+	// MaxValidators - the maximum allowed number of validators. It bounds the
+	// work done when verifying commits and evidence against the set. Commits
+	// by a larger set would fail validation anyway (see MaxVotesCount).
+	MaxValidators = MaxVotesCount
+
+	// verifyCommitCtxCheckInterval is the number of signatures
+	// VerifyCommitWithContext verifies between checks of the context.
+	verifyCommitCtxCheckInterval = 8
 )
 
 // ErrTotalVotingPowerOverflow is returned if the total voting power of the
@@ -35,6 +49,14 @@ const (
 var ErrTotalVotingPowerOverflow = fmt.Errorf("total voting power of resulting valset exceeds max %d",
 	MaxTotalVotingPower)
 
+// ErrTooManyValidators is returned if the resulting validator set has more
+// than MaxValidators validators.
+var ErrTooManyValidators = fmt.Errorf("resulting valset has more than %d validators", MaxValidators)
+
+// ErrVotingPowerOverflow is returned if tallying the voting power of a commit
+// overflows int64. This can't happen for sets obeying MaxTotalVotingPower.
+var ErrVotingPowerOverflow = errors.New("int64 overflow while tallying voting power")
+
 // ValidatorSet represent a set of *Validator at a given height.
 //
 // The validators can be fetched by address or index.
@@ -55,6 +77,8 @@ type ValidatorSet struct {
 
 	// cached (unexported)
 	totalVotingPower int64
+	hash             atomic.Value // *validatorSetHash, see Hash
+	addrFilter       atomic.Value // *addressFilter, see GetByAddress
 }
 
 // NewValidatorSet initializes a ValidatorSet by copying over the values from
@@ -64,19 +88,29 @@ type ValidatorSet struct {
 // The addresses of validators in `valz` must be unique otherwise the function
 // panics.
 //
-// Note the validator set size has an implied limit equal to that of the
-// MaxVotesCount - commits by a validator set larger than this will fail
-// validation.
+// The number of validators must not exceed MaxValidators and their total
+// voting power must not exceed MaxTotalVotingPower otherwise the function
+// panics. See TryNewValidatorSet.
 func NewValidatorSet(valz []*Validator) *ValidatorSet {
-	vals := &ValidatorSet{}
-	err := vals.updateWithChangeSet(valz, false)
+	vals, err := TryNewValidatorSet(valz)
 	if err != nil {
 		panic(fmt.Sprintf("Cannot create validator set: %v", err))
 	}
+	return vals
+}
+
+// TryNewValidatorSet is like NewValidatorSet, but returns an error instead of
+// panicking if the validators are invalid, e.g. ErrTotalVotingPowerOverflow
+// if their total voting power exceeds MaxTotalVotingPower.
+func TryNewValidatorSet(valz []*Validator) (*ValidatorSet, error) {
+	vals := &ValidatorSet{}
+	if err := vals.updateWithChangeSet(valz, false); err != nil {
+		return nil, err
+	}
 	if len(valz) > 0 {
 		vals.IncrementProposerPriority(1)
 	}
-	return vals
+	return vals, nil
 }
 
 func (vals *ValidatorSet) ValidateBasic() error {
@@ -97,6 +131,24 @@ func (vals *ValidatorSet) ValidateBasic() error {
 	return nil
 }
 
+// EnforceKeyTypes returns an error if the pubkey of any validator is not of
+// one of the allowed types (see crypto.PubKey.Type, e.g.
+// ABCIPubKeyTypeEd25519), e.g. to restrict the keys of a chain migrating
+// from one scheme to another.
+func (vals *ValidatorSet) EnforceKeyTypes(allowed ...string) error {
+	for idx, val := range vals.Validators {
+		if val.PubKey == nil {
+			return fmt.Errorf("validator #%d (%X) has no pubkey", idx, val.Address)
+		}
+		keyType := val.PubKey.Type()
+		if !tmstrings.StringInSlice(keyType, allowed) {
+			return fmt.Errorf("validator #%d (%X) has a %s pubkey, allowed types: %v",
+				idx, val.Address, keyType, allowed)
+		}
+	}
+	return nil
+}
+
 // IsNilOrEmpty returns true if validator set is nil or empty.
 func (vals *ValidatorSet) IsNilOrEmpty() bool {
 	return vals == nil || len(vals.Validators) == 0
@@ -163,6 +215,48 @@ func (vals *ValidatorSet) RescalePriorities(diffMax int64) {
 	}
 }
 
+// RescaleProposerPriorities is like RescalePriorities followed by centering
+// the priorities around zero, which IncrementProposerPriority does before
+// every increment, but it can be called defensively on arbitrary priorities:
+// the distance between the maximum and minimum is computed without
+// overflowing, even for priorities at the int64 limits. Priorities are
+// divided rounding down, which preserves their order, and the resulting
+// distance is at most diffMax. Panics if validator set is empty.
+func (vals *ValidatorSet) RescaleProposerPriorities(diffMax int64) {
+	if vals.IsNilOrEmpty() {
+		panic("empty validator set")
+	}
+	if diffMax <= 0 {
+		return
+	}
+
+	max := big.NewInt(math.MinInt64)
+	min := big.NewInt(math.MaxInt64)
+	for _, val := range vals.Validators {
+		prio := big.NewInt(val.ProposerPriority)
+		if prio.Cmp(max) > 0 {
+			max = prio
+		}
+		if prio.Cmp(min) < 0 {
+			min = prio
+		}
+	}
+	diff := new(big.Int).Sub(max, min)
+	bigDiffMax := big.NewInt(diffMax)
+	if diff.Cmp(bigDiffMax) > 0 {
+		// ratio = ceil(diff/diffMax)
+		ratio := diff.Add(diff, big.NewInt(diffMax-1))
+		ratio.Div(ratio, bigDiffMax)
+		prio := new(big.Int)
+		for _, val := range vals.Validators {
+			// Div rounds down (Euclidean division with a positive divisor)
+			val.ProposerPriority = prio.Div(prio.SetInt64(val.ProposerPriority), ratio).Int64()
+		}
+	}
+
+	vals.shiftByAvgProposerPriority()
+}
+
 func (vals *ValidatorSet) incrementProposerPriority() *Validator {
 	for _, val := range vals.Validators {
 		// Check for overflow for sum.
@@ -193,6 +287,32 @@ func (vals *ValidatorSet) computeAvgProposerPriority() int64 {
 	panic(fmt.Sprintf("Cannot represent avg ProposerPriority as an int64 %v", avg))
 }
 
+// InvariantCheck returns an error if the proposer priorities of the set are
+// not centered, i.e. their sum is not in (-n, n) for n validators, or the
+// distance between the maximum and minimum priority exceeds
+// PriorityWindowSizeFactor times the total voting power. Both hold after
+// NewValidatorSet, UpdateWithChangeSet and IncrementProposerPriority.
+func (vals *ValidatorSet) InvariantCheck() error {
+	if vals.IsNilOrEmpty() {
+		return errors.New("validator set is nil or empty")
+	}
+
+	n := int64(len(vals.Validators))
+	sum := big.NewInt(0)
+	for _, val := range vals.Validators {
+		sum.Add(sum, big.NewInt(val.ProposerPriority))
+	}
+	if sum.CmpAbs(big.NewInt(n)) >= 0 {
+		return fmt.Errorf("sum of proposer priorities %v is not in (-%d, %d)", sum, n, n)
+	}
+
+	diffMax := PriorityWindowSizeFactor * vals.TotalVotingPower()
+	if diff := computeMaxMinPriorityDiff(vals); diff > diffMax {
+		return fmt.Errorf("proposer priority distance %d exceeds %d", diff, diffMax)
+	}
+	return nil
+}
+
 // Compute the difference between the max and min ProposerPriority of that set.
 func computeMaxMinPriorityDiff(vals *ValidatorSet) int64 {
 	if vals.IsNilOrEmpty() {
@@ -218,7 +338,9 @@ func computeMaxMinPriorityDiff(vals *ValidatorSet) int64 {
 func (vals *ValidatorSet) getValWithMostPriority() *Validator {
 	var res *Validator
 	for _, val := range vals.Validators {
-		res = res.CompareProposerPriority(val)
+		if res == nil || val.CompareProposerPriority(res) > 0 {
+			res = val
+		}
 	}
 	return res
 }
@@ -247,16 +369,43 @@ func validatorListCopy(valsList []*Validator) []*Validator {
 
 // Copy each validator into a new ValidatorSet.
 func (vals *ValidatorSet) Copy() *ValidatorSet {
-	return &ValidatorSet{
+	valsCopy := &ValidatorSet{
 		Validators:       validatorListCopy(vals.Validators),
 		Proposer:         vals.Proposer,
 		totalVotingPower: vals.totalVotingPower,
 	}
+	if hash := vals.cachedHash(); hash != nil {
+		valsCopy.hash.Store(newValidatorSetHash(valsCopy.Validators, hash))
+	}
+	return valsCopy
+}
+
+// Snapshot returns a deep copy of the set which can be shared by concurrent
+// readers (e.g. commit verifiers), as long as none of them calls a mutator
+// on it. Unlike Copy, the proposer and total voting power are computed
+// upfront, so that the getters don't lazily modify the snapshot (Hash is
+// already safe to call concurrently), and the proposer isn't shared with vals.
+// Mutating vals afterwards doesn't affect the snapshot.
+func (vals *ValidatorSet) Snapshot() *ValidatorSet {
+	snap := vals.Copy()
+	if snap.IsNilOrEmpty() {
+		return snap
+	}
+	if vals.Proposer != nil {
+		snap.Proposer = vals.Proposer.Copy()
+	} else {
+		snap.Proposer = snap.findProposer()
+	}
+	snap.TotalVotingPower()
+	return snap
 }
 
 // HasAddress returns true if address given is in the validator set, false -
 // otherwise.
 func (vals *ValidatorSet) HasAddress(address []byte) bool {
+	if !vals.mayHaveAddress(address) {
+		return false
+	}
 	for _, val := range vals.Validators {
 		if bytes.Equal(val.Address, address) {
 			return true
@@ -267,7 +416,16 @@ func (vals *ValidatorSet) HasAddress(address []byte) bool {
 
 // GetByAddress returns an index of the validator with address and validator
 // itself (copy) if found. Otherwise, -1 and nil are returned.
+// It never panics, even if the set is nil.
+//
+// Lookups of non-members of large sets are short-circuited by a Bloom filter
+// of the addresses, which is rebuilt after UpdateWithChangeSet or when
+// Validators is replaced or resized. Validators must not be replaced or have
+// their addresses changed in place.
 func (vals *ValidatorSet) GetByAddress(address []byte) (index int32, val *Validator) {
+	if vals == nil || !vals.mayHaveAddress(address) {
+		return -1, nil
+	}
 	for idx, val := range vals.Validators {
 		if bytes.Equal(val.Address, address) {
 			return int32(idx), val.Copy()
@@ -279,9 +437,10 @@ func (vals *ValidatorSet) GetByAddress(address []byte) (index int32, val *Valida
 // GetByIndex returns the validator's address and validator itself (copy) by
 // index.
 // It returns nil values if index is less than 0 or greater or equal to
-// len(ValidatorSet.Validators).
+// len(ValidatorSet.Validators), so it's safe to use with an untrusted index
+// (e.g. Vote.ValidatorIndex). It never panics, even if the set is nil.
 func (vals *ValidatorSet) GetByIndex(index int32) (address []byte, val *Validator) {
-	if index < 0 || int(index) >= len(vals.Validators) {
+	if vals == nil || index < 0 || int(index) >= len(vals.Validators) {
 		return nil, nil
 	}
 	val = vals.Validators[index]
@@ -335,8 +494,8 @@ func (vals *ValidatorSet) GetProposer() (proposer *Validator) {
 func (vals *ValidatorSet) findProposer() *Validator {
 	var proposer *Validator
 	for _, val := range vals.Validators {
-		if proposer == nil || !bytes.Equal(val.Address, proposer.Address) {
-			proposer = proposer.CompareProposerPriority(val)
+		if proposer == nil || val.CompareProposerPriority(proposer) > 0 {
+			proposer = val
 		}
 	}
 	return proposer
@@ -344,20 +503,38 @@ func (vals *ValidatorSet) findProposer() *Validator {
 
 // Hash returns the Merkle root hash build using validators (as leaves) in the
 // set.
+//
+// The hash is cached until a validator, its public key or its voting power
+// changes, whether by a mutator or because Validators was modified directly.
+// Proposer priorities are not part of the hash.
 func (vals *ValidatorSet) Hash() []byte {
-	bzs := make([][]byte, len(vals.Validators))
-	for i, val := range vals.Validators {
-		bzs[i] = val.Bytes()
+	hash := vals.cachedHash()
+	if hash == nil {
+		bzs := make([][]byte, len(vals.Validators))
+		for i, val := range vals.Validators {
+			bzs[i] = val.Bytes()
+		}
+		hash = merkle.HashFromByteSlices(bzs)
+		vals.hash.Store(newValidatorSetHash(vals.Validators, hash))
 	}
-	return merkle.HashFromByteSlices(bzs)
+	// the cached hash must not be modified by the caller
+	return append([]byte(nil), hash...)
 }
 
 // Iterate will run the given function over the set.
 func (vals *ValidatorSet) Iterate(fn func(index int, val *Validator) bool) {
+	vals.ForEach(func(index int, val *Validator) bool {
+		return fn(index, val.Copy())
+	})
+}
+
+// ForEach calls fn for each validator of the set, in order, until it returns
+// true. Unlike Iterate, fn gets the validators of the set, not copies, so it
+// must not modify them.
+func (vals *ValidatorSet) ForEach(fn func(index int, val *Validator) (stop bool)) {
 	for i, val := range vals.Validators {
-		stop := fn(i, val.Copy())
-		if stop {
-			break
+		if fn(i, val) {
+			return
 		}
 	}
 }
@@ -599,10 +776,14 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 		return fmt.Errorf("cannot process validators with voting power 0: %v", deletes)
 	}
 
-	// Check that the resulting set will not be empty.
-	if numNewValidators(updates, vals) == 0 && len(vals.Validators) == len(deletes) {
+	// Check that the resulting set will not be empty or too large.
+	numNew := numNewValidators(updates, vals)
+	if numNew == 0 && len(vals.Validators) == len(deletes) {
 		return errors.New("applying the validator changes would result in empty set")
 	}
+	if len(vals.Validators)+numNew-len(deletes) > MaxValidators {
+		return ErrTooManyValidators
+	}
 
 	// Verify that applying the 'deletes' against 'vals' will not result in error.
 	// Get the voting power that is going to be removed.
@@ -624,6 +805,8 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 	// Apply updates and removals.
 	vals.applyUpdates(updates)
 	vals.applyRemovals(deletes)
+	vals.invalidateHash()
+	vals.invalidateAddressFilter()
 
 	vals.updateTotalVotingPower() // will panic if total voting power > MaxTotalVotingPower
 
@@ -652,6 +835,36 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 	return vals.updateWithChangeSet(changes, true)
 }
 
+// ValidatorSetDiff returns the changes which, when applied to oldVals with
+// UpdateWithChangeSet, result in a set with the same validators and voting
+// powers as newVals. Validators which were added or whose voting power changed
+// are returned with their new voting power, removed ones with a voting power
+// of 0. The result is sorted by address.
+func ValidatorSetDiff(oldVals, newVals *ValidatorSet) []*Validator {
+	changes := make([]*Validator, 0)
+
+	for _, val := range newVals.Validators {
+		_, oldVal := oldVals.GetByAddress(val.Address)
+		if oldVal == nil || oldVal.VotingPower != val.VotingPower {
+			change := val.Copy()
+			change.ProposerPriority = 0
+			changes = append(changes, change)
+		}
+	}
+
+	for _, val := range oldVals.Validators {
+		if !newVals.HasAddress(val.Address) {
+			change := val.Copy()
+			change.VotingPower = 0
+			change.ProposerPriority = 0
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Sort(ValidatorsByAddress(changes))
+	return changes
+}
+
 // VerifyCommit verifies +2/3 of the set had signed the given commit.
 //
 // It checks all the signatures! While it's safe to exit as soon as we have
@@ -659,46 +872,199 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 // application that depends on the LastCommitInfo sent in BeginBlock, which
 // includes which validators signed. For instance, Gaia incentivizes proposers
 // with a bonus for including more than +2/3 of the signatures.
+//
+// CommitSigs don't carry a round: every signature is verified against the vote
+// reconstructed with the commit's round (see Commit.GetVote), so signatures
+// spliced from a commit for another round are rejected.
 func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 	height int64, commit *Commit) error {
+	return vals.VerifyCommitWithContext(context.Background(), chainID, blockID, height, commit)
+}
 
-	if vals.Size() != len(commit.Signatures) {
-		return NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
-	}
+// VerifyCommitWithContext is like VerifyCommit, but stops verifying
+// signatures and returns ctx.Err() once ctx is done. The context is checked
+// every verifyCommitCtxCheckInterval signatures.
+func (vals *ValidatorSet) VerifyCommitWithContext(ctx context.Context, chainID string, blockID BlockID,
+	height int64, commit *Commit) error {
 
-	// Validate Height and BlockID.
-	if height != commit.Height {
-		return NewErrInvalidCommitHeight(height, commit.Height)
-	}
-	if !blockID.Equals(commit.BlockID) {
-		return fmt.Errorf("invalid commit -- wrong block ID: want %v, got %v",
-			blockID, commit.BlockID)
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
 	}
+	_, err := vals.verifyCommitSignatures(ctx, chainID, commit)
+	return err
+}
 
+// verifyCommitSignatures verifies the signatures of a commit which passed
+// verifyCommitBasic, and that +2/3 of the set signed for the block. It returns
+// the voting power of the validators absent from the commit.
+func (vals *ValidatorSet) verifyCommitSignatures(ctx context.Context, chainID string,
+	commit *Commit) (absentVotingPower int64, err error) {
+	votingPowerNeeded, err := vals.twoThirdsVotingPower()
+	if err != nil {
+		return 0, err
+	}
 	talliedVotingPower := int64(0)
-	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
 	for idx, commitSig := range commit.Signatures {
-		if commitSig.Absent() {
-			continue // OK, some signatures can be absent.
+		if idx%verifyCommitCtxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
 		}
 
 		// The vals and commit have a 1-to-1 correspondance.
 		// This means we don't need the validator address or to do any lookup.
 		val := vals.Validators[idx]
 
-		// Validate signature.
-		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		if commitSig.Absent() {
+			// OK, some signatures can be absent. Their voting power can't
+			// overflow, as it's part of the total voting power.
+			absentVotingPower += val.VotingPower
+			continue
+		}
+
+		if err := verifyCommitSig(chainID, idx, val, commit.getVote(int32(idx))); err != nil {
+			return 0, err
 		}
-		// Good!
-		if commitSig.ForBlock() {
-			talliedVotingPower += val.VotingPower
+		// Good! We also include stray signatures (~votes for nil) to measure
+		// validator availability, but they aren't tallied.
+		talliedVotingPower, err = tallyCommitSig(talliedVotingPower, val, commitSig)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return 0, ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	return absentVotingPower, nil
+}
+
+// verifyCommitSig verifies the signature of val, the validator with index idx
+// in the set, on its vote of a commit (see Commit.GetVote).
+func verifyCommitSig(chainID string, idx int, val *Validator, vote *Vote) error {
+	if !val.PubKey.VerifySignature(VoteSignBytes(chainID, vote.ToProto()), vote.Signature) {
+		return fmt.Errorf("wrong signature (#%d): %X", idx, vote.Signature)
+	}
+	return nil
+}
+
+// tallyCommitSig returns tallied plus the voting power of val, if its verified
+// signature commitSig is for the block. ErrVotingPowerOverflow is returned if
+// the sum overflows.
+func tallyCommitSig(tallied int64, val *Validator, commitSig CommitSig) (int64, error) {
+	if !commitSig.ForBlock() {
+		return tallied, nil
+	}
+	sum, overflow := safeAdd(tallied, val.VotingPower)
+	if overflow {
+		return tallied, ErrVotingPowerOverflow
+	}
+	return sum, nil
+}
+
+// ErrCommitTimestampSpread is returned by VerifyCommitWithOptions when the
+// timestamps of a commit are further apart than allowed.
+var ErrCommitTimestampSpread = errors.New("commit timestamps are too far apart")
+
+// VerifyCommitOptions are the additional checks of VerifyCommitWithOptions.
+type VerifyCommitOptions struct {
+	// If positive, the timestamps of the signatures which aren't absent must
+	// all be within MaxTimestampSpread of each other, so that an outlier can't
+	// skew the median time (see MedianTime).
+	MaxTimestampSpread time.Duration
+}
+
+// VerifyCommitWithOptions is like VerifyCommit, but also performs the checks
+// enabled in opts.
+func (vals *ValidatorSet) VerifyCommitWithOptions(chainID string, blockID BlockID,
+	height int64, commit *Commit, opts VerifyCommitOptions) error {
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
+	}
+	if opts.MaxTimestampSpread > 0 {
+		if err := verifyCommitTimestampSpread(commit, opts.MaxTimestampSpread); err != nil {
+			return err
+		}
+	}
+	_, err := vals.verifyCommitSignatures(context.Background(), chainID, commit)
+	return err
+}
+
+func verifyCommitTimestampSpread(commit *Commit, maxSpread time.Duration) error {
+	var min, max time.Time
+	minIdx, maxIdx := -1, -1
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.Absent() {
+			continue
+		}
+		if minIdx == -1 || commitSig.Timestamp.Before(min) {
+			min, minIdx = commitSig.Timestamp, idx
+		}
+		if maxIdx == -1 || commitSig.Timestamp.After(max) {
+			max, maxIdx = commitSig.Timestamp, idx
+		}
+	}
+	if minIdx != -1 && max.Sub(min) > maxSpread {
+		return fmt.Errorf("%w: %v (#%d) and %v (#%d), max: %v",
+			ErrCommitTimestampSpread, min, minIdx, max, maxIdx, maxSpread)
+	}
+	return nil
+}
+
+// VerifyCommitParallel is like VerifyCommit, but verifies the signatures
+// using up to workers goroutines. The result doesn't depend on scheduling: if
+// several signatures are invalid, the one with the lowest index is reported,
+// as VerifyCommit would. Panics if workers is not positive.
+func (vals *ValidatorSet) VerifyCommitParallel(chainID string, blockID BlockID,
+	height int64, commit *Commit, workers int) error {
+	if workers <= 0 {
+		panic("workers must be positive")
+	}
+
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
+	}
+
+	votingPowerNeeded, err := vals.twoThirdsVotingPower()
+	if err != nil {
+		return err
+	}
+
+	var (
+		errs    = make([]error, len(commit.Signatures))
+		indices = make(chan int, len(commit.Signatures))
+		wg      sync.WaitGroup
+	)
+	for idx, commitSig := range commit.Signatures {
+		if !commitSig.Absent() {
+			indices <- idx
+		}
+	}
+	close(indices)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				errs[idx] = verifyCommitSig(chainID, idx, vals.Validators[idx], commit.getVote(int32(idx)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	talliedVotingPower := int64(0)
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.Absent() {
+			continue
+		}
+		if errs[idx] != nil {
+			return errs[idx]
+		}
+		talliedVotingPower, err = tallyCommitSig(talliedVotingPower, vals.Validators[idx], commitSig)
+		if err != nil {
+			return err
 		}
-		// else {
-		// It's OK. We include stray signatures (~votes for nil) to measure
-		// validator availability.
-		// }
 	}
 
 	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
@@ -708,6 +1074,24 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 	return nil
 }
 
+// verifyCommitBasic checks that the commit has one signature per validator
+// and is for the given height and block ID.
+func (vals *ValidatorSet) verifyCommitBasic(blockID BlockID, height int64, commit *Commit) error {
+	if vals.Size() != len(commit.Signatures) {
+		return NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
+	}
+
+	// Validate Height and BlockID.
+	if height != commit.Height {
+		return NewErrInvalidCommitHeight(height, commit.Height)
+	}
+	if !blockID.Equals(commit.BlockID) {
+		return fmt.Errorf("invalid commit -- wrong block ID: want %v, got %v",
+			blockID, commit.BlockID)
+	}
+	return nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // LIGHT CLIENT VERIFICATION METHODS
 ///////////////////////////////////////////////////////////////////////////////
@@ -732,8 +1116,11 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 			blockID, commit.BlockID)
 	}
 
+	votingPowerNeeded, err := vals.twoThirdsVotingPower()
+	if err != nil {
+		return err
+	}
 	talliedVotingPower := int64(0)
-	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
 	for idx, commitSig := range commit.Signatures {
 		// No need to verify absent or nil votes.
 		if !commitSig.ForBlock() {
@@ -750,7 +1137,11 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
 		}
 
-		talliedVotingPower += val.VotingPower
+		var overflow bool
+		talliedVotingPower, overflow = safeAdd(talliedVotingPower, val.VotingPower)
+		if overflow {
+			return ErrVotingPowerOverflow
+		}
 
 		// return as soon as +2/3 of the signatures are verified
 		if talliedVotingPower > votingPowerNeeded {
@@ -811,7 +1202,11 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 				return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
 			}
 
-			talliedVotingPower += val.VotingPower
+			var overflow bool
+			talliedVotingPower, overflow = safeAdd(talliedVotingPower, val.VotingPower)
+			if overflow {
+				return ErrVotingPowerOverflow
+			}
 
 			if talliedVotingPower > votingPowerNeeded {
 				return nil
@@ -822,6 +1217,48 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
 }
 
+// twoThirdsVotingPower returns 2/3 of the total voting power, which a commit
+// must exceed. It returns ErrVotingPowerOverflow instead of wrapping around.
+func (vals *ValidatorSet) twoThirdsVotingPower() (int64, error) {
+	doubled, overflow := safeMul(vals.TotalVotingPower(), 2)
+	if overflow {
+		return 0, ErrVotingPowerOverflow
+	}
+	return doubled / 3, nil
+}
+
+// MinSignersFor returns the fewest validators, taken by decreasing voting
+// power, whose combined voting power exceeds the given fraction of the total,
+// e.g. the minimum number of signatures of a commit for 2/3. It returns -1 if
+// no subset of the validators does, i.e. if the fraction is not less than 1.
+//
+// Panics if the fraction is negative or its denominator is not positive.
+func (vals *ValidatorSet) MinSignersFor(fraction tmmath.Fraction) int {
+	if fraction.Numerator < 0 || fraction.Denominator <= 0 {
+		panic(fmt.Sprintf("invalid fraction %v", fraction))
+	}
+
+	powers := make([]int64, len(vals.Validators))
+	for i, val := range vals.Validators {
+		powers[i] = val.VotingPower
+	}
+	sort.Slice(powers, func(i, j int) bool { return powers[i] > powers[j] })
+
+	var (
+		// power*denom must exceed total*num
+		needed = new(big.Int).Mul(big.NewInt(vals.TotalVotingPower()), big.NewInt(fraction.Numerator))
+		denom  = big.NewInt(fraction.Denominator)
+		sum    int64
+	)
+	for i, power := range powers {
+		sum += power
+		if new(big.Int).Mul(big.NewInt(sum), denom).Cmp(needed) > 0 {
+			return i + 1
+		}
+	}
+	return -1
+}
+
 //-----------------
 
 // IsErrNotEnoughVotingPowerSigned returns true if err is
@@ -858,7 +1295,7 @@ func (vals *ValidatorSet) StringIndented(indent string) string {
 		return "nil-ValidatorSet"
 	}
 	var valStrings []string
-	vals.Iterate(func(index int, val *Validator) bool {
+	vals.ForEach(func(index int, val *Validator) bool {
 		valStrings = append(valStrings, val.String())
 		return false
 	})
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// SelectCommittee deterministically samples size distinct validators from the
+// set, weighted by voting power, using randomness derived from the seed.
+//
+// Since the sampling already favours validators with more voting power, every
+// member of the committee gets a voting power of 1. If size is not less than
+// the number of validators, a copy of the whole set is returned instead.
+func (vals *ValidatorSet) SelectCommittee(seed []byte, size int) (*ValidatorSet, error) {
+	if size <= 0 {
+		return nil, errors.New("committee size must be positive")
+	}
+	if vals.IsNilOrEmpty() {
+		return nil, errors.New("empty validator set")
+	}
+	if size >= len(vals.Validators) {
+		return vals.Copy(), nil
+	}
+
+	var (
+		remaining = append([]*Validator(nil), vals.Validators...)
+		total     = vals.TotalVotingPower()
+		committee = make([]*Validator, 0, size)
+		msg       = make([]byte, len(seed)+8)
+	)
+	copy(msg, seed)
+	for i := 0; i < size; i++ {
+		// draw a unit of the remaining voting power
+		binary.BigEndian.PutUint64(msg[len(seed):], uint64(i))
+		r := new(big.Int).SetBytes(tmhash.Sum(msg))
+		target := r.Mod(r, big.NewInt(total)).Int64()
+
+		var (
+			cumulative int64
+			j          int
+		)
+		for j = range remaining {
+			cumulative += remaining[j].VotingPower
+			if target < cumulative {
+				break
+			}
+		}
+
+		member := remaining[j].Copy()
+		total -= member.VotingPower
+		member.VotingPower = 1
+		member.ProposerPriority = 0
+		committee = append(committee, member)
+		remaining = append(remaining[:j], remaining[j+1:]...)
+	}
+
+	return NewValidatorSet(committee), nil
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorSetSelectCommittee(t *testing.T) {
+	valSet := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 10),
+		newValidator([]byte("b"), 30),
+		newValidator([]byte("c"), 60),
+		newValidator([]byte("d"), 100),
+	})
+
+	// reproducible
+	committee, err := valSet.SelectCommittee([]byte("seed"), 2)
+	require.NoError(t, err)
+	again, err := valSet.SelectCommittee([]byte("seed"), 2)
+	require.NoError(t, err)
+	assert.Equal(t, committee, again)
+	assert.Equal(t, 2, committee.Size())
+	assert.EqualValues(t, 2, committee.TotalVotingPower())
+	for _, val := range committee.Validators {
+		assert.True(t, valSet.HasAddress(val.Address))
+	}
+
+	// power-weighted
+	const n = 2000
+	counts := make(map[string]int)
+	seed := make([]byte, 8)
+	for i := 0; i < n; i++ {
+		binary.BigEndian.PutUint64(seed, uint64(i))
+		committee, err := valSet.SelectCommittee(seed, 1)
+		require.NoError(t, err)
+		counts[string(committee.Validators[0].Address)]++
+	}
+	total := valSet.TotalVotingPower()
+	for _, val := range valSet.Validators {
+		expected := float64(n) * float64(val.VotingPower) / float64(total)
+		assert.InDelta(t, expected, counts[string(val.Address)], 0.05*n, "validator %s", val.Address)
+	}
+
+	// edge cases
+	full, err := valSet.SelectCommittee([]byte("seed"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, valSet, full)
+	_, err = valSet.SelectCommittee([]byte("seed"), 0)
+	assert.Error(t, err)
+	_, err = valSet.SelectCommittee([]byte("seed"), -1)
+	assert.Error(t, err)
+	_, err = (&ValidatorSet{}).SelectCommittee([]byte("seed"), 1)
+	assert.Error(t, err)
+}
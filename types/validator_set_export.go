@@ -0,0 +1,135 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	pc "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+// ValidatorExportRow is a validator as exported by ValidatorSet.ExportCSV and
+// ValidatorSet.ExportJSON.
+type ValidatorExportRow struct {
+	Address          tmbytes.HexBytes `json:"address"`
+	PubKeyType       string           `json:"pub_key_type"`
+	PubKey           []byte           `json:"pub_key"` // base64 in JSON
+	VotingPower      int64            `json:"voting_power"`
+	ProposerPriority int64            `json:"proposer_priority"`
+}
+
+var validatorExportCSVHeader = []string{"address", "pub_key_type", "pub_key", "voting_power", "proposer_priority"}
+
+// exportRows returns the rows of the validators, sorted by address.
+func (vals *ValidatorSet) exportRows() []ValidatorExportRow {
+	rows := make([]ValidatorExportRow, len(vals.Validators))
+	for i, val := range vals.Validators {
+		rows[i] = ValidatorExportRow{
+			Address:          val.Address,
+			VotingPower:      val.VotingPower,
+			ProposerPriority: val.ProposerPriority,
+		}
+		if val.PubKey != nil {
+			rows[i].PubKeyType = val.PubKey.Type()
+			rows[i].PubKey = val.PubKey.Bytes()
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return bytes.Compare(rows[i].Address, rows[j].Address) < 0
+	})
+	return rows
+}
+
+// ExportCSV writes the validators to w as CSV, one row per validator sorted
+// by address, preceded by a header row: address (hex), public key type,
+// public key (base64), voting power and proposer priority.
+func (vals *ValidatorSet) ExportCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(validatorExportCSVHeader); err != nil {
+		return err
+	}
+	for _, row := range vals.exportRows() {
+		record := []string{
+			row.Address.String(),
+			row.PubKeyType,
+			base64.StdEncoding.EncodeToString(row.PubKey),
+			strconv.FormatInt(row.VotingPower, 10),
+			strconv.FormatInt(row.ProposerPriority, 10),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportJSON writes the validators to w as a JSON array of
+// ValidatorExportRow, sorted by address. The current proposer isn't exported.
+//
+// See ImportValidatorSetJSON.
+func (vals *ValidatorSet) ExportJSON(w io.Writer) error {
+	if _, err := io.WriteString(w, "[\n"); err != nil {
+		return err
+	}
+	for i, row := range vals.exportRows() {
+		bz, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, ",\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(bz); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n]\n")
+	return err
+}
+
+// ImportValidatorSetJSON reads a validator set written by ExportJSON. The
+// validators keep their exported proposer priorities. Only ed25519 public
+// keys are supported.
+func ImportValidatorSetJSON(r io.Reader) (*ValidatorSet, error) {
+	var rows []ValidatorExportRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, err
+	}
+
+	valz := make([]*Validator, len(rows))
+	for i, row := range rows {
+		if row.PubKeyType != ABCIPubKeyTypeEd25519 {
+			return nil, fmt.Errorf("validator #%d: unsupported pub_key_type %q", i, row.PubKeyType)
+		}
+		pubKey, err := cryptoenc.PubKeyFromProto(pc.PublicKey{Sum: &pc.PublicKey_Ed25519{Ed25519: row.PubKey}})
+		if err != nil {
+			return nil, fmt.Errorf("validator #%d: %w", i, err)
+		}
+		if !bytes.Equal(pubKey.Address(), row.Address) {
+			return nil, fmt.Errorf("validator #%d: address %v doesn't match pub_key", i, row.Address)
+		}
+		valz[i] = NewValidator(pubKey, row.VotingPower)
+		valz[i].ProposerPriority = row.ProposerPriority
+	}
+
+	vals := &ValidatorSet{}
+	if err := vals.updateWithChangeSet(valz, false); err != nil {
+		return nil, err
+	}
+	// updateWithChangeSet computes the priorities of new validators
+	for _, val := range valz {
+		idx, _ := vals.GetByAddress(val.Address)
+		vals.Validators[idx].ProposerPriority = val.ProposerPriority
+	}
+	return vals, nil
+}
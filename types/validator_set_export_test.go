@@ -0,0 +1,79 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func exportTestValidatorSet() *ValidatorSet {
+	vset := NewValidatorSet([]*Validator{
+		NewValidator(ed25519.GenPrivKey().PubKey(), 10),
+		NewValidator(ed25519.GenPrivKey().PubKey(), 20),
+		NewValidator(ed25519.GenPrivKey().PubKey(), 30),
+	})
+	vset.IncrementProposerPriority(2)
+	return vset
+}
+
+func TestValidatorSetExportCSV(t *testing.T) {
+	vset := exportTestValidatorSet()
+	sorted := validatorListCopy(vset.Validators)
+	sort.Sort(ValidatorsByAddress(sorted))
+
+	var buf bytes.Buffer
+	require.NoError(t, vset.ExportCSV(&buf))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	require.Len(t, records, 4)
+	assert.Equal(t, []string{"address", "pub_key_type", "pub_key", "voting_power", "proposer_priority"}, records[0])
+	for i, val := range sorted {
+		assert.Equal(t, []string{
+			val.Address.String(),
+			"ed25519",
+			base64.StdEncoding.EncodeToString(val.PubKey.Bytes()),
+			strconv.FormatInt(val.VotingPower, 10),
+			strconv.FormatInt(val.ProposerPriority, 10),
+		}, records[i+1])
+	}
+}
+
+func TestValidatorSetExportJSON(t *testing.T) {
+	vset := exportTestValidatorSet()
+
+	var buf bytes.Buffer
+	require.NoError(t, vset.ExportJSON(&buf))
+	vset2, err := ImportValidatorSetJSON(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, vset.Validators, vset2.Validators)
+	assert.Equal(t, vset.Hash(), vset2.Hash())
+	assert.Equal(t, vset.TotalVotingPower(), vset2.TotalVotingPower())
+
+	// tampered address
+	buf.Reset()
+	require.NoError(t, vset.ExportJSON(&buf))
+	tampered := strings.Replace(buf.String(), vset.Validators[0].Address.String(),
+		strings.Repeat("A", 40), 1)
+	_, err = ImportValidatorSetJSON(strings.NewReader(tampered))
+	assert.Error(t, err)
+
+	_, err = ImportValidatorSetJSON(strings.NewReader("not json"))
+	assert.Error(t, err)
+
+	buf.Reset()
+	require.NoError(t, (&ValidatorSet{}).ExportJSON(&buf))
+	empty, err := ImportValidatorSetJSON(&buf)
+	require.NoError(t, err)
+	assert.True(t, empty.IsNilOrEmpty())
+}
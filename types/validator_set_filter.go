@@ -0,0 +1,105 @@
+package types
+
+import "sync/atomic"
+
+const (
+	// addressFilterMinValidators is the minimum size of a validator set for
+	// which GetByAddress uses an addressFilter: a linear scan of smaller sets
+	// is as fast.
+	addressFilterMinValidators = 64
+
+	// With 10 bits per address and 7 hash functions, about 1% of the lookups
+	// of non-members are false positives.
+	addressFilterBitsPerAddress = 10
+	addressFilterHashes         = 7
+)
+
+// addressFilter is a Bloom filter of the addresses of a validator set, used
+// to reject lookups of non-members without scanning the set. It's immutable
+// once built.
+type addressFilter struct {
+	bits []uint64
+	vals []*Validator // the validators the filter was built for, see builtFor
+}
+
+func newAddressFilter(vals []*Validator) *addressFilter {
+	numBits := uint64(len(vals)*addressFilterBitsPerAddress+63) / 64 * 64
+	f := &addressFilter{
+		bits: make([]uint64, numBits/64),
+		vals: vals,
+	}
+	for _, val := range vals {
+		h1, h2 := addressFilterHash(val.Address)
+		for i := uint64(0); i < addressFilterHashes; i++ {
+			bit := (h1 + i*h2) % numBits
+			f.bits[bit/64] |= 1 << (bit % 64)
+		}
+	}
+	return f
+}
+
+// builtFor returns true if the filter was built for vals, i.e. for the same
+// slice of validators. It's an O(1) check, which detects when Validators is
+// replaced or resized, but not when its elements are modified in place:
+// mutators of the set must call invalidateAddressFilter instead.
+func (f *addressFilter) builtFor(vals []*Validator) bool {
+	if len(f.vals) != len(vals) {
+		return false
+	}
+	return len(vals) == 0 || &f.vals[0] == &vals[0]
+}
+
+// sameBytes returns true if a and b are the same slice.
+func sameBytes(a, b []byte) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// mayContain returns false if the address is definitely not in the filter.
+func (f *addressFilter) mayContain(address []byte) bool {
+	numBits := uint64(len(f.bits)) * 64
+	h1, h2 := addressFilterHash(address)
+	for i := uint64(0); i < addressFilterHashes; i++ {
+		bit := (h1 + i*h2) % numBits
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// addressFilterHash returns the two halves of the 64-bit FNV-1a hash of the
+// address, for double hashing. The second one is odd, so that it's never 0.
+func addressFilterHash(address []byte) (h1, h2 uint64) {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for _, b := range address {
+		h ^= uint64(b)
+		h *= prime64
+	}
+	return h >> 32, h&0xffffffff | 1
+}
+
+// mayHaveAddress returns false if the set definitely doesn't contain the
+// address. The filter is built lazily, and rebuilt after a mutator changed the
+// validators or Validators was replaced.
+func (vals *ValidatorSet) mayHaveAddress(address []byte) bool {
+	if len(vals.Validators) < addressFilterMinValidators {
+		return true
+	}
+	f, _ := vals.addrFilter.Load().(*addressFilter)
+	if f == nil || !f.builtFor(vals.Validators) {
+		f = newAddressFilter(vals.Validators)
+		vals.addrFilter.Store(f)
+	}
+	return f.mayContain(address)
+}
+
+// invalidateAddressFilter discards the filter used by mayHaveAddress, so that
+// it's rebuilt by the next lookup. Like invalidateHash, it must be called by
+// every mutator which changes the validators.
+func (vals *ValidatorSet) invalidateAddressFilter() {
+	vals.addrFilter = atomic.Value{}
+}
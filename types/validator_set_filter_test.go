@@ -0,0 +1,97 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestValidatorSetAddressFilter(t *testing.T) {
+	valSet, _ := RandValidatorSet(1000, 1)
+
+	// members are always found
+	for idx, val := range valSet.Validators {
+		gotIdx, gotVal := valSet.GetByAddress(val.Address)
+		assert.EqualValues(t, idx, gotIdx)
+		assert.Equal(t, val, gotVal)
+		assert.True(t, valSet.HasAddress(val.Address))
+	}
+
+	// non-members are never found, and mostly rejected by the filter
+	const lookups = 10000
+	rejected := 0
+	for i := 0; i < lookups; i++ {
+		addr := crypto.AddressHash(tmrand.Bytes(32))
+		idx, val := valSet.GetByAddress(addr)
+		assert.EqualValues(t, -1, idx)
+		assert.Nil(t, val)
+		assert.False(t, valSet.HasAddress(addr))
+		if !valSet.mayHaveAddress(addr) {
+			rejected++
+		}
+	}
+	assert.Greater(t, rejected, lookups*95/100)
+	assert.False(t, valSet.HasAddress([]byte("short")))
+
+	// the filter is rebuilt when the set changes
+	newVal := NewValidator(randPubKey(), 1)
+	assert.False(t, valSet.HasAddress(newVal.Address))
+	require.NoError(t, valSet.UpdateWithChangeSet([]*Validator{newVal}))
+	assert.Nil(t, valSet.addrFilter.Load())
+	idx, val := valSet.GetByAddress(newVal.Address)
+	assert.NotEqualValues(t, -1, idx)
+	assert.Equal(t, newVal.Address, val.Address)
+
+	// or when validators are appended directly
+	newVal = NewValidator(randPubKey(), 1)
+	valSet.Validators = append(valSet.Validators, newVal)
+	assert.True(t, valSet.HasAddress(newVal.Address))
+
+	// or the slice is replaced
+	newVal = NewValidator(randPubKey(), 1)
+	oldVal := valSet.Validators[0]
+	vals := validatorListCopy(valSet.Validators)
+	vals[0] = newVal
+	valSet.Validators = vals
+	assert.True(t, valSet.HasAddress(newVal.Address))
+	assert.False(t, valSet.HasAddress(oldVal.Address))
+
+	// lookups can run concurrently, also while the filter is built
+	valSet = valSet.Copy()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.True(t, valSet.HasAddress(newVal.Address))
+	}()
+	assert.True(t, valSet.HasAddress(newVal.Address))
+	<-done
+}
+
+func BenchmarkValidatorSetGetByAddressNonMember(b *testing.B) {
+	valSet, _ := RandValidatorSet(MaxValidators, 1)
+	addr := crypto.AddressHash([]byte("not a validator"))
+	valSet.GetByAddress(addr) // build the filter
+
+	b.Run("filter", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if idx, _ := valSet.GetByAddress(addr); idx != -1 {
+				b.Fatal("found non-member")
+			}
+		}
+	})
+
+	b.Run("linear scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, val := range valSet.Validators {
+				if bytes.Equal(val.Address, addr) {
+					b.Fatal("found non-member")
+				}
+			}
+		}
+	})
+}
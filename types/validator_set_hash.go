@@ -0,0 +1,69 @@
+package types
+
+import "sync/atomic"
+
+// validatorSetHash is the Merkle hash of a validator set, cached by Hash
+// together with the public keys and voting powers it was computed from. It's
+// immutable once built.
+type validatorSetHash struct {
+	hash    []byte
+	pubKeys [][]byte
+	powers  []int64
+}
+
+func newValidatorSetHash(vals []*Validator, hash []byte) *validatorSetHash {
+	h := &validatorSetHash{
+		hash:    hash,
+		pubKeys: make([][]byte, len(vals)),
+		powers:  make([]int64, len(vals)),
+	}
+	for i, val := range vals {
+		h.pubKeys[i] = validatorPubKeyBytes(val)
+		h.powers[i] = val.VotingPower
+	}
+	return h
+}
+
+// computedFor returns true if the hash was computed for vals, i.e. none of
+// them was replaced and none of their public keys or voting powers changed.
+// Public keys are compared by identity, not by value, which is much cheaper
+// than hashing the set: replacing a validator or its public key is detected,
+// but the bytes of a public key must never be modified in place.
+func (h *validatorSetHash) computedFor(vals []*Validator) bool {
+	if len(h.pubKeys) != len(vals) {
+		return false
+	}
+	for i, val := range vals {
+		if h.powers[i] != val.VotingPower || !sameBytes(h.pubKeys[i], validatorPubKeyBytes(val)) {
+			return false
+		}
+	}
+	return true
+}
+
+func validatorPubKeyBytes(val *Validator) []byte {
+	if val.PubKey == nil {
+		return nil
+	}
+	return val.PubKey.Bytes()
+}
+
+// cachedHash returns the hash cached by Hash, or nil if the validators changed
+// since.
+func (vals *ValidatorSet) cachedHash() []byte {
+	h, _ := vals.hash.Load().(*validatorSetHash)
+	if h == nil || !h.computedFor(vals.Validators) {
+		return nil
+	}
+	return h.hash
+}
+
+// invalidateHash discards the hash cached by Hash. The cache would be ignored
+// anyway once the validators changed, but it must not be kept: it would make
+// the set differ from an equal set (e.g. as compared by reflect.DeepEqual)
+// whose hash was never computed. It must be called by every mutator which
+// changes the validators or their voting powers, which like all mutators
+// isn't safe for concurrent use.
+func (vals *ValidatorSet) invalidateHash() {
+	vals.hash = atomic.Value{}
+}
@@ -2,10 +2,14 @@ package types
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"math"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"testing/quick"
 	"time"
@@ -15,6 +19,8 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -136,6 +142,47 @@ func TestValidatorSetValidateBasic(t *testing.T) {
 
 }
 
+func TestValidatorSetGetByIndexAndAddress(t *testing.T) {
+	vset, _ := RandValidatorSet(4, 10)
+
+	for i, expected := range vset.Validators {
+		addr, val := vset.GetByIndex(int32(i))
+		assert.Equal(t, []byte(expected.Address), addr)
+		assert.Equal(t, expected, val)
+
+		idx, val := vset.GetByAddress(expected.Address)
+		assert.EqualValues(t, i, idx)
+		assert.Equal(t, expected, val)
+	}
+
+	// a copy is returned
+	_, val := vset.GetByIndex(0)
+	val.VotingPower++
+	assert.NotEqual(t, val.VotingPower, vset.Validators[0].VotingPower)
+
+	for _, index := range []int32{-1, math.MinInt32, int32(vset.Size()), math.MaxInt32} {
+		addr, val := vset.GetByIndex(index)
+		assert.Nil(t, addr, "index %d", index)
+		assert.Nil(t, val, "index %d", index)
+	}
+
+	for _, addr := range [][]byte{nil, {}, []byte("absent"), ed25519.GenPrivKey().PubKey().Address()} {
+		idx, val := vset.GetByAddress(addr)
+		assert.EqualValues(t, -1, idx, "address %X", addr)
+		assert.Nil(t, val, "address %X", addr)
+	}
+
+	var nilSet *ValidatorSet
+	assert.NotPanics(t, func() {
+		addr, val := nilSet.GetByIndex(0)
+		assert.Nil(t, addr)
+		assert.Nil(t, val)
+		idx, val := nilSet.GetByAddress([]byte("absent"))
+		assert.EqualValues(t, -1, idx)
+		assert.Nil(t, val)
+	})
+}
+
 func TestCopy(t *testing.T) {
 	vset := randValidatorSet(10)
 	vsetHash := vset.Hash()
@@ -151,6 +198,89 @@ func TestCopy(t *testing.T) {
 	}
 }
 
+func TestValidatorSetHashCache(t *testing.T) {
+	vset := randValidatorSet(10)
+	hash := vset.Hash()
+	assert.Equal(t, hash, vset.Hash())
+	assert.Equal(t, hash, vset.cachedHash())
+
+	// the cached hash can't be modified through the returned one
+	vset.Hash()[0]++
+	assert.Equal(t, hash, vset.Hash())
+
+	// copies keep the cached hash
+	assert.Equal(t, hash, vset.Copy().cachedHash())
+
+	// adding a validator changes the hash
+	require.NoError(t, vset.UpdateWithChangeSet([]*Validator{randValidator(vset.TotalVotingPower())}))
+	hash2 := vset.Hash()
+	assert.NotEqual(t, hash, hash2)
+
+	// so does changing the power of one
+	val := vset.Validators[0].Copy()
+	val.VotingPower++
+	require.NoError(t, vset.UpdateWithChangeSet([]*Validator{val}))
+	hash3 := vset.Hash()
+	assert.NotEqual(t, hash2, hash3)
+
+	// and mutators discard the cached hash, so that the set equals its copies
+	require.NoError(t, vset.UpdateWithChangeSet([]*Validator{val.Copy()}))
+	assert.Nil(t, vset.hash.Load())
+	assert.Equal(t, vset.Copy(), vset)
+	assert.Equal(t, hash3, vset.Hash())
+
+	// proposer priorities are not part of the hash
+	vset.IncrementProposerPriority(3)
+	assert.Equal(t, hash3, vset.Hash())
+
+	// the hash is always the one computed from scratch
+	assert.Equal(t, NewValidatorSet(validatorListCopy(vset.Validators)).Hash(), vset.Hash())
+
+	// even if Validators is modified directly
+	vset.Validators[0] = randValidator(vset.TotalVotingPower())
+	hash4 := vset.Hash()
+	assert.NotEqual(t, hash3, hash4)
+	vset.Validators[1].VotingPower++
+	hash5 := vset.Hash()
+	assert.NotEqual(t, hash4, hash5)
+	vset.Validators[2].PubKey = ed25519.GenPrivKey().PubKey()
+	assert.NotEqual(t, hash5, vset.Hash())
+	vset.Validators = vset.Validators[1:]
+	assert.Equal(t, NewValidatorSet(validatorListCopy(vset.Validators)).Hash(), vset.Hash())
+
+	// concurrent reads are safe
+	vset = randValidatorSet(10)
+	hash = NewValidatorSet(validatorListCopy(vset.Validators)).Hash()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, hash, vset.Hash())
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkValidatorSetHash(b *testing.B) {
+	vset, _ := RandValidatorSet(1000, 10)
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			vset.hash.Store((*validatorSetHash)(nil))
+			vset.Hash()
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		vset.Hash()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			vset.Hash()
+		}
+	})
+}
+
 // Test that IncrementProposerPriority requires positive times.
 func TestIncrementProposerPriorityPositiveTimes(t *testing.T) {
 	vset := NewValidatorSet([]*Validator{
@@ -164,6 +294,33 @@ func TestIncrementProposerPriorityPositiveTimes(t *testing.T) {
 	vset.IncrementProposerPriority(1)
 }
 
+func BenchmarkValidatorSetVerifyCommit(b *testing.B) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 200, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(b, err)
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := valSet.VerifyCommit(chainID, blockID, h, commit); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := valSet.VerifyCommitParallel(chainID, blockID, h, commit, runtime.NumCPU()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func BenchmarkValidatorSetCopy(b *testing.B) {
 	b.StopTimer()
 	vset := NewValidatorSet([]*Validator{})
@@ -207,6 +364,153 @@ func TestProposerSelection1(t *testing.T) {
 	}
 }
 
+func TestTryNewValidatorSet(t *testing.T) {
+	half := MaxTotalVotingPower / 2
+
+	vset, err := TryNewValidatorSet([]*Validator{newValidator([]byte("a"), half), newValidator([]byte("b"), half)})
+	require.NoError(t, err)
+	assert.Equal(t, 2*half, vset.TotalVotingPower())
+
+	_, err = TryNewValidatorSet([]*Validator{newValidator([]byte("a"), half+1), newValidator([]byte("b"), half+1)})
+	assert.True(t, errors.Is(err, ErrTotalVotingPowerOverflow), err)
+	assert.Panics(t, func() {
+		NewValidatorSet([]*Validator{newValidator([]byte("a"), half+1), newValidator([]byte("b"), half+1)})
+	})
+
+	_, err = TryNewValidatorSet([]*Validator{newValidator([]byte("a"), math.MaxInt64)})
+	assert.Error(t, err)
+	_, err = TryNewValidatorSet([]*Validator{newValidator([]byte("a"), 1), newValidator([]byte("a"), 2)})
+	assert.Error(t, err)
+}
+
+func TestValidatorSetMinSignersFor(t *testing.T) {
+	twoThirds := tmmath.Fraction{Numerator: 2, Denominator: 3}
+
+	vset := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 5),
+		newValidator([]byte("b"), 30),
+		newValidator([]byte("c"), 50),
+		newValidator([]byte("d"), 15),
+	})
+	assert.Equal(t, 2, vset.MinSignersFor(twoThirds))
+	assert.Equal(t, 1, vset.MinSignersFor(tmmath.Fraction{Numerator: 0, Denominator: 1}))
+	assert.Equal(t, 3, vset.MinSignersFor(tmmath.Fraction{Numerator: 4, Denominator: 5}))
+	assert.Equal(t, 4, vset.MinSignersFor(tmmath.Fraction{Numerator: 95, Denominator: 100}))
+	assert.Equal(t, -1, vset.MinSignersFor(tmmath.Fraction{Numerator: 1, Denominator: 1}))
+	assert.Panics(t, func() { vset.MinSignersFor(tmmath.Fraction{Numerator: 1, Denominator: 0}) })
+
+	// exactly 2/3 is not enough
+	uniform, _ := RandValidatorSet(9, 10)
+	assert.Equal(t, 7, uniform.MinSignersFor(twoThirds))
+	uniform, _ = RandValidatorSet(10, 10)
+	assert.Equal(t, 7, uniform.MinSignersFor(twoThirds))
+}
+
+func TestValidatorSetEnforceKeyTypes(t *testing.T) {
+	edVals := NewValidatorSet([]*Validator{
+		NewValidator(ed25519.GenPrivKey().PubKey(), 10),
+		NewValidator(ed25519.GenPrivKey().PubKey(), 20),
+	})
+	assert.NoError(t, edVals.EnforceKeyTypes(ABCIPubKeyTypeEd25519))
+
+	mixedVals := NewValidatorSet([]*Validator{
+		NewValidator(ed25519.GenPrivKey().PubKey(), 10),
+		NewValidator(secp256k1.GenPrivKey().PubKey(), 20),
+	})
+	assert.Error(t, mixedVals.EnforceKeyTypes(ABCIPubKeyTypeEd25519))
+	assert.Error(t, mixedVals.EnforceKeyTypes())
+	assert.NoError(t, mixedVals.EnforceKeyTypes(ABCIPubKeyTypeEd25519, "secp256k1"))
+
+	noKeyVals := NewValidatorSet([]*Validator{newValidator([]byte("a"), 10)})
+	assert.Error(t, noKeyVals.EnforceKeyTypes(ABCIPubKeyTypeEd25519))
+}
+
+func TestProposerSelectionTieBreak(t *testing.T) {
+	a, b := newValidator([]byte("a"), 10), newValidator([]byte("b"), 10)
+
+	for _, vals := range [][]*Validator{{a, b}, {b, a}} {
+		// equal priorities: the smallest address wins regardless of the order
+		vset := &ValidatorSet{Validators: []*Validator{vals[0].Copy(), vals[1].Copy()}}
+		assert.EqualValues(t, "a", vset.GetProposer().Address)
+
+		vset = NewValidatorSet([]*Validator{vals[0].Copy(), vals[1].Copy()})
+		var proposers []string
+		for i := 0; i < 4; i++ {
+			proposers = append(proposers, string(vset.GetProposer().Address))
+			vset.IncrementProposerPriority(1)
+		}
+		assert.Equal(t, []string{"a", "b", "a", "b"}, proposers)
+	}
+}
+
+func TestValidatorSetInvariantCheck(t *testing.T) {
+	vset := createNewValidatorSet([]testVal{{"v1", 1}, {"v2", 10}, {"v3", 333}, {"v4", 1000}, {"v5", 5000}})
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, vset.InvariantCheck(), "round %d", i)
+		vset.IncrementProposerPriority(1 + int32(i%3))
+	}
+
+	// updates keep the invariant
+	require.NoError(t, vset.UpdateWithChangeSet(createNewValidatorList([]testVal{{"v1", 0}, {"v6", 777}})))
+	require.NoError(t, vset.InvariantCheck())
+
+	drifted := vset.Copy()
+	drifted.Validators[0].ProposerPriority += 3 * drifted.TotalVotingPower()
+	drifted.Validators[1].ProposerPriority -= 3 * drifted.TotalVotingPower()
+	assert.Error(t, drifted.InvariantCheck())
+
+	uncentered := vset.Copy()
+	uncentered.Validators[0].ProposerPriority += int64(len(uncentered.Validators))
+	assert.Error(t, uncentered.InvariantCheck())
+
+	assert.Error(t, (&ValidatorSet{}).InvariantCheck())
+}
+
+func TestValidatorSetSnapshot(t *testing.T) {
+	vset := NewValidatorSet([]*Validator{
+		NewValidator(ed25519.GenPrivKey().PubKey(), 10),
+		NewValidator(ed25519.GenPrivKey().PubKey(), 20),
+		NewValidator(ed25519.GenPrivKey().PubKey(), 30),
+	})
+	snap := vset.Snapshot()
+	var (
+		proposer   = snap.GetProposer()
+		priorities = make([]int64, snap.Size())
+		hash       = snap.Hash()
+	)
+	for i, val := range snap.Validators {
+		priorities[i] = val.ProposerPriority
+	}
+
+	// concurrent readers of the snapshot while the original is mutated
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.Equal(t, proposer, snap.GetProposer())
+				assert.Equal(t, hash, snap.Hash())
+				assert.EqualValues(t, 60, snap.TotalVotingPower())
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		vset.IncrementProposerPriority(1)
+	}
+	wg.Wait()
+	require.NoError(t, vset.UpdateWithChangeSet([]*Validator{NewValidator(ed25519.GenPrivKey().PubKey(), 40)}))
+
+	assert.Equal(t, proposer, snap.GetProposer())
+	assert.Equal(t, hash, snap.Hash())
+	for i, val := range snap.Validators {
+		assert.Equal(t, priorities[i], val.ProposerPriority)
+	}
+	assert.Equal(t, 3, snap.Size())
+
+	assert.True(t, (&ValidatorSet{}).Snapshot().IsNilOrEmpty())
+}
+
 func TestProposerSelection2(t *testing.T) {
 	addr0 := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 	addr1 := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
@@ -436,6 +740,33 @@ func TestValidatorSetTotalVotingPowerPanicsOnOverflow(t *testing.T) {
 	assert.Panics(t, shouldPanic)
 }
 
+func TestValidatorSetMaxValidators(t *testing.T) {
+	vals := func(from, to int) []*Validator {
+		valz := make([]*Validator, 0, to-from)
+		for i := from; i < to; i++ {
+			valz = append(valz, newValidator([]byte(fmt.Sprintf("val%05d", i)), 1))
+		}
+		return valz
+	}
+
+	// at the limit
+	var valSet *ValidatorSet
+	require.NotPanics(t, func() { valSet = NewValidatorSet(vals(0, MaxValidators)) })
+	assert.Equal(t, MaxValidators, valSet.Size())
+
+	// over the limit
+	assert.Panics(t, func() { NewValidatorSet(vals(0, MaxValidators+1)) })
+
+	// an update pushing the set over the limit is rejected and leaves it unchanged
+	assert.Equal(t, ErrTooManyValidators, valSet.UpdateWithChangeSet(vals(MaxValidators, MaxValidators+1)))
+	assert.Equal(t, MaxValidators, valSet.Size())
+
+	// replacing a validator keeps the set at the limit
+	changes := append(vals(MaxValidators, MaxValidators+1), newValidator([]byte("val00000"), 0))
+	assert.NoError(t, valSet.UpdateWithChangeSet(changes))
+	assert.Equal(t, MaxValidators, valSet.Size())
+}
+
 func TestAvgProposerPriority(t *testing.T) {
 	// Create Validator set without calling IncrementProposerPriority:
 	tcs := []struct {
@@ -741,6 +1072,78 @@ func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 	}
 }
 
+// hookedPubKey calls onVerify on every signature verification.
+type hookedPubKey struct {
+	crypto.PubKey
+	onVerify func()
+}
+
+func (pk hookedPubKey) VerifySignature(msg []byte, sig []byte) bool {
+	pk.onVerify()
+	return pk.PubKey.VerifySignature(msg, sig)
+}
+
+func TestValidatorSet_VerifyCommitWithContext(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4*verifyCommitCtxCheckInterval, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, valSet.VerifyCommitWithContext(context.Background(), chainID, blockID, h, commit))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	verified := 0
+	for _, val := range valSet.Validators {
+		val.PubKey = hookedPubKey{PubKey: val.PubKey, onVerify: func() {
+			verified++
+			cancel() // cancel after the first signature
+		}}
+	}
+
+	err = valSet.VerifyCommitWithContext(ctx, chainID, blockID, h, commit)
+	assert.True(t, errors.Is(err, context.Canceled), err)
+	assert.Equal(t, verifyCommitCtxCheckInterval, verified)
+}
+
+func TestValidatorSet_VerifyCommitParallel(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 100, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	for _, workers := range []int{1, 4, 200} {
+		assert.NoError(t, valSet.VerifyCommitParallel(chainID, blockID, h, commit, workers))
+	}
+	assert.Error(t, valSet.VerifyCommitParallel(chainID, makeBlockIDRandom(), h, commit, 4))
+	assert.Panics(t, func() { _ = valSet.VerifyCommitParallel(chainID, blockID, h, commit, 0) })
+
+	// invalidate signatures #5 and #50
+	for _, idx := range []int{50, 5} {
+		vote := voteSet.GetByIndex(int32(idx))
+		v := vote.ToProto()
+		require.NoError(t, vals[idx].SignVote("CentaurusA", v))
+		commit.Signatures[idx].Signature = v.Signature
+	}
+	serialErr := valSet.VerifyCommit(chainID, blockID, h, commit)
+	require.Error(t, serialErr)
+	for i := 0; i < 10; i++ {
+		err := valSet.VerifyCommitParallel(chainID, blockID, h, commit, 8)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "wrong signature (#5)")
+			assert.Equal(t, serialErr.Error(), err.Error())
+		}
+	}
+}
+
 func TestValidatorSet_VerifyCommit_CheckAllSignatures(t *testing.T) {
 	var (
 		chainID = "test_chain_id"
@@ -766,6 +1169,101 @@ func TestValidatorSet_VerifyCommit_CheckAllSignatures(t *testing.T) {
 	}
 }
 
+func TestValidatorSet_VerifyCommit_TamperedTimestamp(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	// the timestamp is part of the sign bytes, so changing it without
+	// re-signing invalidates the signature
+	commit.Signatures[0].Timestamp = commit.Signatures[0].Timestamp.Add(time.Hour)
+
+	err = valSet.VerifyCommit(chainID, blockID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#0)")
+	}
+	err = valSet.VerifyCommitLight(chainID, blockID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#0)")
+	}
+	err = valSet.VerifyCommitLightTrusting(chainID, commit, tmmath.Fraction{Numerator: 1, Denominator: 3})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#0)")
+	}
+}
+
+func TestValidatorSet_VerifyCommit_SplicedRound(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	// splice in the 2nd validator's precommit for the same block in round 1
+	vote := commit.GetVote(1)
+	vote.Round = 1
+	v := vote.ToProto()
+	require.NoError(t, vals[1].SignVote(chainID, v))
+	pubKey, err := vals[1].GetPubKey()
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(VoteSignBytes(chainID, v), v.Signature))
+	commit.Signatures[1].Signature = v.Signature
+
+	err = valSet.VerifyCommit(chainID, blockID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#1)")
+	}
+}
+
+func TestValidatorSet_VerifyCommitWithOptions(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+		now     = time.Now()
+		opts    = VerifyCommitOptions{MaxTimestampSpread: 10 * time.Second}
+	)
+
+	_, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	sigs := make([]CommitSig, len(vals))
+	for i, val := range vals {
+		// clustered within 3s, the last validator is absent
+		if i == 3 {
+			sigs[i] = NewCommitSigAbsent()
+			continue
+		}
+		sigs[i] = makeVote(t, val, chainID, int32(i), h, 0, 2, blockID, now.Add(time.Duration(i)*time.Second)).
+			CommitSig()
+	}
+	commit := NewCommit(h, 0, blockID, sigs)
+	assert.NoError(t, valSet.VerifyCommitWithOptions(chainID, blockID, h, commit, opts))
+
+	// an outlier, correctly signed
+	sigs[2] = makeVote(t, vals[2], chainID, 2, h, 0, 2, blockID, now.Add(time.Hour)).CommitSig()
+	commit = NewCommit(h, 0, blockID, sigs)
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	err := valSet.VerifyCommitWithOptions(chainID, blockID, h, commit, opts)
+	assert.True(t, errors.Is(err, ErrCommitTimestampSpread), err)
+
+	// the check is disabled
+	assert.NoError(t, valSet.VerifyCommitWithOptions(chainID, blockID, h, commit, VerifyCommitOptions{}))
+
+	// the usual checks are still performed
+	sigs[2].Signature = sigs[1].Signature
+	commit = NewCommit(h, 0, blockID, sigs)
+	assert.Error(t, valSet.VerifyCommitWithOptions(chainID, blockID, h, commit, VerifyCommitOptions{}))
+}
+
 func TestValidatorSet_VerifyCommitLight_ReturnsAsSoonAsMajorityOfVotingPowerSigned(t *testing.T) {
 	var (
 		chainID = "test_chain_id"
@@ -1537,6 +2035,42 @@ func TestValidatorSet_VerifyCommitLightTrusting(t *testing.T) {
 	}
 }
 
+func TestValidatorSet_VerifyCommitLightTrusting_PartialOverlap(t *testing.T) {
+	var (
+		blockID                  = makeBlockIDRandom()
+		voteSet, commitVals, pvs = randVoteSet(1, 1, tmproto.PrecommitType, 5, 1)
+		commit, err              = MakeCommit(blockID, 1, 1, voteSet, pvs, time.Now())
+		trustLevel               = tmmath.Fraction{Numerator: 1, Denominator: 3}
+	)
+	require.NoError(t, err)
+
+	// trustedSet returns a set of 5 validators with equal power, overlap of
+	// which are also in the commit's validator set.
+	trustedSet := func(overlap int) *ValidatorSet {
+		others, _ := RandValidatorSet(5-overlap, 1)
+		vals := make([]*Validator, 0, 5)
+		for _, val := range commitVals.Validators[:overlap] {
+			vals = append(vals, val.Copy())
+		}
+		return NewValidatorSet(append(vals, others.Validators...))
+	}
+
+	// 2/5 of the trusted power signed, which is more than 1/3
+	assert.NoError(t, trustedSet(2).VerifyCommitLightTrusting("test_chain_id", commit, trustLevel))
+
+	// 1/5 of the trusted power signed, which is less than 1/3
+	err = trustedSet(1).VerifyCommitLightTrusting("test_chain_id", commit, trustLevel)
+	assert.True(t, IsErrNotEnoughVotingPowerSigned(err), err)
+
+	// the same signature counted twice is rejected
+	dupCommit := *commit
+	dupCommit.Signatures = append([]CommitSig{commit.Signatures[0]}, commit.Signatures...)
+	err = trustedSet(1).VerifyCommitLightTrusting("test_chain_id", &dupCommit, trustLevel)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "double vote")
+	}
+}
+
 func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 	var (
 		blockID               = makeBlockIDRandom()
@@ -1552,6 +2086,41 @@ func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 	}
 }
 
+func TestValidatorSet_VerifyCommitErrorsOnVotingPowerOverflow(t *testing.T) {
+	const power = math.MaxInt64/2 + 1
+
+	var (
+		chainID               = "test_chain_id"
+		blockID               = makeBlockIDRandom()
+		voteSet, valSet, vals = randVoteSet(1, 1, tmproto.PrecommitType, 2, 10)
+		commit, err           = MakeCommit(blockID, 1, 1, voteSet, vals, time.Now())
+	)
+	require.NoError(t, err)
+
+	// such a set can't be constructed ...
+	assert.Panics(t, func() {
+		NewValidatorSet([]*Validator{
+			NewValidator(ed25519.GenPrivKey().PubKey(), power),
+			NewValidator(ed25519.GenPrivKey().PubKey(), power),
+		})
+	})
+
+	// ... but if it were, tallying must not wrap around.
+	for _, val := range valSet.Validators {
+		val.VotingPower = power
+	}
+	valSet.totalVotingPower = math.MaxInt64 / 2
+
+	err = valSet.VerifyCommit(chainID, blockID, 1, commit)
+	assert.Equal(t, ErrVotingPowerOverflow, err)
+	err = valSet.VerifyCommitLightTrusting(chainID, commit, tmmath.Fraction{Numerator: 2, Denominator: 1})
+	assert.Equal(t, ErrVotingPowerOverflow, err)
+
+	valSet.totalVotingPower = math.MaxInt64
+	err = valSet.VerifyCommitLight(chainID, blockID, 1, commit)
+	assert.Equal(t, ErrVotingPowerOverflow, err)
+}
+
 func TestSafeMul(t *testing.T) {
 	testCases := []struct {
 		a        int64
@@ -1578,6 +2147,45 @@ func TestSafeMul(t *testing.T) {
 	}
 }
 
+func TestValidatorSetDiff(t *testing.T) {
+	oldVals, _ := RandValidatorSet(5, 10)
+
+	var (
+		removed = oldVals.Validators[0]
+		changed = oldVals.Validators[1].Copy()
+		added   = randValidator(oldVals.TotalVotingPower())
+	)
+	changed.VotingPower += 5
+
+	newVals := oldVals.Copy()
+	require.NoError(t, newVals.UpdateWithChangeSet([]*Validator{
+		{Address: removed.Address, PubKey: removed.PubKey, VotingPower: 0},
+		changed,
+		added,
+	}))
+
+	diff := ValidatorSetDiff(oldVals, newVals)
+	require.Len(t, diff, 3)
+	for _, val := range diff {
+		switch {
+		case bytes.Equal(val.Address, removed.Address):
+			assert.Zero(t, val.VotingPower)
+		case bytes.Equal(val.Address, changed.Address):
+			assert.Equal(t, changed.VotingPower, val.VotingPower)
+		case bytes.Equal(val.Address, added.Address):
+			assert.Equal(t, added.VotingPower, val.VotingPower)
+		default:
+			t.Errorf("unexpected change %v", val)
+		}
+	}
+
+	require.NoError(t, oldVals.UpdateWithChangeSet(diff))
+	assert.Equal(t, newVals.Hash(), oldVals.Hash())
+
+	// no changes between identical sets
+	assert.Empty(t, ValidatorSetDiff(oldVals, newVals))
+}
+
 func TestValidatorSetProtoBuf(t *testing.T) {
 	valset, _ := RandValidatorSet(10, 100)
 	valset2, _ := RandValidatorSet(10, 100)
@@ -1694,3 +2302,120 @@ func BenchmarkUpdates(b *testing.B) {
 		assert.NoError(b, valSetCopy.UpdateWithChangeSet(newValList))
 	}
 }
+
+func TestValidatorSetMarshalJSONDeterministic(t *testing.T) {
+	valz := []*Validator{
+		NewValidator(randPubKey(), 10),
+		NewValidator(randPubKey(), 20),
+		NewValidator(randPubKey(), 20),
+		NewValidator(randPubKey(), 30),
+	}
+	reversed := make([]*Validator, len(valz))
+	for i, val := range valz {
+		reversed[len(valz)-1-i] = val.Copy()
+	}
+	vals1 := NewValidatorSet(valz)
+	vals2 := NewValidatorSet(reversed)
+
+	bz1, err := tmjson.Marshal(vals1)
+	require.NoError(t, err)
+	bz2, err := tmjson.Marshal(vals2)
+	require.NoError(t, err)
+	assert.Equal(t, bz1, bz2)
+
+	// the order of the set is kept
+	vals3 := vals1.Copy()
+	vals3.Validators[0], vals3.Validators[3] = vals3.Validators[3], vals3.Validators[0]
+	bz3, err := tmjson.Marshal(vals3)
+	require.NoError(t, err)
+	assert.NotEqual(t, bz1, bz3)
+
+	var decoded3 ValidatorSet
+	require.NoError(t, tmjson.Unmarshal(bz3, &decoded3))
+	assert.Equal(t, vals3.Validators, decoded3.Validators)
+	assert.Equal(t, vals3.Hash(), decoded3.Hash())
+
+	var decoded ValidatorSet
+	require.NoError(t, tmjson.Unmarshal(bz1, &decoded))
+	assert.Equal(t, vals1.Validators, decoded.Validators)
+	assert.Equal(t, vals1.Proposer, decoded.Proposer)
+	assert.Equal(t, vals1.Hash(), decoded.Hash())
+	assert.Equal(t, vals1.TotalVotingPower(), decoded.TotalVotingPower())
+}
+
+func TestValidatorSetRescaleProposerPriorities(t *testing.T) {
+	const diffMax = int64(1000)
+	valSet := NewValidatorSet([]*Validator{
+		NewValidator(randPubKey(), 10),
+		NewValidator(randPubKey(), 20),
+		NewValidator(randPubKey(), 30),
+		NewValidator(randPubKey(), 40),
+	})
+	priorities := []int64{math.MaxInt64, math.MinInt64, math.MaxInt64 / 3, -42}
+	for i, val := range valSet.Validators {
+		val.ProposerPriority = priorities[i]
+	}
+	// addresses of the validators, by increasing priority
+	byPriority := func() []Address {
+		sorted := validatorListCopy(valSet.Validators)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].ProposerPriority < sorted[j].ProposerPriority
+		})
+		addrs := make([]Address, len(sorted))
+		for i, val := range sorted {
+			addrs[i] = val.Address
+		}
+		return addrs
+	}
+	order := byPriority()
+
+	valSet.RescaleProposerPriorities(diffMax)
+	assert.LessOrEqual(t, computeMaxMinPriorityDiff(valSet), diffMax)
+	assert.InDelta(t, 0, valSet.computeAvgProposerPriority(), 1)
+	assert.Equal(t, order, byPriority(), "the order of priorities should be preserved")
+
+	// priorities within diffMax are only centered
+	for i, val := range valSet.Validators {
+		val.ProposerPriority = int64(100 * i)
+	}
+	valSet.RescaleProposerPriorities(diffMax)
+	for i, val := range valSet.Validators {
+		assert.EqualValues(t, 100*i-150, val.ProposerPriority)
+	}
+	assert.NotPanics(t, func() { valSet.IncrementProposerPriority(10) })
+
+	assert.Panics(t, func() { (&ValidatorSet{}).RescaleProposerPriorities(diffMax) })
+}
+
+func TestValidatorSetForEach(t *testing.T) {
+	vals := make([]*Validator, 5)
+	for i := range vals {
+		vals[i] = NewValidator(randPubKey(), int64(i+1))
+	}
+	valSet := NewValidatorSet(vals)
+
+	var (
+		sum     int64
+		indexes []int
+	)
+	valSet.ForEach(func(index int, val *Validator) bool {
+		assert.Equal(t, valSet.Validators[index], val)
+		sum += val.VotingPower
+		indexes = append(indexes, index)
+		return false
+	})
+	assert.EqualValues(t, 15, sum)
+	assert.Equal(t, valSet.TotalVotingPower(), sum)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, indexes)
+}
+
+func TestValidatorSetForEachStop(t *testing.T) {
+	valSet, _ := RandValidatorSet(5, 1)
+
+	var visited []*Validator
+	valSet.ForEach(func(index int, val *Validator) bool {
+		visited = append(visited, val)
+		return index == 2
+	})
+	assert.Equal(t, valSet.Validators[:3], visited)
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto/sr25519"
+)
+
+// VRFProof proves that a proposer was sampled by
+// ValidatorSet.SampleProposerVRF.
+type VRFProof struct {
+	// Proof is the sr25519 VRF proof over the seed.
+	Proof []byte
+}
+
+// SampleProposerVRF is an opt-in alternative to the deterministic proposer
+// priority scheme: it picks a validator at random, weighted by voting power,
+// using the VRF hash of the seed under privKey. The result is reproducible for
+// a given key and seed, but can't be predicted without the private key; anyone
+// with the public key can check it using VerifyProposerVRF.
+func (vals *ValidatorSet) SampleProposerVRF(privKey sr25519.PrivKey, seed []byte) (*Validator, *VRFProof, error) {
+	if vals.IsNilOrEmpty() {
+		return nil, nil, errors.New("empty validator set")
+	}
+	hash, proof, err := privKey.VRFProve(seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vals.proposerFromVRFHash(hash), &VRFProof{Proof: proof}, nil
+}
+
+// VerifyProposerVRF returns an error unless proof shows that the validator
+// with proposerAddress was sampled by SampleProposerVRF from the seed, using
+// the private key of pubKey.
+func (vals *ValidatorSet) VerifyProposerVRF(pubKey sr25519.PubKey, seed []byte, proposerAddress Address,
+	proof *VRFProof) error {
+	if vals.IsNilOrEmpty() {
+		return errors.New("empty validator set")
+	}
+	if proof == nil {
+		return errors.New("nil VRF proof")
+	}
+	hash, err := pubKey.VRFVerify(seed, proof.Proof)
+	if err != nil {
+		return err
+	}
+	if proposer := vals.proposerFromVRFHash(hash); !bytes.Equal(proposer.Address, proposerAddress) {
+		return fmt.Errorf("wrong proposer: got %X, sampled %X", proposerAddress, proposer.Address)
+	}
+	return nil
+}
+
+// proposerFromVRFHash maps the hash, interpreted as a big-endian integer,
+// modulo the total voting power, to the validator owning that unit of power.
+func (vals *ValidatorSet) proposerFromVRFHash(hash []byte) *Validator {
+	r := new(big.Int).SetBytes(hash)
+	r.Mod(r, big.NewInt(vals.TotalVotingPower()))
+	target := r.Int64()
+
+	var cumulative int64
+	for _, val := range vals.Validators {
+		cumulative += val.VotingPower
+		if target < cumulative {
+			return val
+		}
+	}
+	panic("unreachable: target is below the total voting power")
+}
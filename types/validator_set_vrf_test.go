@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/sr25519"
+)
+
+func TestSampleProposerVRF(t *testing.T) {
+	var (
+		valSet = NewValidatorSet([]*Validator{
+			newValidator([]byte("a"), 10),
+			newValidator([]byte("b"), 30),
+			newValidator([]byte("c"), 60),
+		})
+		privKey = sr25519.GenPrivKey()
+		pubKey  = privKey.PubKey().(sr25519.PubKey)
+	)
+
+	// reproducible and verifiable
+	proposer, proof, err := valSet.SampleProposerVRF(privKey, []byte("seed"))
+	require.NoError(t, err)
+	again, _, err := valSet.SampleProposerVRF(privKey, []byte("seed"))
+	require.NoError(t, err)
+	assert.Equal(t, proposer, again)
+	assert.NoError(t, valSet.VerifyProposerVRF(pubKey, []byte("seed"), proposer.Address, proof))
+
+	for _, val := range valSet.Validators {
+		if val != proposer {
+			assert.Error(t, valSet.VerifyProposerVRF(pubKey, []byte("seed"), val.Address, proof))
+		}
+	}
+	assert.Error(t, valSet.VerifyProposerVRF(pubKey, []byte("other seed"), proposer.Address, proof))
+	other := sr25519.GenPrivKey().PubKey().(sr25519.PubKey)
+	assert.Error(t, valSet.VerifyProposerVRF(other, []byte("seed"), proposer.Address, proof))
+	assert.Error(t, valSet.VerifyProposerVRF(pubKey, []byte("seed"), proposer.Address, nil))
+
+	// power-weighted
+	const n = 2000
+	counts := make(map[string]int)
+	seed := make([]byte, 8)
+	for i := 0; i < n; i++ {
+		binary.BigEndian.PutUint64(seed, uint64(i))
+		proposer, _, err := valSet.SampleProposerVRF(privKey, seed)
+		require.NoError(t, err)
+		counts[string(proposer.Address)]++
+	}
+	total := valSet.TotalVotingPower()
+	for _, val := range valSet.Validators {
+		expected := float64(n) * float64(val.VotingPower) / float64(total)
+		assert.InDelta(t, expected, counts[string(val.Address)], 0.05*n, "validator %s", val.Address)
+	}
+
+	_, _, err = (&ValidatorSet{}).SampleProposerVRF(privKey, []byte("seed"))
+	assert.Error(t, err)
+}
@@ -97,3 +97,23 @@ func TestValidatorValidateBasic(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatorCompareProposerPriority(t *testing.T) {
+	a := &Validator{Address: []byte("a"), ProposerPriority: 1}
+	b := &Validator{Address: []byte("b"), ProposerPriority: 1}
+	c := &Validator{Address: []byte("c"), ProposerPriority: 2}
+
+	testCases := []struct {
+		v, other *Validator
+		exp      int
+	}{
+		{c, a, 1},
+		{a, c, -1},
+		{a, b, 1}, // equal priorities: smallest address wins
+		{b, a, -1},
+		{a, a.Copy(), 0},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.exp, tc.v.CompareProposerPriority(tc.other), "%v vs %v", tc.v, tc.other)
+	}
+}
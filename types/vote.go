@@ -2,20 +2,32 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/protoio"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 const (
-	// MaxVoteBytes is a maximum vote size (including amino overhead).
+	// MaxVoteBytes is a maximum vote size (including amino overhead), without
+	// an extension, which commits and evidence don't include.
 	MaxVoteBytes int64  = 209
 	nilVoteStr   string = "nil-Vote"
+
+	// MaxVoteExtensionSize is the maximum size of a vote's extension.
+	MaxVoteExtensionSize = 64
+
+	// canonicalVoteExtensionKey is the protobuf key (field 8, bytes) under which
+	// the extension of a precommit is appended to CanonicalVote sign bytes. See
+	// VoteExtensionSignBytes.
+	canonicalVoteExtensionKey byte = 8<<3 | 2
 )
 
 var (
@@ -26,8 +38,14 @@ var (
 	ErrVoteInvalidBlockHash          = errors.New("invalid block hash")
 	ErrVoteNonDeterministicSignature = errors.New("non-deterministic signature")
 	ErrVoteNil                       = errors.New("nil vote")
+	ErrVoteDoubleSign                = errors.New("refusing to double sign")
+	ErrVoteFromUnlistedValidator     = errors.New("vote from a validator not in the allowed list")
 )
 
+// ErrVoteConflictingVotes is returned by VoteSet.AddVote when the validator
+// already voted for another block. VoteA is the vote already in the set and
+// VoteB the new one, so the pair can be used to build DuplicateVoteEvidence.
+// It can be extracted from wrapped errors with errors.As.
 type ErrVoteConflictingVotes struct {
 	VoteA *Vote
 	VoteB *Vote
@@ -58,6 +76,13 @@ type Vote struct {
 	ValidatorAddress Address               `json:"validator_address"`
 	ValidatorIndex   int32                 `json:"validator_index"`
 	Signature        []byte                `json:"signature"`
+
+	// Extension is application data attached to a precommit. It's signed
+	// separately (see VoteExtensionSignBytes), so that Signature can still be
+	// verified from a commit, which doesn't include the extension. Evidence
+	// doesn't include it either (see ErrEvidenceVoteExtension).
+	Extension          []byte `json:"extension,omitempty"`
+	ExtensionSignature []byte `json:"extension_signature,omitempty"`
 }
 
 // CommitSig converts the Vote to a CommitSig.
@@ -100,11 +125,99 @@ func VoteSignBytes(chainID string, vote *tmproto.Vote) []byte {
 	return bz
 }
 
+// VoteSignBytesVersion returns the sign bytes of the Vote under the given
+// block protocol version, so that votes signed under an earlier version (e.g.
+// those contained in old evidence) can be verified.
+//
+// The layout of CanonicalVote hasn't changed in any block protocol version so
+// far, so every version up to version.BlockProtocol has the sign bytes of
+// VoteSignBytes. When the layout changes, the previous one must be kept here
+// for the versions which used it. An error is returned for versions after
+// version.BlockProtocol, whose layout is unknown.
+func VoteSignBytesVersion(chainID string, vote *tmproto.Vote, blockVersion uint64) ([]byte, error) {
+	if blockVersion > version.BlockProtocol {
+		return nil, fmt.Errorf("unknown block protocol version %d (latest: %d)", blockVersion, version.BlockProtocol)
+	}
+	return VoteSignBytes(chainID, vote), nil
+}
+
+// VoteExtensionSignBytes returns the sign bytes of the extension of the vote:
+// its canonical vote (see CanonicalizeVote) with the extension appended as an
+// extra bytes field. They never match the sign bytes of a vote (see
+// VoteSignBytes), so neither signature can be passed off as the other.
+//
+// Panics if the marshaling fails.
+func VoteExtensionSignBytes(chainID string, vote *Vote) []byte {
+	pb := CanonicalizeVote(chainID, vote.ToProto())
+	bz, err := pb.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, uint64(len(vote.Extension)))
+	bz = append(bz, canonicalVoteExtensionKey)
+	bz = append(bz, buf[:n]...)
+	bz = append(bz, vote.Extension...)
+
+	n = binary.PutUvarint(buf, uint64(len(bz)))
+	return append(buf[:n:n], bz...)
+}
+
+// ContentHash returns the hash of the canonical vote (see CanonicalizeVote)
+// for the given chain ID without its timestamp. Like the canonical vote, it
+// doesn't include the validator address nor the signature, so all votes for
+// the same consensus decision (height, round, type and BlockID) have the same
+// content hash, whoever signed them.
+func (vote *Vote) ContentHash(chainID string) []byte {
+	pb := CanonicalizeVote(chainID, vote.ToProto())
+	pb.Timestamp = time.Time{}
+	bz, err := protoio.MarshalDelimited(&pb)
+	if err != nil {
+		panic(err)
+	}
+	return tmhash.Sum(bz)
+}
+
+// Copy returns a deep copy of the vote: the copy shares no byte slices (block
+// hashes, validator address, signatures or extension) with the original.
 func (vote *Vote) Copy() *Vote {
 	voteCopy := *vote
+	voteCopy.BlockID.Hash = copyBytes(vote.BlockID.Hash)
+	voteCopy.BlockID.PartSetHeader.Hash = copyBytes(vote.BlockID.PartSetHeader.Hash)
+	voteCopy.ValidatorAddress = copyBytes(vote.ValidatorAddress)
+	voteCopy.Signature = copyBytes(vote.Signature)
+	voteCopy.Extension = copyBytes(vote.Extension)
+	voteCopy.ExtensionSignature = copyBytes(vote.ExtensionSignature)
+	return &voteCopy
+}
+
+// withoutExtension returns the vote if it has no extension, and otherwise a
+// shallow copy of it without the extension and its signature.
+func (vote *Vote) withoutExtension() *Vote {
+	if vote == nil || (len(vote.Extension) == 0 && len(vote.ExtensionSignature) == 0) {
+		return vote
+	}
+	voteCopy := *vote
+	voteCopy.Extension = nil
+	voteCopy.ExtensionSignature = nil
 	return &voteCopy
 }
 
+// EqualExceptTimestamp returns true if both votes are for the same consensus
+// decision, i.e. they are equal except for their timestamps and hence their
+// signatures.
+func (vote *Vote) EqualExceptTimestamp(other *Vote) bool {
+	if vote == nil || other == nil {
+		return vote == other
+	}
+	return vote.Type == other.Type &&
+		vote.Height == other.Height &&
+		vote.Round == other.Round &&
+		vote.BlockID.Equals(other.BlockID) &&
+		bytes.Equal(vote.ValidatorAddress, other.ValidatorAddress) &&
+		vote.ValidatorIndex == other.ValidatorIndex
+}
+
 // String returns a string representation of Vote.
 //
 // 1. validator index
@@ -144,6 +257,34 @@ func (vote *Vote) String() string {
 	)
 }
 
+// StringIndented returns a multi-line string representation of the vote,
+// which, unlike String, includes the full block ID, validator address and
+// signature.
+func (vote *Vote) StringIndented(indent string) string {
+	if vote == nil {
+		return nilVoteStr
+	}
+	return fmt.Sprintf(`Vote{
+%s  Type:             %v
+%s  Height:           %d
+%s  Round:            %d
+%s  BlockID:          %v
+%s  Timestamp:        %s
+%s  ValidatorAddress: %v
+%s  ValidatorIndex:   %d
+%s  Signature:        %X
+%s}`,
+		indent, vote.Type,
+		indent, vote.Height,
+		indent, vote.Round,
+		indent, vote.BlockID,
+		indent, CanonicalTime(vote.Timestamp),
+		indent, vote.ValidatorAddress,
+		indent, vote.ValidatorIndex,
+		indent, vote.Signature,
+		indent)
+}
+
 func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
 	if !bytes.Equal(pubKey.Address(), vote.ValidatorAddress) {
 		return ErrVoteInvalidValidatorAddress
@@ -152,9 +293,62 @@ func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
 	if !pubKey.VerifySignature(VoteSignBytes(chainID, v), vote.Signature) {
 		return ErrVoteInvalidSignature
 	}
+	if len(vote.Extension) > 0 &&
+		!pubKey.VerifySignature(VoteExtensionSignBytes(chainID, vote), vote.ExtensionSignature) {
+		return ErrVoteInvalidSignature
+	}
 	return nil
 }
 
+// VerifyFromAllowed verifies the vote like Verify, using the key in +allowed+
+// whose address matches the vote's validator address. It returns
+// ErrVoteFromUnlistedValidator if there's no such key.
+func (vote *Vote) VerifyFromAllowed(chainID string, allowed []crypto.PubKey) error {
+	for _, pubKey := range allowed {
+		if bytes.Equal(pubKey.Address(), vote.ValidatorAddress) {
+			return vote.Verify(chainID, pubKey)
+		}
+	}
+	return ErrVoteFromUnlistedValidator
+}
+
+// IsEquivocation returns true if the two votes constitute a punishable
+// equivocation: they are from the same validator, for the same height, round
+// and type, but for different blocks. It returns false if the votes aren't
+// for the same height, round and type, and an error if they are nil, from
+// different validators (ErrEvidenceAddressMismatch) or for the same block
+// (ErrEvidenceSameBlockID). Signatures aren't verified. Extensions are
+// ignored: precommits for the same block with different extensions aren't an
+// equivocation.
+func IsEquivocation(v1, v2 *Vote) (bool, error) {
+	if v1 == nil || v2 == nil {
+		return false, ErrVoteNil
+	}
+	if !bytes.Equal(v1.ValidatorAddress, v2.ValidatorAddress) {
+		return false, fmt.Errorf("%w: %X vs %X", ErrEvidenceAddressMismatch,
+			v1.ValidatorAddress, v2.ValidatorAddress)
+	}
+	if v1.Height != v2.Height || v1.Round != v2.Round || v1.Type != v2.Type {
+		return false, nil
+	}
+	if v1.BlockID.Equals(v2.BlockID) {
+		return false, fmt.Errorf("%w (%v) - not a real duplicate vote", ErrEvidenceSameBlockID, v1.BlockID)
+	}
+	return true, nil
+}
+
+// IsPartSetConflict returns true if both votes are for the same block hash,
+// but different part set headers. Such votes are still an equivocation (see
+// IsEquivocation): peers can't tell which parts make up the block the
+// validator voted for. Nil votes return false.
+func IsPartSetConflict(v1, v2 *Vote) bool {
+	if v1 == nil || v2 == nil {
+		return false
+	}
+	return bytes.Equal(v1.BlockID.Hash, v2.BlockID.Hash) &&
+		!v1.BlockID.PartSetHeader.Equals(v2.BlockID.PartSetHeader)
+}
+
 // ValidateBasic performs basic validation.
 func (vote *Vote) ValidateBasic() error {
 	if !IsVoteTypeValid(vote.Type) {
@@ -177,8 +371,8 @@ func (vote *Vote) ValidateBasic() error {
 
 	// BlockID.ValidateBasic would not err if we for instance have an empty hash but a
 	// non-empty PartsSetHeader:
-	if !vote.BlockID.IsZero() && !vote.BlockID.IsComplete() {
-		return fmt.Errorf("blockID must be either empty or complete, got: %v", vote.BlockID)
+	if err := vote.BlockID.ValidateZeroOrComplete(); err != nil {
+		return err
 	}
 
 	if len(vote.ValidatorAddress) != crypto.AddressSize {
@@ -198,6 +392,23 @@ func (vote *Vote) ValidateBasic() error {
 		return fmt.Errorf("signature is too big (max: %d)", MaxSignatureSize)
 	}
 
+	if len(vote.Extension) > 0 {
+		if vote.Type != tmproto.PrecommitType {
+			return errors.New("extension is only allowed for precommits")
+		}
+		if len(vote.Extension) > MaxVoteExtensionSize {
+			return fmt.Errorf("extension is too big (max: %d)", MaxVoteExtensionSize)
+		}
+		if len(vote.ExtensionSignature) == 0 {
+			return errors.New("extension signature is missing")
+		}
+		if len(vote.ExtensionSignature) > MaxSignatureSize {
+			return fmt.Errorf("extension signature is too big (max: %d)", MaxSignatureSize)
+		}
+	} else if len(vote.ExtensionSignature) > 0 {
+		return errors.New("extension signature without extension")
+	}
+
 	return nil
 }
 
@@ -217,6 +428,9 @@ func (vote *Vote) ToProto() *tmproto.Vote {
 		ValidatorAddress: vote.ValidatorAddress,
 		ValidatorIndex:   vote.ValidatorIndex,
 		Signature:        vote.Signature,
+
+		Extension:          vote.Extension,
+		ExtensionSignature: vote.ExtensionSignature,
 	}
 }
 
@@ -241,6 +455,8 @@ func VoteFromProto(pv *tmproto.Vote) (*Vote, error) {
 	vote.ValidatorAddress = pv.ValidatorAddress
 	vote.ValidatorIndex = pv.ValidatorIndex
 	vote.Signature = pv.Signature
+	vote.Extension = pv.Extension
+	vote.ExtensionSignature = pv.ExtensionSignature
 
 	return vote, vote.ValidateBasic()
 }
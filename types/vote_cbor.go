@@ -0,0 +1,330 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// VoteToCBOR encodes the vote as a CBOR (RFC 8949) map, for clients which
+// can't decode protobuf. This is an interop encoding only: votes are still
+// signed over their canonical protobuf encoding (see VoteSignBytes).
+//
+// The map has the following keys, in the deterministic order of RFC 8949
+// section 4.2.1:
+//
+//	"type":              unsigned int (SignedMsgType)
+//	"round":             int
+//	"height":            int
+//	"block_id":          map {"hash": bytes, "parts": map {"hash": bytes, "total": unsigned int}}
+//	"signature":         bytes
+//	"timestamp":         array [int (seconds since Unix epoch), unsigned int (nanoseconds)]
+//	"validator_index":   int
+//	"validator_address": bytes
+func VoteToCBOR(v *Vote) ([]byte, error) {
+	if v == nil {
+		return nil, errors.New("nil vote")
+	}
+
+	var w cborWriter
+	w.writeHeader(cborMap, 8)
+	w.writeString("type")
+	w.writeInt(int64(v.Type))
+	w.writeString("round")
+	w.writeInt(int64(v.Round))
+	w.writeString("height")
+	w.writeInt(v.Height)
+	w.writeString("block_id")
+	w.writeHeader(cborMap, 2)
+	w.writeString("hash")
+	w.writeBytes(v.BlockID.Hash)
+	w.writeString("parts")
+	w.writeHeader(cborMap, 2)
+	w.writeString("hash")
+	w.writeBytes(v.BlockID.PartSetHeader.Hash)
+	w.writeString("total")
+	w.writeInt(int64(v.BlockID.PartSetHeader.Total))
+	w.writeString("signature")
+	w.writeBytes(v.Signature)
+	w.writeString("timestamp")
+	w.writeHeader(cborArray, 2)
+	w.writeInt(v.Timestamp.Unix())
+	w.writeInt(int64(v.Timestamp.Nanosecond()))
+	w.writeString("validator_index")
+	w.writeInt(int64(v.ValidatorIndex))
+	w.writeString("validator_address")
+	w.writeBytes(v.ValidatorAddress)
+	return w.buf, nil
+}
+
+// VoteFromCBOR decodes a vote encoded by VoteToCBOR. The map keys may be in
+// any order, but all of them must be present and no others are allowed. The
+// vote is not validated (see Vote.ValidateBasic).
+func VoteFromCBOR(bz []byte) (*Vote, error) {
+	r := cborReader{buf: bz}
+	val, err := r.readValue(0)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.buf) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes", len(r.buf))
+	}
+
+	m, err := cborFields(val, "type", "round", "height", "block_id", "signature",
+		"timestamp", "validator_index", "validator_address")
+	if err != nil {
+		return nil, err
+	}
+	blockID, err := cborFields(m["block_id"], "hash", "parts")
+	if err != nil {
+		return nil, fmt.Errorf("block_id: %w", err)
+	}
+	parts, err := cborFields(blockID["parts"], "hash", "total")
+	if err != nil {
+		return nil, fmt.Errorf("block_id.parts: %w", err)
+	}
+	timestamp, ok := m["timestamp"].([]interface{})
+	if !ok || len(timestamp) != 2 {
+		return nil, errors.New("timestamp: expected an array of 2 ints")
+	}
+
+	var (
+		vote = new(Vote)
+		d    = cborFieldDecoder{}
+	)
+	vote.Type = tmproto.SignedMsgType(d.int(m["type"], "type", 0, math.MaxInt32))
+	vote.Round = int32(d.int(m["round"], "round", math.MinInt32, math.MaxInt32))
+	vote.Height = d.int(m["height"], "height", math.MinInt64, math.MaxInt64)
+	vote.BlockID.Hash = d.bytes(blockID["hash"], "block_id.hash")
+	vote.BlockID.PartSetHeader.Hash = d.bytes(parts["hash"], "block_id.parts.hash")
+	vote.BlockID.PartSetHeader.Total = uint32(d.int(parts["total"], "block_id.parts.total", 0, math.MaxUint32))
+	vote.Signature = d.bytes(m["signature"], "signature")
+	secs := d.int(timestamp[0], "timestamp seconds", math.MinInt64, math.MaxInt64)
+	nanos := d.int(timestamp[1], "timestamp nanoseconds", 0, 999999999)
+	vote.Timestamp = time.Unix(secs, nanos).UTC()
+	vote.ValidatorIndex = int32(d.int(m["validator_index"], "validator_index", math.MinInt32, math.MaxInt32))
+	vote.ValidatorAddress = d.bytes(m["validator_address"], "validator_address")
+	if d.err != nil {
+		return nil, d.err
+	}
+	return vote, nil
+}
+
+//-------------------------------------
+// A minimal CBOR codec, supporting integers, byte and text strings, arrays
+// and maps with text keys, all of definite length.
+
+const (
+	cborUint   byte = 0
+	cborNegInt byte = 1
+	cborBytes  byte = 2
+	cborText   byte = 3
+	cborArray  byte = 4
+	cborMap    byte = 5
+
+	// cborMaxDepth is the maximum nesting of decoded arrays and maps.
+	cborMaxDepth = 4
+)
+
+type cborWriter struct {
+	buf []byte
+}
+
+// writeHeader writes the initial bytes of an item, using the shortest
+// encoding of arg.
+func (w *cborWriter) writeHeader(major byte, arg uint64) {
+	major <<= 5
+	switch {
+	case arg < 24:
+		w.buf = append(w.buf, major|byte(arg))
+	case arg <= math.MaxUint8:
+		w.buf = append(w.buf, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		w.buf = append(w.buf, major|25, 0, 0)
+		binary.BigEndian.PutUint16(w.buf[len(w.buf)-2:], uint16(arg))
+	case arg <= math.MaxUint32:
+		w.buf = append(w.buf, major|26, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(w.buf[len(w.buf)-4:], uint32(arg))
+	default:
+		w.buf = append(w.buf, major|27, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(w.buf[len(w.buf)-8:], arg)
+	}
+}
+
+func (w *cborWriter) writeInt(i int64) {
+	if i >= 0 {
+		w.writeHeader(cborUint, uint64(i))
+	} else {
+		w.writeHeader(cborNegInt, uint64(-1-i))
+	}
+}
+
+func (w *cborWriter) writeBytes(bz []byte) {
+	w.writeHeader(cborBytes, uint64(len(bz)))
+	w.buf = append(w.buf, bz...)
+}
+
+func (w *cborWriter) writeString(s string) {
+	w.writeHeader(cborText, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+type cborReader struct {
+	buf []byte
+}
+
+func (r *cborReader) readHeader() (major byte, arg uint64, err error) {
+	if len(r.buf) == 0 {
+		return 0, 0, errors.New("unexpected end of input")
+	}
+	major, info := r.buf[0]>>5, r.buf[0]&0x1f
+	r.buf = r.buf[1:]
+	if info < 24 {
+		return major, uint64(info), nil
+	}
+	if info > 27 {
+		return 0, 0, fmt.Errorf("unsupported additional information %d", info)
+	}
+	n := 1 << (info - 24)
+	if len(r.buf) < n {
+		return 0, 0, errors.New("unexpected end of input")
+	}
+	for _, b := range r.buf[:n] {
+		arg = arg<<8 | uint64(b)
+	}
+	r.buf = r.buf[n:]
+	return major, arg, nil
+}
+
+// readValue decodes the next item as an uint64, int64 (negative integers
+// only), []byte, string, []interface{} or map[string]interface{}.
+func (r *cborReader) readValue(depth int) (interface{}, error) {
+	major, arg, err := r.readHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUint:
+		return arg, nil
+	case cborNegInt:
+		if arg > math.MaxInt64 {
+			return nil, errors.New("negative integer overflows int64")
+		}
+		return -1 - int64(arg), nil
+	case cborBytes, cborText:
+		if arg > uint64(len(r.buf)) {
+			return nil, errors.New("unexpected end of input")
+		}
+		bz := r.buf[:arg]
+		r.buf = r.buf[arg:]
+		if major == cborText {
+			return string(bz), nil
+		}
+		return append([]byte(nil), bz...), nil
+	case cborArray, cborMap:
+		if depth >= cborMaxDepth {
+			return nil, errors.New("max nesting depth exceeded")
+		}
+		// each item takes at least a byte
+		if arg > uint64(len(r.buf)) {
+			return nil, errors.New("unexpected end of input")
+		}
+		if major == cborArray {
+			arr := make([]interface{}, arg)
+			for i := range arr {
+				if arr[i], err = r.readValue(depth + 1); err != nil {
+					return nil, err
+				}
+			}
+			return arr, nil
+		}
+		m := make(map[string]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := r.readValue(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("map key must be a text string, got %T", key)
+			}
+			if _, ok := m[k]; ok {
+				return nil, fmt.Errorf("duplicate map key %q", k)
+			}
+			if m[k], err = r.readValue(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("unsupported major type %d", major)
+	}
+}
+
+// cborFields returns val as a map, erroring unless it has exactly the given
+// keys.
+func cborFields(val interface{}, keys ...string) (map[string]interface{}, error) {
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a map, got %T", val)
+	}
+	if len(m) != len(keys) {
+		return nil, fmt.Errorf("expected %d keys, got %d", len(keys), len(m))
+	}
+	for _, key := range keys {
+		if _, ok := m[key]; !ok {
+			return nil, fmt.Errorf("missing key %q", key)
+		}
+	}
+	return m, nil
+}
+
+// cborFieldDecoder converts decoded values, keeping the first error.
+type cborFieldDecoder struct {
+	err error
+}
+
+func (d *cborFieldDecoder) int(val interface{}, name string, min, max int64) int64 {
+	if d.err != nil {
+		return 0
+	}
+	var i int64
+	switch v := val.(type) {
+	case uint64:
+		if v > math.MaxInt64 {
+			d.err = fmt.Errorf("%s: integer overflows int64", name)
+			return 0
+		}
+		i = int64(v)
+	case int64:
+		i = v
+	default:
+		d.err = fmt.Errorf("%s: expected an integer, got %T", name, val)
+		return 0
+	}
+	if i < min || i > max {
+		d.err = fmt.Errorf("%s: %d is out of range [%d, %d]", name, i, min, max)
+		return 0
+	}
+	return i
+}
+
+func (d *cborFieldDecoder) bytes(val interface{}, name string) []byte {
+	if d.err != nil {
+		return nil
+	}
+	bz, ok := val.([]byte)
+	if !ok {
+		d.err = fmt.Errorf("%s: expected a byte string, got %T", name, val)
+		return nil
+	}
+	if len(bz) == 0 {
+		return nil
+	}
+	return bz
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestVoteCBORRoundTrip(t *testing.T) {
+	nilVote := examplePrevote()
+	nilVote.BlockID = BlockID{}
+
+	for _, vote := range []*Vote{examplePrecommit(), nilVote, {}} {
+		vote.Signature = []byte("signature")
+		bz, err := VoteToCBOR(vote)
+		require.NoError(t, err)
+
+		decoded, err := VoteFromCBOR(bz)
+		require.NoError(t, err)
+		assert.Equal(t, vote, decoded)
+	}
+
+	_, err := VoteToCBOR(nil)
+	assert.Error(t, err)
+}
+
+// TestVoteCBORGolden pins the CBOR encoding of a fixed vote, which clients
+// in other languages rely on.
+func TestVoteCBORGolden(t *testing.T) {
+	vote := examplePrecommit()
+	vote.Signature = []byte("signature")
+
+	bz, err := VoteToCBOR(vote)
+	require.NoError(t, err)
+	const expected = "a864747970650265726f756e64026668656967687419303968626c6f636b5f6964a2646861736858208b" +
+		"01023386c371778ecb6368573e539afc3cc860ec3a2f614e54fe5652f4fc80657061727473a26468617368582072db" +
+		"3d959635dff1bb567bedaa70573392c5159666a3f8caf11e413aac52207a65746f74616c1a000f4240697369676e61" +
+		"74757265497369676e61747572656974696d657374616d70821a5a4069b11a0df28e806f76616c696461746f725f69" +
+		"6e64657819ddd57176616c696461746f725f61646472657373546af1f4111082efb388211bc72c55bcd61e9ac3d5"
+	assert.Equal(t, expected, hex.EncodeToString(bz))
+}
+
+func TestVoteFromCBORErrors(t *testing.T) {
+	valid, err := VoteToCBOR(examplePrecommit())
+	require.NoError(t, err)
+
+	// encode builds a map with the given entries, in order
+	encode := func(entries ...interface{}) []byte {
+		var w cborWriter
+		w.writeHeader(cborMap, uint64(len(entries)/2))
+		for i := 0; i < len(entries); i += 2 {
+			w.writeString(entries[i].(string))
+			w.buf = append(w.buf, entries[i+1].([]byte)...)
+		}
+		return w.buf
+	}
+	item := func(f func(w *cborWriter)) []byte {
+		var w cborWriter
+		f(&w)
+		return w.buf
+	}
+	intItem := func(i int64) []byte { return item(func(w *cborWriter) { w.writeInt(i) }) }
+	bytesItem := func(bz []byte) []byte { return item(func(w *cborWriter) { w.writeBytes(bz) }) }
+	blockID := encode("hash", bytesItem(nil), "parts", encode("hash", bytesItem(nil), "total", intItem(0)))
+	timestamp := item(func(w *cborWriter) {
+		w.writeHeader(cborArray, 2)
+		w.writeInt(0)
+		w.writeInt(0)
+	})
+	fields := func(typ []byte) []interface{} {
+		return []interface{}{
+			"validator_address", bytesItem(nil),
+			"validator_index", intItem(0),
+			"timestamp", timestamp,
+			"signature", bytesItem(nil),
+			"block_id", blockID,
+			"height", intItem(1),
+			"round", intItem(0),
+			"type", typ,
+		}
+	}
+
+	// keys in another order are fine
+	vote, err := VoteFromCBOR(encode(fields(intItem(int64(tmproto.PrevoteType)))...))
+	require.NoError(t, err)
+	assert.Equal(t, tmproto.PrevoteType, vote.Type)
+	assert.EqualValues(t, 1, vote.Height)
+
+	testCases := map[string][]byte{
+		"empty":             {},
+		"truncated":         valid[:len(valid)-1],
+		"trailing bytes":    append(append([]byte(nil), valid...), 0),
+		"not a map":         intItem(1),
+		"missing key":       encode(fields(intItem(1))[2:]...),
+		"unknown key":       encode(append(fields(intItem(1)), "foo", intItem(1))...),
+		"duplicate key":     encode(append(fields(intItem(1))[2:], "type", intItem(1))...),
+		"wrong type":        encode(fields(bytesItem([]byte{1}))...),
+		"out of range":      encode(fields(intItem(-1))...),
+		"indefinite length": {0xbf},
+	}
+	for name, bz := range testCases {
+		_, err := VoteFromCBOR(bz)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"fmt"
+
+	tmsync "github.com/tendermint/tendermint/libs/sync"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// VotePool stores the votes received from any height, round and validator,
+// detecting equivocations as votes are added. Unlike VoteSet, it doesn't know
+// the validator set, so the signatures of the votes are not verified.
+//
+// VotePool is safe for concurrent use.
+type VotePool struct {
+	mtx   tmsync.Mutex
+	votes map[votePoolKey]*Vote
+}
+
+type votePoolKey struct {
+	address string
+	height  int64
+	round   int32
+	typ     tmproto.SignedMsgType
+}
+
+// NewVotePool returns an empty VotePool.
+func NewVotePool() *VotePool {
+	return &VotePool{votes: make(map[votePoolKey]*Vote)}
+}
+
+// Add adds the vote to the pool. If the validator already voted for another
+// block at the same height, round and type, the first vote is kept and new
+// DuplicateVoteEvidence is returned. Adding a vote for the same block again,
+// even with another timestamp (see Vote.EqualExceptTimestamp), is a no-op. An error is returned if the vote fails ValidateBasic.
+//
+// The evidence time is the latest timestamp of the two votes, as the time of
+// the block at that height is not known.
+func (vp *VotePool) Add(v *Vote) (evidence *DuplicateVoteEvidence, err error) {
+	if v == nil {
+		return nil, ErrVoteNil
+	}
+	if err := v.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid vote: %w", err)
+	}
+
+	key := votePoolKey{
+		address: string(v.ValidatorAddress),
+		height:  v.Height,
+		round:   v.Round,
+		typ:     v.Type,
+	}
+
+	vp.mtx.Lock()
+	defer vp.mtx.Unlock()
+
+	existing, ok := vp.votes[key]
+	if !ok {
+		vp.votes[key] = v
+		return nil, nil
+	}
+	// the same decision, maybe signed again with another timestamp
+	if existing.EqualExceptTimestamp(v) || existing.BlockID.Equals(v.BlockID) {
+		return nil, nil
+	}
+
+	evTime := existing.Timestamp
+	if v.Timestamp.After(evTime) {
+		evTime = v.Timestamp
+	}
+	return NewDuplicateVoteEvidence(existing, v, evTime), nil
+}
+
+// Size returns the number of votes in the pool.
+func (vp *VotePool) Size() int {
+	vp.mtx.Lock()
+	defer vp.mtx.Unlock()
+	return len(vp.votes)
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestVotePool(t *testing.T) {
+	const chainID = "mychain"
+	var (
+		val, val2 = NewMockPV(), NewMockPV()
+		blockID   = makeBlockIDRandom()
+		blockID2  = makeBlockIDRandom()
+		vp        = NewVotePool()
+	)
+
+	// agreeing votes from two validators
+	vote := makeVote(t, val, chainID, 0, 10, 1, 2, blockID, defaultVoteTime)
+	ev, err := vp.Add(vote)
+	require.NoError(t, err)
+	assert.Nil(t, ev)
+	ev, err = vp.Add(makeVote(t, val2, chainID, 1, 10, 1, 2, blockID, defaultVoteTime))
+	require.NoError(t, err)
+	assert.Nil(t, ev)
+
+	// the same vote twice
+	ev, err = vp.Add(vote)
+	require.NoError(t, err)
+	assert.Nil(t, ev)
+	ev, err = vp.Add(makeVote(t, val, chainID, 0, 10, 1, 2, blockID, defaultVoteTime.Add(time.Minute)))
+	require.NoError(t, err)
+	assert.Nil(t, ev, "a timestamp-only difference isn't an equivocation")
+	assert.Equal(t, 2, vp.Size())
+
+	// votes for another block in another round or of another type
+	ev, err = vp.Add(makeVote(t, val, chainID, 0, 10, 2, 2, blockID2, defaultVoteTime))
+	require.NoError(t, err)
+	assert.Nil(t, ev)
+	ev, err = vp.Add(makeVote(t, val, chainID, 0, 10, 1, 1, blockID2, defaultVoteTime))
+	require.NoError(t, err)
+	assert.Nil(t, ev)
+	assert.Equal(t, 4, vp.Size())
+
+	// conflicting votes
+	conflicting := makeVote(t, val, chainID, 0, 10, 1, 2, blockID2, defaultVoteTime.Add(1*time.Second))
+	ev, err = vp.Add(conflicting)
+	require.NoError(t, err)
+	require.NotNil(t, ev)
+	assert.Equal(t, NewDuplicateVoteEvidence(vote, conflicting, conflicting.Timestamp), ev)
+	assert.NoError(t, ev.ValidateBasic())
+	pubKey, err := val.GetPubKey()
+	require.NoError(t, err)
+	assert.NoError(t, ev.Verify(chainID, pubKey))
+	assert.Equal(t, 4, vp.Size())
+
+	// invalid votes
+	_, err = vp.Add(nil)
+	assert.Equal(t, ErrVoteNil, err)
+	_, err = vp.Add(&Vote{Type: tmproto.PrevoteType, Height: -1})
+	assert.Error(t, err)
+}
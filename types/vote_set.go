@@ -56,7 +56,8 @@ type P2PID string
 	told us to track that block, each peer only gets to tell us 1 such block, and,
 	there's only a limited number of peers.
 
-	NOTE: Assumes that the sum total of voting power does not exceed MaxUInt64.
+	NOTE: Assumes that the sum total of voting power does not exceed MaxTotalVotingPower.
+	AddVote returns ErrVotingPowerOverflow instead of wrapping sums around.
 */
 type VoteSet struct {
 	chainID       string
@@ -136,7 +137,8 @@ func (voteSet *VoteSet) Size() int {
 //		UnexpectedStep | InvalidIndex | InvalidAddress |
 //		InvalidSignature | InvalidBlockHash | ConflictingVotes ]
 // Duplicate votes return added=false, err=nil.
-// Conflicting votes return added=*, err=ErrVoteConflictingVotes.
+// Conflicting votes return added=*, err=*ErrVoteConflictingVotes, carrying both
+// votes.
 // NOTE: vote should not be mutated after adding.
 // NOTE: VoteSet must not be nil
 // NOTE: Vote must not be nil
@@ -150,6 +152,22 @@ func (voteSet *VoteSet) AddVote(vote *Vote) (added bool, err error) {
 	return voteSet.addVote(vote)
 }
 
+// AddVoteCheckTwoThirdsMajority is like AddVote, but also reports whether the
+// set has a +2/3 majority after the vote was processed. Both are done under a
+// single lock, so the caller can stop collecting votes as soon as maj23 is
+// true without a separate HasTwoThirdsMajority call.
+// NOTE: VoteSet must not be nil
+func (voteSet *VoteSet) AddVoteCheckTwoThirdsMajority(vote *Vote) (added, maj23 bool, err error) {
+	if voteSet == nil {
+		panic("AddVoteCheckTwoThirdsMajority() on nil VoteSet")
+	}
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+
+	added, err = voteSet.addVote(vote)
+	return added, voteSet.maj23 != nil, err
+}
+
 // NOTE: Validates as much as possible before attempting to verify the signature.
 func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	if vote == nil {
@@ -204,6 +222,14 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 		return false, fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
 	}
 
+	// Make sure the tallies don't overflow.
+	if _, overflow := safeAdd(voteSet.sum, val.VotingPower); overflow {
+		return false, ErrVotingPowerOverflow
+	}
+	if _, overflow := safeAdd(voteSet.votesByBlock[blockKey].getSum(), val.VotingPower); overflow {
+		return false, ErrVotingPowerOverflow
+	}
+
 	// Add vote and get conflicting vote if any.
 	added, conflicting := voteSet.addVerifiedVote(vote, blockKey, val.VotingPower)
 	if conflicting != nil {
@@ -634,6 +660,13 @@ func (vs *blockVotes) addVerifiedVote(vote *Vote, votingPower int64) {
 	}
 }
 
+func (vs *blockVotes) getSum() int64 {
+	if vs == nil {
+		return 0
+	}
+	return vs.sum
+}
+
 func (vs *blockVotes) getByIndex(index int32) *Vote {
 	if vs == nil {
 		return nil
@@ -2,6 +2,8 @@ package types
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,6 +47,47 @@ func TestVoteSet_AddVote_Good(t *testing.T) {
 	assert.False(t, ok || !blockID.IsZero(), "there should be no 2/3 majority")
 }
 
+func TestVoteSet_AddVote_Result(t *testing.T) {
+	height, round := int64(1), int32(0)
+	voteSet, _, privValidators := randVoteSet(height, round, tmproto.PrevoteType, 10, 1)
+	val0 := privValidators[0]
+
+	val0p, err := val0.GetPubKey()
+	require.NoError(t, err)
+
+	vote := &Vote{
+		ValidatorAddress: val0p.Address(),
+		ValidatorIndex:   0,
+		Height:           height,
+		Round:            round,
+		Type:             tmproto.PrevoteType,
+		Timestamp:        tmtime.Now(),
+		BlockID:          BlockID{Hash: tmrand.Bytes(32), PartSetHeader: PartSetHeader{Total: 1, Hash: tmrand.Bytes(32)}},
+	}
+
+	// new vote
+	added, err := signAddVote(val0, vote, voteSet)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	// duplicate
+	added, err = voteSet.AddVote(vote)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	// conflicting vote
+	conflicting := withBlockHash(vote, tmrand.Bytes(32))
+	added, err = signAddVote(val0, conflicting, voteSet)
+	assert.False(t, added)
+	var conflictErr *ErrVoteConflictingVotes
+	require.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &conflictErr), err)
+	assert.Equal(t, vote, conflictErr.VoteA)
+	assert.Equal(t, conflicting, conflictErr.VoteB)
+	ev := NewDuplicateVoteEvidence(conflictErr.VoteA, conflictErr.VoteB, vote.Timestamp)
+	assert.NoError(t, ev.ValidateBasic())
+	assert.NoError(t, ev.Verify(voteSet.ChainID(), val0p))
+}
+
 func TestVoteSet_AddVote_Bad(t *testing.T) {
 	height, round := int64(1), int32(0)
 	voteSet, _, privValidators := randVoteSet(height, round, tmproto.PrevoteType, 10, 1)
@@ -170,6 +213,82 @@ func TestVoteSet_2_3Majority(t *testing.T) {
 	}
 }
 
+func TestVoteSet_AddVoteCheckTwoThirdsMajority(t *testing.T) {
+	height, round := int64(1), int32(0)
+	voteSet, _, privValidators := randVoteSet(height, round, tmproto.PrecommitType, 7, 1)
+
+	blockHash := tmrand.Bytes(32)
+	voteProto := &Vote{
+		ValidatorAddress: nil, // NOTE: must fill in
+		ValidatorIndex:   -1,  // NOTE: must fill in
+		Height:           height,
+		Round:            round,
+		Type:             tmproto.PrecommitType,
+		Timestamp:        tmtime.Now(),
+		BlockID:          BlockID{blockHash, PartSetHeader{}},
+	}
+
+	for i := int32(0); i < 7; i++ {
+		pubKey, err := privValidators[i].GetPubKey()
+		require.NoError(t, err)
+		vote := withValidator(voteProto, pubKey.Address(), i)
+		v := vote.ToProto()
+		require.NoError(t, privValidators[i].SignVote(voteSet.ChainID(), v))
+		vote.Signature = v.Signature
+
+		added, maj23, err := voteSet.AddVoteCheckTwoThirdsMajority(vote)
+		require.NoError(t, err)
+		assert.True(t, added)
+		// 5 out of 7 is the first count above 2/3
+		assert.Equal(t, i >= 4, maj23, "vote #%d", i+1)
+		assert.Equal(t, maj23, voteSet.HasTwoThirdsMajority(), "vote #%d", i+1)
+
+		// re-adding the same vote is a no-op
+		added, maj23Again, err := voteSet.AddVoteCheckTwoThirdsMajority(vote)
+		require.NoError(t, err)
+		assert.False(t, added)
+		assert.Equal(t, maj23, maj23Again)
+	}
+
+	blockID, ok := voteSet.TwoThirdsMajority()
+	assert.True(t, ok)
+	assert.Equal(t, blockHash, []byte(blockID.Hash))
+}
+
+func TestVoteSet_SumNearMaxTotalVotingPower(t *testing.T) {
+	const (
+		height, round = int64(1), int32(0)
+		power         = MaxTotalVotingPower / 3
+	)
+	privValidators := []PrivValidator{NewMockPV(), NewMockPV(), NewMockPV()}
+	vals := make([]*Validator, len(privValidators))
+	for i, pv := range privValidators {
+		vals[i] = pv.(MockPV).ExtractIntoValidator(power)
+	}
+	valSet := NewValidatorSet(vals)
+	voteSet := NewVoteSet("test_chain_id", height, round, tmproto.PrecommitType, valSet)
+
+	voteProto := &Vote{
+		Height:    height,
+		Round:     round,
+		Type:      tmproto.PrecommitType,
+		Timestamp: tmtime.Now(),
+		BlockID:   BlockID{tmrand.Bytes(32), PartSetHeader{}},
+	}
+	for _, pv := range privValidators {
+		pubKey, err := pv.GetPubKey()
+		require.NoError(t, err)
+		idx, _ := valSet.GetByAddress(pubKey.Address())
+		added, err := signAddVote(pv, withValidator(voteProto, pubKey.Address(), idx), voteSet)
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+
+	assert.EqualValues(t, 3*power, voteSet.sum)
+	assert.EqualValues(t, 3*power, voteSet.votesByBlock[voteProto.BlockID.Key()].sum)
+	assert.True(t, voteSet.HasTwoThirdsMajority())
+}
+
 func TestVoteSet_2_3MajorityRedux(t *testing.T) {
 	height, round := int64(1), int32(0)
 	voteSet, _, privValidators := randVoteSet(height, round, tmproto.PrevoteType, 100, 1)
@@ -1,7 +1,11 @@
 package types
 
 import (
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,8 +16,10 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/protoio"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 func examplePrevote() *Vote {
@@ -137,6 +143,63 @@ func TestVoteSignBytesTestVectors(t *testing.T) {
 	}
 }
 
+// TestVoteSignBytesGolden pins the sign bytes of a fully populated vote, so
+// that signers in other languages can check their canonical encoding against
+// it.
+func TestVoteSignBytesGolden(t *testing.T) {
+	const want = "7C0802113930000000000000190200000000000000224A0A208B01023386C371778ECB6368573E539AFC3C" +
+		"C860EC3A2F614E54FE5652F4FC80122608C0843D122072DB3D959635DFF1BB567BEDAA70573392C5159666A3F8" +
+		"CAF11E413AAC52207A2A0B08B1D381D20510809DCA6F320D746573745F636861696E5F6964"
+
+	got := VoteSignBytes("test_chain_id", examplePrecommit().ToProto())
+	assert.Equal(t, want, strings.ToUpper(hex.EncodeToString(got)))
+}
+
+// TestVoteExtensionSignBytesGolden pins the sign bytes of the extension of a
+// precommit: the canonical vote, followed by the extension as field 8.
+func TestVoteExtensionSignBytesGolden(t *testing.T) {
+	const want = "87010802113930000000000000190200000000000000224A0A208B01023386C371778ECB6368573E539AFC3C" +
+		"C860EC3A2F614E54FE5652F4FC80122608C0843D122072DB3D959635DFF1BB567BEDAA70573392C5159666A3F8" +
+		"CAF11E413AAC52207A2A0B08B1D381D20510809DCA6F320D746573745F636861696E5F69644209657874656E73696F6E"
+
+	vote := examplePrecommit()
+	vote.Extension = []byte("extension")
+	got := VoteExtensionSignBytes("test_chain_id", vote)
+	assert.Equal(t, want, strings.ToUpper(hex.EncodeToString(got)))
+
+	// the extension isn't part of the sign bytes of the vote
+	assert.Equal(t, VoteSignBytes("test_chain_id", examplePrecommit().ToProto()),
+		VoteSignBytes("test_chain_id", vote.ToProto()))
+}
+
+func TestVoteCopy(t *testing.T) {
+	vote := examplePrecommit()
+	vote.Signature = []byte("signature")
+	vote.Extension = []byte("extension")
+	vote.ExtensionSignature = []byte("extension signature")
+	orig := *vote
+	orig.BlockID.Hash = copyBytes(vote.BlockID.Hash)
+	orig.BlockID.PartSetHeader.Hash = copyBytes(vote.BlockID.PartSetHeader.Hash)
+	orig.ValidatorAddress = copyBytes(vote.ValidatorAddress)
+	orig.Signature = copyBytes(vote.Signature)
+	orig.Extension = copyBytes(vote.Extension)
+	orig.ExtensionSignature = copyBytes(vote.ExtensionSignature)
+
+	voteCopy := vote.Copy()
+	require.Equal(t, vote, voteCopy)
+
+	// mutate the copy's slices in place
+	voteCopy.BlockID.Hash[0] ^= 0xff
+	voteCopy.BlockID.PartSetHeader.Hash[0] ^= 0xff
+	voteCopy.ValidatorAddress[0] ^= 0xff
+	voteCopy.Signature[0] ^= 0xff
+	voteCopy.Extension[0] ^= 0xff
+	voteCopy.ExtensionSignature[0] ^= 0xff
+
+	assert.Equal(t, &orig, vote)
+	assert.NotEqual(t, vote, voteCopy)
+}
+
 func TestVoteProposalNotEq(t *testing.T) {
 	cv := CanonicalizeVote("", &tmproto.Vote{Height: 1, Round: 1})
 	p := CanonicalizeProposal("", &tmproto.Proposal{Height: 1, Round: 1})
@@ -187,6 +250,10 @@ func TestIsVoteTypeValid(t *testing.T) {
 		{"Prevote", tmproto.PrevoteType, true},
 		{"Precommit", tmproto.PrecommitType, true},
 		{"InvalidType", tmproto.SignedMsgType(0x3), false},
+		{"Unknown", tmproto.UnknownType, false},
+		{"Proposal", tmproto.ProposalType, false},
+		{"Arbitrary", tmproto.SignedMsgType(0x7f), false},
+		{"Negative", tmproto.SignedMsgType(-1), false},
 	}
 
 	for _, tt := range tc {
@@ -199,6 +266,65 @@ func TestIsVoteTypeValid(t *testing.T) {
 	}
 }
 
+func TestVoteValidateBasicType(t *testing.T) {
+	vote := examplePrecommit()
+	vote.Signature = []byte{0x01}
+	require.NoError(t, vote.ValidateBasic())
+
+	vote.Type = tmproto.ProposalType
+	assert.Error(t, vote.ValidateBasic(), "a proposal type is not a valid vote type")
+
+	vote.Type = tmproto.SignedMsgType(0x3)
+	assert.Error(t, vote.ValidateBasic())
+}
+
+func TestVoteSignBytesVersion(t *testing.T) {
+	const chainID = "test_chain_id"
+	v := examplePrecommit().ToProto()
+
+	// the layout hasn't changed in any version so far
+	for blockVersion := uint64(0); blockVersion <= version.BlockProtocol; blockVersion++ {
+		bz, err := VoteSignBytesVersion(chainID, v, blockVersion)
+		require.NoError(t, err)
+		assert.Equal(t, VoteSignBytes(chainID, v), bz, blockVersion)
+	}
+
+	// the layout of future versions is unknown
+	_, err := VoteSignBytesVersion(chainID, v, version.BlockProtocol+1)
+	assert.Error(t, err)
+}
+
+func TestVoteContentHash(t *testing.T) {
+	const chainID = "test_chain_id"
+	var (
+		val1, val2 = NewMockPV(), NewMockPV()
+		blockID    = makeBlockIDRandom()
+	)
+	vote1 := makeVote(t, val1, chainID, 0, 10, 1, 2, blockID, defaultVoteTime)
+	vote2 := makeVote(t, val2, chainID, 1, 10, 1, 2, blockID, defaultVoteTime.Add(time.Second))
+	assert.Equal(t, vote1.ContentHash(chainID), vote2.ContentHash(chainID))
+	assert.Len(t, vote1.ContentHash(chainID), tmhash.Size)
+
+	testCases := []struct {
+		name    string
+		vote    *Vote
+		chainID string
+	}{
+		{"other block", makeVote(t, val2, chainID, 1, 10, 1, 2, makeBlockIDRandom(), defaultVoteTime), chainID},
+		{"nil", makeVote(t, val2, chainID, 1, 10, 1, 2, BlockID{}, defaultVoteTime), chainID},
+		{"other height", makeVote(t, val2, chainID, 1, 11, 1, 2, blockID, defaultVoteTime), chainID},
+		{"other round", makeVote(t, val2, chainID, 1, 10, 2, 2, blockID, defaultVoteTime), chainID},
+		{"other type", makeVote(t, val2, chainID, 1, 10, 1, 1, blockID, defaultVoteTime), chainID},
+		{"other chain", vote2, "other_chain_id"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NotEqual(t, vote1.ContentHash(chainID), tc.vote.ContentHash(tc.chainID))
+		})
+	}
+}
+
 func TestVoteVerify(t *testing.T) {
 	privVal := NewMockPV()
 	pubkey, err := privVal.GetPubKey()
@@ -218,6 +344,141 @@ func TestVoteVerify(t *testing.T) {
 	}
 }
 
+func TestVoteVerifyExtension(t *testing.T) {
+	const chainID = "test_chain_id"
+	privVal := NewMockPV()
+	pubkey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	vote := examplePrecommit()
+	vote.ValidatorAddress = pubkey.Address()
+	vote.ValidatorIndex = 0
+	vote.Extension = []byte("price=42")
+	v := vote.ToProto()
+	require.NoError(t, privVal.SignVote(chainID, v))
+	vote.Signature = v.Signature
+	require.NoError(t, privVal.SignVoteExtension(chainID, vote))
+	require.NoError(t, vote.ValidateBasic())
+	require.NoError(t, vote.Verify(chainID, pubkey))
+
+	tampered := vote.Copy()
+	tampered.Extension[0] ^= 0xff
+	assert.Equal(t, ErrVoteInvalidSignature, tampered.Verify(chainID, pubkey))
+	unsigned := vote.Copy()
+	unsigned.ExtensionSignature = nil
+	assert.Equal(t, ErrVoteInvalidSignature, unsigned.Verify(chainID, pubkey))
+	swapped := vote.Copy()
+	swapped.Signature, swapped.ExtensionSignature = vote.ExtensionSignature, vote.Signature
+	assert.Equal(t, ErrVoteInvalidSignature, swapped.Verify(chainID, pubkey))
+
+	// the signature of the vote still verifies from a commit, which doesn't
+	// include the extension
+	commit := NewCommit(vote.Height, vote.Round, vote.BlockID, []CommitSig{vote.CommitSig()})
+	valSet := NewValidatorSet([]*Validator{NewValidator(pubkey, 10)})
+	assert.NoError(t, valSet.VerifyCommit(chainID, vote.BlockID, vote.Height, commit))
+
+	// the extension is kept by the protobuf encoding, e.g. when gossiping the
+	// vote
+	decoded, err := VoteFromProto(vote.ToProto())
+	require.NoError(t, err)
+	assert.Equal(t, vote, decoded)
+	assert.NoError(t, decoded.Verify(chainID, pubkey))
+
+	// but not by evidence
+	ev := NewFutureHeightEvidence(vote, vote.Height-1, vote.Timestamp)
+	assert.Nil(t, ev.Vote.Extension)
+	assert.Nil(t, ev.Vote.ExtensionSignature)
+	assert.NotNil(t, vote.Extension)
+	assert.NoError(t, ev.ValidateBasic())
+	assert.NoError(t, ev.Verify(chainID, pubkey))
+	ev.Vote = vote
+	err = ev.ValidateBasic()
+	assert.True(t, errors.Is(err, ErrEvidenceVoteExtension), err)
+}
+
+func TestVoteVerifyFromAllowed(t *testing.T) {
+	const chainID = "test_chain_id"
+	privVal := NewMockPV()
+	pubkey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	other := ed25519.GenPrivKey().PubKey()
+
+	vote := examplePrevote()
+	vote.ValidatorAddress = pubkey.Address()
+	v := vote.ToProto()
+	require.NoError(t, privVal.SignVote(chainID, v))
+	vote.Signature = v.Signature
+
+	assert.NoError(t, vote.VerifyFromAllowed(chainID, []crypto.PubKey{other, pubkey}))
+	assert.Equal(t, ErrVoteFromUnlistedValidator, vote.VerifyFromAllowed(chainID, []crypto.PubKey{other}))
+	assert.Equal(t, ErrVoteFromUnlistedValidator, vote.VerifyFromAllowed(chainID, nil))
+
+	vote.Signature[0] ^= 0x01
+	assert.Equal(t, ErrVoteInvalidSignature, vote.VerifyFromAllowed(chainID, []crypto.PubKey{other, pubkey}))
+}
+
+func TestVoteEqualExceptTimestamp(t *testing.T) {
+	vote := examplePrevote()
+	otherTime := vote.Copy()
+	otherTime.Timestamp = vote.Timestamp.Add(time.Minute)
+	otherTime.Signature = []byte("other signature")
+	otherBlock := vote.Copy()
+	otherBlock.BlockID = makeBlockIDRandom()
+	otherIndex := vote.Copy()
+	otherIndex.ValidatorIndex++
+
+	assert.True(t, vote.EqualExceptTimestamp(vote.Copy()))
+	assert.True(t, vote.EqualExceptTimestamp(otherTime))
+	assert.False(t, vote.EqualExceptTimestamp(otherBlock))
+	assert.False(t, vote.EqualExceptTimestamp(otherIndex))
+	assert.False(t, vote.EqualExceptTimestamp(nil))
+	assert.True(t, (*Vote)(nil).EqualExceptTimestamp(nil))
+}
+
+func TestIsEquivocation(t *testing.T) {
+	vote := examplePrevote()
+	otherBlock := vote.Copy()
+	otherBlock.BlockID = makeBlockIDRandom()
+	otherValidator := otherBlock.Copy()
+	otherValidator.ValidatorAddress = crypto.AddressHash([]byte("other_validator"))
+	otherHeight := otherBlock.Copy()
+	otherHeight.Height++
+	otherType := otherBlock.Copy()
+	otherType.Type = tmproto.PrecommitType
+	otherTime := vote.Copy()
+	otherTime.Timestamp = otherTime.Timestamp.Add(time.Minute)
+	otherExtension := vote.Copy()
+	otherExtension.Extension = []byte("extension")
+
+	testCases := []struct {
+		name   string
+		v2     *Vote
+		expOk  bool
+		expErr error
+	}{
+		{"equivocation", otherBlock, true, nil},
+		{"identical", vote.Copy(), false, ErrEvidenceSameBlockID},
+		{"different timestamp", otherTime, false, ErrEvidenceSameBlockID},
+		{"different extension", otherExtension, false, ErrEvidenceSameBlockID},
+		{"different validator", otherValidator, false, ErrEvidenceAddressMismatch},
+		{"different height", otherHeight, false, nil},
+		{"different type", otherType, false, nil},
+		{"nil", nil, false, ErrVoteNil},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := IsEquivocation(vote, tc.v2)
+			assert.Equal(t, tc.expOk, ok)
+			if tc.expErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tc.expErr), err)
+			}
+		})
+	}
+}
+
 func TestMaxVoteBytes(t *testing.T) {
 	// time is varint encoded so need to pick the max.
 	// year int, month Month, day, hour, min, sec, nsec int, loc *Location
@@ -264,6 +525,24 @@ func TestVoteString(t *testing.T) {
 	}
 }
 
+func TestVoteStringIndented(t *testing.T) {
+	vote := examplePrecommit()
+	vote.Signature = []byte{0xde, 0xad, 0xbe, 0xef}
+
+	compact := vote.String()
+	assert.NotContains(t, compact, "\n")
+	assert.Contains(t, compact, "12345")
+	assert.Contains(t, compact, fmt.Sprintf("%X", tmbytes.Fingerprint(vote.BlockID.Hash)))
+	assert.NotContains(t, compact, vote.BlockID.Hash.String())
+
+	indented := vote.StringIndented("  ")
+	assert.Contains(t, indented, "\n    Height:           12345\n")
+	assert.Contains(t, indented, vote.BlockID.Hash.String())
+	assert.Contains(t, indented, "DEADBEEF")
+
+	assert.Equal(t, nilVoteStr, (*Vote)(nil).StringIndented(""))
+}
+
 func TestVoteValidateBasic(t *testing.T) {
 	privVal := NewMockPV()
 
@@ -282,6 +561,27 @@ func TestVoteValidateBasic(t *testing.T) {
 		{"Invalid ValidatorIndex", func(v *Vote) { v.ValidatorIndex = -1 }, true},
 		{"Invalid Signature", func(v *Vote) { v.Signature = nil }, true},
 		{"Too big Signature", func(v *Vote) { v.Signature = make([]byte, MaxSignatureSize+1) }, true},
+		{"Extension", func(v *Vote) {
+			v.Extension = make([]byte, MaxVoteExtensionSize)
+			v.ExtensionSignature = []byte("extension signature")
+		}, false},
+		{"Too big Extension", func(v *Vote) {
+			v.Extension = make([]byte, MaxVoteExtensionSize+1)
+			v.ExtensionSignature = []byte("extension signature")
+		}, true},
+		{"Prevote Extension", func(v *Vote) {
+			v.Type = tmproto.PrevoteType
+			v.Extension = []byte("extension")
+			v.ExtensionSignature = []byte("extension signature")
+		}, true},
+		{"Missing Extension Signature", func(v *Vote) { v.Extension = []byte("extension") }, true},
+		{"Too big Extension Signature", func(v *Vote) {
+			v.Extension = []byte("extension")
+			v.ExtensionSignature = make([]byte, MaxSignatureSize+1)
+		}, true},
+		{"Extension Signature without Extension", func(v *Vote) {
+			v.ExtensionSignature = []byte("extension signature")
+		}, true},
 	}
 	for _, tc := range testCases {
 		tc := tc